@@ -8,6 +8,7 @@ import (
 	"path"
 
 	"github.com/norganna/cynosure/common"
+	"github.com/norganna/cynosure/process"
 	"google.golang.org/grpc/grpclog"
 )
 
@@ -29,6 +30,12 @@ var (
 func Boot() {
 	var err error
 
+	// Processes with listen sockets are started through the server executable so that `LISTEN_PID` can be set.
+	if len(os.Args) > 1 && os.Args[1] == process.ActivateCommand {
+		process.Activate(os.Args[2:])
+		return
+	}
+
 	log = grpclog.NewLoggerV2(os.Stdout, ioutil.Discard, ioutil.Discard)
 	grpclog.SetLoggerV2(log)
 	common.SetLogger(log)
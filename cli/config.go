@@ -88,12 +88,37 @@ func createServerConfig(folder string) string {
 			"port": {
 				Kind: "port",
 			},
+			"tcp": {
+				Kind: "tcp",
+			},
+			"unix": {
+				Kind: "unix",
+			},
+			"file": {
+				Kind: "file",
+			},
+			"dns": {
+				Kind: "dns",
+			},
 			"http": {
 				Kind: "http",
 			},
+			"grpc": {
+				Kind: "grpc",
+			},
 			"wait": {
 				Kind: "wait",
 			},
+			"exec": {
+				Kind: "exec",
+			},
+		},
+		Ports: &common.ConfigPorts{
+			Min: 20000,
+			Max: 29999,
+		},
+		Jobs: &common.ConfigJobs{
+			TTL: 3600,
 		},
 	}
 
@@ -4,7 +4,6 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/base64"
-	"encoding/pem"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -35,6 +34,18 @@ type ConfigBroker struct {
 	Config ConfigBrokerConfigs `json:"config,omitempty"`
 }
 
+// ConfigPorts specifies the range of ports that can be allocated to processes for the config file.
+type ConfigPorts struct {
+	Min int `json:"min,omitempty"`
+	Max int `json:"max,omitempty"`
+}
+
+// ConfigJobs specifies how finished jobs are handled for the config file.
+type ConfigJobs struct {
+	// TTL is the number of seconds a finished job is kept before being removed.
+	TTL int `json:"ttl,omitempty"`
+}
+
 // Config contains the config file details.
 type Config struct {
 	Server      string                   `json:"server,omitempty"`
@@ -43,6 +54,8 @@ type Config struct {
 	Authority   *ConfigCertificate       `json:"authority,omitempty"`
 	Certificate *ConfigCertificate       `json:"certificate,omitempty"`
 	Brokers     map[string]*ConfigBroker `json:"brokers,omitempty"`
+	Ports       *ConfigPorts             `json:"ports,omitempty"`
+	Jobs        *ConfigJobs              `json:"jobs,omitempty"`
 
 	log       grpclog.LoggerV2
 	auth      *tls.Certificate
@@ -62,28 +75,31 @@ func (c *Config) CertPool() (pool *x509.CertPool, err error) {
 		return c.crtPool, nil
 	}
 
-	c.crtPool = x509.NewCertPool()
-
 	var ca *x509.Certificate
-	var pemData []byte
+	var caEnc string
 	if c.Certificate != nil && c.Certificate.CA != "" {
-		pemData = Pemify("CERTIFICATE", []byte(c.Certificate.CA))
+		caEnc = c.Certificate.CA
 	} else if c.Authority != nil && c.Authority.CA != "" {
-		pemData = Pemify("CERTIFICATE", []byte(c.Authority.CA))
+		caEnc = c.Authority.CA
 	}
 
-	if len(pemData) > 0 {
-		pb, _ := pem.Decode(pemData)
-		ca, err = x509.ParseCertificate(pb.Bytes)
+	if caEnc != "" {
+		var data []byte
+		data, err = base64.RawStdEncoding.DecodeString(caEnc)
+		if err != nil {
+			return nil, Error(err, "failed to decode supplied CA")
+		}
+		ca, err = x509.ParseCertificate(data)
 		if err != nil {
 			return nil, Error(err, "failed to parse supplied CA")
 		}
-	} else if c.auth.Leaf != nil {
+	} else if c.auth != nil && c.auth.Leaf != nil {
 		ca = c.auth.Leaf
 	} else {
 		return nil, ErrorMsg("cannot find a valid CA certificate to use")
 	}
 
+	c.crtPool = x509.NewCertPool()
 	c.crtPool.AddCert(ca)
 	return c.crtPool, nil
 }
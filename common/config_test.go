@@ -0,0 +1,54 @@
+package common
+
+import (
+	"testing"
+)
+
+func TestCertPool(t *testing.T) {
+	caCert, caKey, err := CreateCACert("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth, err := LoadCertificate(caCert, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		config *Config
+		fails  bool
+	}{
+		{"certificate ca", &Config{Certificate: &ConfigCertificate{CA: caCert}}, false},
+		{"authority ca", &Config{Authority: &ConfigCertificate{CA: caCert}}, false},
+		{"loaded authority", &Config{auth: auth}, false},
+		{"no ca", &Config{}, true},
+		{"no ca in certificate", &Config{Certificate: &ConfigCertificate{}}, true},
+		{"bad encoding", &Config{Certificate: &ConfigCertificate{CA: "not base64!"}}, true},
+		{"bad certificate", &Config{Certificate: &ConfigCertificate{CA: "bm90IGEgY2VydA"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool, err := tt.config.CertPool()
+			if (err != nil) != tt.fails {
+				t.Fatalf("got error %v", err)
+			}
+			if tt.fails {
+				// A failure is not remembered as an empty pool.
+				if tt.config.crtPool != nil {
+					t.Error("failed pool was kept")
+				}
+				return
+			}
+
+			subjects := pool.Subjects()
+			if len(subjects) != 1 || string(subjects[0]) != string(auth.Leaf.RawSubject) {
+				t.Errorf("got %d subjects", len(subjects))
+			}
+			if again, _ := tt.config.CertPool(); again != pool {
+				t.Error("pool was not reused")
+			}
+		})
+	}
+}
@@ -4,3 +4,9 @@ package deps
 type Broker interface {
 	Dep(wait string) (Depender, error)
 }
+
+// NamespacedBroker is a Broker whose dependencies are relative to the namespace of the process that requires them.
+type NamespacedBroker interface {
+	Broker
+	InNamespace(namespace string) Broker
+}
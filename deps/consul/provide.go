@@ -2,11 +2,51 @@
 //
 // Accepts config:
 //    address: ADDRESS
+//    token: TOKEN
+//    datacenter: DC
+//    ca: PATH
+//    cert: PATH
+//    key: PATH
+//    insecure: "true"
 //
-// Pass the ADDRESS to the consul you wish to interrogate.
+// Pass the ADDRESS to the consul you wish to interrogate, e.g. "127.0.0.1:8500" or "https://consul:8501".
+// If no scheme is given, "https" is used when any of the TLS options are set, otherwise "http".
+//
+// The ACL TOKEN (if any) is sent with each request, and DC selects a datacenter other than the agent's own.
+// The TLS options are described in `deps.TLSConfig`.
+//
+// The "wait" parameter will be either a plain service name, or JSON of one of the forms:
+//    {"service": NAME, "tag": TAG, "passing": COUNT}
+//    {"check": CHECK, "service": NAME}
+//    {"key": KEY, "value": VALUE}
+//
+// If only a service name is supplied, it will expand to:
+//
+//    {"service": NAME}
+//
+// Default values (if none specified):
+//    passing = 1
+//
+// A service waits for at least COUNT instances (with the TAG if given) that have all of their checks passing.
+//
+// A check waits for every check with the CHECK id or name to be passing, optionally only looking at the checks of the
+// NAME service.
+//
+// A key waits for the KEY to exist in the KV store, and if a VALUE is given, for it to have exactly that value.
+//
+// Responses are cached for a couple of seconds, so many dependencies on the same thing won't all query consul.
 package consul
 
 import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/norganna/cynosure/common"
 	"github.com/norganna/cynosure/deps"
 )
@@ -14,37 +54,304 @@ import (
 // Kind contains the kind string of this provider.
 const Kind = "consul"
 
+// timeout is how long a request to consul has to complete.
+const timeout = 5 * time.Second
+
+// cacheTTL is how long a response is reused for.
+const cacheTTL = 2 * time.Second
+
 func init() {
 	deps.RegisterProvider(Kind, create)
 }
 
 // create returns the Broker.
 func create(config common.StringMap) (deps.Broker, error) {
-	b := &broker{}
 	addr := config["address"]
 	if addr == "" {
 		return nil, common.ErrorMsg("must supply a consul server api endpoint address")
 	}
 
+	tlsConfig, err := deps.TLSConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.Contains(addr, "://") {
+		if tlsConfig != nil {
+			addr = "https://" + addr
+		} else {
+			addr = "http://" + addr
+		}
+	}
+
+	b := &broker{
+		address:    strings.TrimRight(addr, "/"),
+		token:      config["token"],
+		datacenter: config["datacenter"],
+		client: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: tlsConfig,
+			},
+		},
+		responses: map[string]*response{},
+	}
+
 	return b, nil
 }
 
 type broker struct {
-	address string
+	sync.RWMutex
+
+	address    string
+	token      string
+	datacenter string
+	client     *http.Client
+
+	// responses[url]
+	responses map[string]*response
+}
+
+// response is a cached response body, which is nil if the path was not found.
+type response struct {
+	ts   time.Time
+	body []byte
+}
+
+type request struct {
+	Service string  `json:"service,omitempty"`
+	Tag     string  `json:"tag,omitempty"`
+	Passing int     `json:"passing,omitempty"`
+	Check   string  `json:"check,omitempty"`
+	Key     string  `json:"key,omitempty"`
+	Value   *string `json:"value,omitempty"`
 }
 
 func (b *broker) Dep(wait string) (deps.Depender, error) {
+	r := &request{}
+	if wait == "" {
+		return nil, common.ErrorMsg("no consul service, check or key to wait for")
+	}
+
+	if wait[0:1] != "{" {
+		r.Service = wait
+	} else {
+		err := json.Unmarshal([]byte(wait), r)
+		if err != nil {
+			return nil, common.Error(err, "failed to parse dependency condition JSON")
+		}
+	}
+
+	if r.Check == "" && r.Key == "" && r.Service == "" {
+		return nil, common.ErrorMsg("must supply one of a consul service, check or key to wait for")
+	}
+	if r.Check != "" && r.Key != "" {
+		return nil, common.ErrorMsg("cannot wait for both a consul check and key")
+	}
+	if r.Key != "" && r.Service != "" {
+		return nil, common.ErrorMsg("cannot wait for both a consul service and key")
+	}
+
+	if r.Passing < 1 {
+		r.Passing = 1
+	}
+
 	return &dep{
-		b:    b,
-		wait: wait,
+		b: b,
+		r: r,
 	}, nil
 }
 
 type dep struct {
-	b    *broker
-	wait string
+	b *broker
+	r *request
 }
 
 func (d *dep) Check() (msg string, ok bool) {
-	panic("not implemented")
+	switch {
+	case d.r.Key != "":
+		return d.checkKey()
+	case d.r.Check != "":
+		return d.checkCheck()
+	default:
+		return d.checkService()
+	}
+}
+
+// healthCheck is the part of a consul health check that is needed.
+type healthCheck struct {
+	CheckID string
+	Name    string
+	Status  string
+	Output  string
+}
+
+func (d *dep) checkService() (msg string, ok bool) {
+	msg = fmt.Sprintf("%s service %s", Kind, d.r.Service)
+
+	q := url.Values{"passing": {"true"}}
+	if d.r.Tag != "" {
+		q.Set("tag", d.r.Tag)
+	}
+
+	var entries []json.RawMessage
+	_, err := d.b.get("/v1/health/service/"+url.PathEscape(d.r.Service), q, &entries)
+	if err != nil {
+		return msg + " " + err.Error(), false
+	}
+
+	status := fmt.Sprintf(" %d/%d passing", len(entries), d.r.Passing)
+	if len(entries) < d.r.Passing {
+		return msg + status + " waiting", false
+	}
+	return msg + status, true
+}
+
+func (d *dep) checkCheck() (msg string, ok bool) {
+	msg = fmt.Sprintf("%s check %s", Kind, d.r.Check)
+
+	path := "/v1/health/state/any"
+	if d.r.Service != "" {
+		path = "/v1/health/checks/" + url.PathEscape(d.r.Service)
+	}
+
+	var checks []*healthCheck
+	_, err := d.b.get(path, nil, &checks)
+	if err != nil {
+		return msg + " " + err.Error(), false
+	}
+
+	found := 0
+	for _, check := range checks {
+		if check.CheckID != d.r.Check && check.Name != d.r.Check {
+			continue
+		}
+		found++
+
+		if check.Status != "passing" {
+			status := " " + check.Status
+			if output := strings.TrimSpace(check.Output); output != "" {
+				status += ": " + strings.SplitN(output, "\n", 2)[0]
+			}
+			return msg + status, false
+		}
+	}
+
+	if found == 0 {
+		return msg + " not found", false
+	}
+	return msg + " passing", true
+}
+
+func (d *dep) checkKey() (msg string, ok bool) {
+	msg = fmt.Sprintf("%s key %s", Kind, d.r.Key)
+
+	found, err := d.b.get("/v1/kv/"+strings.TrimLeft(d.r.Key, "/"), url.Values{"raw": {""}}, nil)
+	if err != nil {
+		return msg + " " + err.Error(), false
+	}
+
+	if found == nil {
+		return msg + " not found", false
+	}
+	if d.r.Value != nil && string(found) != *d.r.Value {
+		return msg + " waiting for value", false
+	}
+	return msg + " good", true
+}
+
+// get requests the path from the consul API, decoding the result into v (if not nil).
+//
+// Returns the raw body, which will be nil if the path is not found.
+func (b *broker) get(path string, q url.Values, v interface{}) ([]byte, error) {
+	body, err := b.fetch(path, q)
+	if err != nil || body == nil {
+		return nil, err
+	}
+
+	if v != nil {
+		err = json.Unmarshal(body, v)
+		if err != nil {
+			return nil, common.Error(err, "failed to parse consul response")
+		}
+	}
+	return body, nil
+}
+
+// fetch returns the (possibly cached) raw body of the path from the consul API, which will be nil if not found.
+func (b *broker) fetch(path string, q url.Values) ([]byte, error) {
+	if q == nil {
+		q = url.Values{}
+	}
+	if b.datacenter != "" {
+		q.Set("dc", b.datacenter)
+	}
+
+	u := b.address + path
+	if len(q) > 0 {
+		u += "?" + q.Encode()
+	}
+
+	now := time.Now()
+	var cached *response
+	func() {
+		b.RLock()
+		defer b.RUnlock()
+
+		if r, ok := b.responses[u]; ok && now.Sub(r.ts) <= cacheTTL {
+			cached = r
+		}
+	}()
+
+	if cached != nil {
+		return cached.body, nil
+	}
+
+	body, err := b.request(u)
+	if err != nil {
+		return nil, err
+	}
+
+	b.Lock()
+	defer b.Unlock()
+
+	b.responses[u] = &response{ts: now, body: body}
+	return body, nil
+}
+
+// request gets the url from the consul API, returning the raw body (or nil if not found).
+func (b *broker) request(u string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if b.token != "" {
+		req.Header.Set("X-Consul-Token", b.token)
+	}
+
+	res, err := b.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, common.ErrorMsg("consul returned %s: %s", res.Status, strings.TrimSpace(string(body)))
+	}
+
+	if body == nil {
+		body = []byte{}
+	}
+	return body, nil
 }
@@ -0,0 +1,238 @@
+package consul
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/norganna/cynosure/common"
+)
+
+// fakeConsul is an httptest stand-in for the consul HTTP API.
+type fakeConsul struct {
+	sync.Mutex
+
+	// requests[path] = count
+	requests map[string]int
+	failing  bool
+}
+
+func (f *fakeConsul) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	f.requests[r.URL.Path]++
+	failing := f.failing
+	f.Unlock()
+
+	if r.Header.Get("X-Consul-Token") != "secret" || r.URL.Query().Get("dc") != "dc2" {
+		http.Error(w, "ACL not found", http.StatusForbidden)
+		return
+	}
+	if failing {
+		http.Error(w, "no cluster leader", http.StatusInternalServerError)
+		return
+	}
+
+	switch r.URL.Path {
+	case "/v1/health/service/web":
+		if r.URL.Query().Get("passing") != "true" {
+			http.Error(w, "passing filter missing", http.StatusBadRequest)
+			return
+		}
+		if r.URL.Query().Get("tag") == "canary" {
+			_, _ = fmt.Fprint(w, `[{"Node":{}}]`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `[{"Node":{}},{"Node":{}}]`)
+	case "/v1/health/service/db":
+		_, _ = fmt.Fprint(w, `[]`)
+	case "/v1/health/state/any":
+		_, _ = fmt.Fprint(w, `[
+			{"CheckID":"serfHealth","Name":"Serf Health Status","Status":"passing"},
+			{"CheckID":"service:web","Name":"web-http","Status":"critical","Output":"connection refused\nmore"}
+		]`)
+	case "/v1/health/checks/web":
+		_, _ = fmt.Fprint(w, `[{"CheckID":"service:web","Name":"web-http","Status":"passing"}]`)
+	case "/v1/kv/config/mode":
+		if _, ok := r.URL.Query()["raw"]; !ok {
+			http.Error(w, "raw missing", http.StatusBadRequest)
+			return
+		}
+		_, _ = fmt.Fprint(w, "live")
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (f *fakeConsul) count(path string) int {
+	f.Lock()
+	defer f.Unlock()
+
+	return f.requests[path]
+}
+
+// newFake starts a fake consul and returns a broker for it.
+func newFake(t *testing.T) (*fakeConsul, *broker, func()) {
+	f := &fakeConsul{requests: map[string]int{}}
+	server := httptest.NewServer(f)
+
+	b, err := create(common.StringMap{
+		"address":    strings.TrimPrefix(server.URL, "http://"),
+		"token":      "secret",
+		"datacenter": "dc2",
+	})
+	if err != nil {
+		server.Close()
+		t.Fatal(err)
+	}
+	return f, b.(*broker), server.Close
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name string
+		wait string
+		ok   bool
+		msg  string
+	}{
+		{"service", "web", true, "consul service web 2/1 passing"},
+		{"service count", `{"service":"web","passing":3}`, false, "consul service web 2/3 passing waiting"},
+		{"service tag", `{"service":"web","tag":"canary","passing":2}`, false, "consul service web 1/2 passing waiting"},
+		{"service none passing", "db", false, "consul service db 0/1 passing waiting"},
+		{"check by id", `{"check":"serfHealth"}`, true, "consul check serfHealth passing"},
+		{"check failing", `{"check":"web-http"}`, false, "consul check web-http critical: connection refused"},
+		{"check of service", `{"check":"web-http","service":"web"}`, true, "consul check web-http passing"},
+		{"check missing", `{"check":"nope"}`, false, "consul check nope not found"},
+		{"key", `{"key":"config/mode"}`, true, "consul key config/mode good"},
+		{"key value", `{"key":"/config/mode","value":"live"}`, true, "consul key /config/mode good"},
+		{"key other value", `{"key":"config/mode","value":"maintenance"}`, false, "consul key config/mode waiting for value"},
+		{"key missing", `{"key":"config/other"}`, false, "consul key config/other not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, b, done := newFake(t)
+			defer done()
+
+			d, err := b.Dep(tt.wait)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg, ok := d.Check()
+			if ok != tt.ok || msg != tt.msg {
+				t.Errorf("got %v %q, want %v %q", ok, msg, tt.ok, tt.msg)
+			}
+		})
+	}
+}
+
+func TestCheckErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		wait    string
+		failing bool
+		token   string
+		msg     string
+	}{
+		{"no cluster leader", "web", true, "secret", "consul service web consul returned 500 Internal Server Error: no cluster leader"},
+		{"bad token", "web", false, "wrong", "consul service web consul returned 403 Forbidden: ACL not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, b, done := newFake(t)
+			defer done()
+			f.failing = tt.failing
+			b.token = tt.token
+
+			d, err := b.Dep(tt.wait)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg, ok := d.Check()
+			if ok || msg != tt.msg {
+				t.Errorf("got %v %q, want %q", ok, msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestDepInvalid(t *testing.T) {
+	b := &broker{}
+	for _, wait := range []string{
+		"",
+		`{}`,
+		`{"check":"a","key":"b"}`,
+		`{"service":"a","key":"b"}`,
+		`{"service":`,
+	} {
+		if _, err := b.Dep(wait); err == nil {
+			t.Errorf("%s: expected an error", wait)
+		}
+	}
+}
+
+func TestCache(t *testing.T) {
+	f, b, done := newFake(t)
+	defer done()
+
+	web, err := b.Dep("web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	canary, err := b.Dep(`{"service":"web","tag":"canary"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Dependencies on the same thing share a response, while different queries don't.
+	for i := 0; i < 3; i++ {
+		_, _ = web.Check()
+		_, _ = canary.Check()
+	}
+	if n := f.count("/v1/health/service/web"); n != 2 {
+		t.Errorf("got %d requests, want 2", n)
+	}
+
+	// Responses expire after the TTL.
+	b.Lock()
+	for _, r := range b.responses {
+		r.ts = r.ts.Add(-cacheTTL - time.Second)
+	}
+	b.Unlock()
+
+	_, _ = web.Check()
+	if n := f.count("/v1/health/service/web"); n != 3 {
+		t.Errorf("got %d requests after expiry, want 3", n)
+	}
+
+	// Not found responses are cached, but errors are not.
+	missing, err := b.Dep(`{"key":"missing"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = missing.Check()
+	_, _ = missing.Check()
+	if n := f.count("/v1/kv/missing"); n != 1 {
+		t.Errorf("got %d not found requests, want 1", n)
+	}
+
+	f.Lock()
+	f.failing = true
+	f.Unlock()
+
+	db, err := b.Dep("db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = db.Check()
+	_, _ = db.Check()
+	if n := f.count("/v1/health/service/db"); n != 2 {
+		t.Errorf("got %d failed requests, want 2", n)
+	}
+}
@@ -8,16 +8,60 @@
 //
 // If type is "config", will use a RPC using the client config file at PATH.
 // You can generate a remote config file using `cynosure config` on the destination server.
+// Connections to each remote server are shared, and its process list is cached for a couple of seconds.
+//
+// The "wait" parameter will be either a plain process name, or JSON of the form:
+//    {
+//      "namespace": NAMESPACE,
+//      "name": NAME,
+//      "identifier": IDENTIFIER,
+//      "selector": SELECTOR,
+//      "condition": CONDITION,
+//      "replicas": COUNT,
+//      "observation": WATCH
+//    }
+//
+// If only a name is supplied, it will expand to:
+//
+//    {"name": NAME}
+//
+// Default values (if none specified):
+//    namespace = the namespace of the waiting process
+//    condition = "ready"
+//    replicas = 1
+//
+// NAME matches the command name of the processes, and IDENTIFIER matches a single process identifier.
+// SELECTOR is a comma separated list of label requirements of the form `KEY=VALUE` or `KEY!=VALUE`.
+// CONDITION is one of:
+//    "running" - the process has a running PID (and hasn't completed).
+//    "ready" - the process is ready.
+//    "observed" - the WATCH has been observed in the process output.
+// COUNT is the minimum number of matching processes that must meet the condition.
+//
+// All of the name, identifier and selector that are specified must match.
 package cyno
 
 import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
 	"github.com/norganna/cynosure/common"
 	"github.com/norganna/cynosure/deps"
+	"github.com/norganna/cynosure/process"
+	"github.com/norganna/cynosure/proto/cynosure"
 )
 
 // Kind contains the kind string of this provider.
 const Kind = "cyno"
 
+// Conditions.
+const (
+	ConditionRunning  = "running"
+	ConditionReady    = "ready"
+	ConditionObserved = "observed"
+)
+
 func init() {
 	deps.RegisterProvider(Kind, create)
 }
@@ -42,23 +86,223 @@ func create(config common.StringMap) (br deps.Broker, err error) {
 }
 
 type broker struct {
-	internal bool
-	config   *common.Config
+	internal  bool
+	config    *common.Config
+	namespace string
+}
+
+var _ deps.NamespacedBroker = (*broker)(nil)
+
+// InNamespace returns a copy of the broker whose dependencies default to the namespace.
+func (b *broker) InNamespace(namespace string) deps.Broker {
+	nb := *b
+	nb.namespace = namespace
+	return &nb
+}
+
+type request struct {
+	Namespace   string `json:"namespace,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Identifier  string `json:"identifier,omitempty"`
+	Selector    string `json:"selector,omitempty"`
+	Condition   string `json:"condition,omitempty"`
+	Replicas    int    `json:"replicas,omitempty"`
+	Observation string `json:"observation,omitempty"`
 }
 
 func (b *broker) Dep(wait string) (deps.Depender, error) {
+	r := &request{}
+	if wait == "" {
+		return nil, common.ErrorMsg("no process to wait for")
+	}
+
+	if wait[0:1] != "{" {
+		r.Name = wait
+	} else {
+		err := json.Unmarshal([]byte(wait), r)
+		if err != nil {
+			return nil, common.Error(err, "failed to parse dependency condition JSON")
+		}
+	}
+
+	if r.Namespace == "" {
+		r.Namespace = b.namespace
+	}
+	if r.Condition == "" {
+		r.Condition = ConditionReady
+	}
+	if r.Replicas < 1 {
+		r.Replicas = 1
+	}
+
+	switch r.Condition {
+	case ConditionRunning, ConditionReady:
+	case ConditionObserved:
+		if r.Observation == "" {
+			return nil, common.ErrorMsg("an observation is required for the %s condition", r.Condition)
+		}
+	default:
+		return nil, common.ErrorMsg("unknown condition %s", r.Condition)
+	}
+
+	filters, err := r.filters()
+	if err != nil {
+		return nil, err
+	}
+
 	return &dep{
-		b:    b,
-		wait: wait,
+		b:       b,
+		r:       r,
+		filters: filters,
 	}, nil
 }
 
+// filters returns the process filters that select the processes to check.
+func (r *request) filters() ([]*cynosure.Filter, error) {
+	filters := []*cynosure.Filter{{
+		Type:   cynosure.Filter_Namespace,
+		Values: []string{r.Namespace},
+	}}
+
+	if r.Name != "" {
+		filters = append(filters, &cynosure.Filter{
+			Type:   cynosure.Filter_Name,
+			Values: []string{r.Name},
+		})
+	}
+
+	if r.Identifier != "" {
+		filters = append(filters, &cynosure.Filter{
+			Type:   cynosure.Filter_Identifier,
+			Values: []string{r.Identifier},
+		})
+	}
+
+	for _, term := range strings.Split(r.Selector, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+
+		op := cynosure.Filter_In
+		parts := strings.SplitN(term, "!=", 2)
+		if len(parts) == 2 {
+			op = cynosure.Filter_NotIn
+		} else {
+			parts = strings.SplitN(term, "=", 2)
+			if len(parts) != 2 {
+				return nil, common.ErrorMsg("failed to parse label selector %s", term)
+			}
+		}
+
+		filters = append(filters, &cynosure.Filter{
+			Type:   cynosure.Filter_Label,
+			Op:     op,
+			Key:    strings.TrimSpace(parts[0]),
+			Values: []string{strings.TrimSpace(parts[1])},
+		})
+	}
+
+	return filters, nil
+}
+
+// describe returns a short description of what is being waited for.
+func (r *request) describe() string {
+	var what []string
+	if r.Namespace != "" {
+		what = append(what, r.Namespace+"/")
+	}
+	if r.Name != "" {
+		what = append(what, r.Name)
+	}
+	if r.Identifier != "" {
+		what = append(what, r.Identifier)
+	}
+	if r.Selector != "" {
+		what = append(what, "{"+r.Selector+"}")
+	}
+	return strings.Join(what, "")
+}
+
+// instance is the state of a process needed to check the conditions.
+type instance struct {
+	running  bool
+	ready    bool
+	observed map[string]string
+}
+
 type dep struct {
-	b    *broker
-	wait string
+	b       *broker
+	r       *request
+	filters []*cynosure.Filter
 }
 
 func (d *dep) Check() (msg string, ok bool) {
-	// TODO implement checks once the process manager is completed.
-	panic("implement me")
+	msg = fmt.Sprintf("%s %s", Kind, d.r.describe())
+
+	var list []*instance
+	if d.b.internal {
+		list = d.local()
+	} else {
+		var err error
+		list, err = d.remote()
+		if err != nil {
+			return msg + " " + err.Error(), false
+		}
+	}
+
+	if len(list) == 0 {
+		return msg + " no matching processes", false
+	}
+
+	met := 0
+	for _, inst := range list {
+		if d.meets(inst) {
+			met++
+		}
+	}
+
+	status := fmt.Sprintf(" %d/%d %s", met, d.r.Replicas, d.r.Condition)
+	if d.r.Condition == ConditionObserved {
+		status += " " + d.r.Observation
+	}
+
+	if met < d.r.Replicas {
+		return msg + status + " waiting", false
+	}
+	return msg + status, true
+}
+
+// local returns the matching processes from this server.
+func (d *dep) local() []*instance {
+	var list []*instance
+	for _, p := range process.Default.List(d.filters) {
+		inst := &instance{
+			running: running(p.PID(), p.State()),
+			ready:   p.Ready(),
+		}
+		if logging := p.Log(); logging != nil {
+			inst.observed = logging.Observed()
+		}
+		list = append(list, inst)
+	}
+	return list
+}
+
+// running returns whether a process is running, in the same way for local and remote processes.
+func running(pid int, state cynosure.Process_State) bool {
+	return pid > 0 && state == cynosure.Process_Running
+}
+
+func (d *dep) meets(inst *instance) bool {
+	switch d.r.Condition {
+	case ConditionRunning:
+		return inst.running
+	case ConditionReady:
+		return inst.ready
+	case ConditionObserved:
+		_, ok := inst.observed[d.r.Observation]
+		return ok
+	}
+	return false
 }
@@ -0,0 +1,114 @@
+package cyno
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/norganna/cynosure/deps"
+	"github.com/norganna/cynosure/process"
+	"github.com/norganna/cynosure/proto/cynosure"
+)
+
+func TestDep(t *testing.T) {
+	b := (&broker{internal: true}).InNamespace("caller")
+
+	tests := []struct {
+		name      string
+		wait      string
+		namespace string
+		condition string
+		replicas  int
+		fails     bool
+	}{
+		{"plain name", "web", "caller", ConditionReady, 1, false},
+		{"json", `{"name":"web","replicas":2,"condition":"running"}`, "caller", ConditionRunning, 2, false},
+		{"other namespace", `{"namespace":"other","name":"web"}`, "other", ConditionReady, 1, false},
+		{"observed", `{"name":"web","condition":"observed","observation":"up"}`, "caller", ConditionObserved, 1, false},
+		{"observed without observation", `{"name":"web","condition":"observed"}`, "", "", 0, true},
+		{"unknown condition", `{"name":"web","condition":"asleep"}`, "", "", 0, true},
+		{"bad json", `{"name":`, "", "", 0, true},
+		{"empty", "", "", "", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dd, err := b.Dep(tt.wait)
+			if (err != nil) != tt.fails {
+				t.Fatalf("got error %v, want failure %v", err, tt.fails)
+			}
+			if err != nil {
+				return
+			}
+
+			r := dd.(*dep).r
+			if r.Namespace != tt.namespace || r.Condition != tt.condition || r.Replicas != tt.replicas {
+				t.Errorf("got %+v", r)
+			}
+		})
+	}
+}
+
+func TestRunning(t *testing.T) {
+	tests := []struct {
+		pid     int
+		state   cynosure.Process_State
+		running bool
+	}{
+		{123, cynosure.Process_Running, true},
+		{-1, cynosure.Process_Running, false},
+		{123, cynosure.Process_Succeeded, false},
+		{123, cynosure.Process_Failed, false},
+	}
+	for _, tt := range tests {
+		if running := running(tt.pid, tt.state); running != tt.running {
+			t.Errorf("pid %d %s: got running %v, want %v", tt.pid, tt.state, running, tt.running)
+		}
+	}
+}
+
+func TestLocalNamespace(t *testing.T) {
+	err := deps.NewInstance("self", "", Kind, map[string]string{"type": "self"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = process.Default.Start(&cynosure.StartRequest{
+		Namespace: "cyno-test",
+		Command: &cynosure.Command{
+			Name:  "sleeper",
+			Entry: "/bin/sleep",
+			Args:  []string{"60"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer process.Default.Quit()
+	time.Sleep(200 * time.Millisecond)
+
+	tests := []struct {
+		name      string
+		namespace string
+		wait      string
+		ok        bool
+		msg       string
+	}{
+		{"caller namespace", "cyno-test", `{"name":"sleeper","condition":"running"}`, true, "1/1 running"},
+		{"other caller", "elsewhere", `{"name":"sleeper","condition":"running"}`, false, "no matching processes"},
+		{"explicit namespace", "elsewhere", `{"namespace":"cyno-test","name":"sleeper","condition":"running"}`, true, "1/1 running"},
+		{"too few", "cyno-test", `{"name":"sleeper","condition":"running","replicas":2}`, false, "1/2 running waiting"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dd, err := deps.Instance("self", tt.namespace).Dep(tt.wait)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg, ok := dd.Check()
+			if ok != tt.ok || !strings.HasSuffix(msg, tt.msg) {
+				t.Errorf("got %v %q, want %v %q", ok, msg, tt.ok, tt.msg)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package cyno
+
+import (
+	"context"
+	"crypto/tls"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/norganna/cynosure/common"
+	"github.com/norganna/cynosure/proto/cynosure"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// remoteCacheTTL is how long the processes returned from a remote server are reused for.
+const remoteCacheTTL = 2 * time.Second
+
+// remoteTimeout is how long a call to a remote server has to complete.
+const remoteTimeout = 5 * time.Second
+
+type remoteManager struct {
+	sync.RWMutex
+
+	clients map[string]*remoteClient
+}
+
+var remoteMan = &remoteManager{
+	clients: map[string]*remoteClient{},
+}
+
+// GetClient returns the (shared) client for the server in the config, connecting if required.
+func (m *remoteManager) GetClient(config *common.Config) (client *remoteClient, err error) {
+	func() {
+		m.RLock()
+		defer m.RUnlock()
+
+		if c, ok := m.clients[config.Server]; ok {
+			client = c
+		}
+	}()
+
+	if client != nil {
+		return client, nil
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	if c, ok := m.clients[config.Server]; ok {
+		return c, nil
+	}
+
+	crt, err := config.ClientCert(365)
+	if err != nil {
+		return nil, common.Error(err, "failed to get client certificate")
+	}
+	if crt == nil {
+		return nil, common.ErrorMsg("no client certificate in config")
+	}
+
+	pool, err := config.CertPool()
+	if err != nil {
+		return nil, common.Error(err, "failed to obtain a certificate pool")
+	}
+
+	creds := credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{*crt},
+		RootCAs:      pool,
+		ServerName:   "api.cynosure",
+	})
+
+	// Without blocking, the connection is made (and remade) in the background as it is used.
+	conn, err := grpc.Dial("passthrough:///"+config.Server, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, common.Error(err, "failed to dial server %s", config.Server)
+	}
+
+	client = &remoteClient{
+		api:       cynosure.NewAPIClient(conn),
+		processes: map[string]*remoteProcesses{},
+	}
+
+	m.clients[config.Server] = client
+	return client, nil
+}
+
+type remoteClient struct {
+	sync.RWMutex
+
+	api       cynosure.APIClient
+	processes map[string]*remoteProcesses
+}
+
+type remoteProcesses struct {
+	ts   time.Time
+	list []*cynosure.Process
+}
+
+// GetProcesses returns the running processes on the remote server that match the filters.
+func (c *remoteClient) GetProcesses(filters []*cynosure.Filter) (procs *remoteProcesses, err error) {
+	now := time.Now()
+	key := filterKey(filters)
+
+	func() {
+		c.RLock()
+		defer c.RUnlock()
+
+		if p, ok := c.processes[key]; ok {
+			if now.Sub(p.ts) <= remoteCacheTTL {
+				procs = p
+			}
+		}
+	}()
+
+	if procs != nil {
+		return procs, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), remoteTimeout)
+	defer cancel()
+
+	res, err := c.api.Running(ctx, &cynosure.RunningRequest{
+		Filters: filters,
+	})
+	if err != nil {
+		return nil, common.Error(err, "failed to get remote process list")
+	}
+
+	procs = &remoteProcesses{
+		ts:   now,
+		list: res.GetProcesses(),
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	c.processes[key] = procs
+	return procs, nil
+}
+
+func filterKey(filters []*cynosure.Filter) string {
+	keys := make([]string, len(filters))
+	for i, filter := range filters {
+		keys[i] = filter.String()
+	}
+	return strings.Join(keys, "\n")
+}
+
+// remote returns the matching processes from the remote server.
+func (d *dep) remote() ([]*instance, error) {
+	client, err := remoteMan.GetClient(d.b.config)
+	if err != nil {
+		return nil, err
+	}
+
+	procs, err := client.GetProcesses(d.filters)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []*instance
+	for _, p := range procs.list {
+		list = append(list, &instance{
+			running:  running(int(p.GetPid()), p.GetState()),
+			ready:    p.GetReady(),
+			observed: p.GetObservations(),
+		})
+	}
+	return list, nil
+}
@@ -0,0 +1,328 @@
+package cyno
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/norganna/cynosure/common"
+	"github.com/norganna/cynosure/proto/cynosure"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// fakeAPI is an in-process stand-in for the API of a remote cynosure server.
+type fakeAPI struct {
+	cynosure.UnimplementedAPIServer
+	sync.Mutex
+
+	filters [][]*cynosure.Filter
+	list    []*cynosure.Process
+}
+
+func (f *fakeAPI) Running(_ context.Context, req *cynosure.RunningRequest) (*cynosure.RunningResponse, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.filters = append(f.filters, req.GetFilters())
+	return &cynosure.RunningResponse{
+		Processes: f.list,
+	}, nil
+}
+
+func (f *fakeAPI) calls() [][]*cynosure.Filter {
+	f.Lock()
+	defer f.Unlock()
+
+	return f.filters
+}
+
+// testCert creates a certificate for the tests, signed by the parent (or self-signed as a CA if there is none), and
+// returns it with its key in the encoding used by the config file.
+//
+// The keys are P-256, as TLS doesn't support the curve of the certificates created by the common package.
+func testCert(t *testing.T, parent *tls.Certificate, cn string, usage x509.ExtKeyUsage) (string, string, *tls.Certificate) {
+	prv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+	}
+
+	signer, signerKey := template, interface{}(prv)
+	if parent == nil {
+		template.IsCA = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		template.ExtKeyUsage = []x509.ExtKeyUsage{usage}
+		template.DNSNames = []string{cn}
+		signer, signerKey = parent.Leaf, parent.PrivateKey
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &prv.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(prv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert := base64.RawStdEncoding.EncodeToString(der)
+	key := base64.RawStdEncoding.EncodeToString(keyDer)
+	crt, err := common.LoadCertificate(cert, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key, crt
+}
+
+// serveRemote starts a TLS gRPC server with a certificate for the server name, and returns the config file a client
+// uses to connect to it.
+func serveRemote(t *testing.T, api cynosure.APIServer, serverName string) (string, func()) {
+	dir, err := ioutil.TempDir("", "cyno-remote")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+
+	cleanup := func() {
+		_ = l.Close()
+		_ = os.RemoveAll(dir)
+	}
+
+	ca, _, auth := testCert(t, nil, "test", 0)
+	_, _, srv := testCert(t, auth, serverName, x509.ExtKeyUsageServerAuth)
+	cert, key, _ := testCert(t, auth, "client.cynosure", x509.ExtKeyUsageClientAuth)
+
+	data, err := json.Marshal(&common.Config{
+		Server:      l.Addr().String(),
+		Root:        dir,
+		Certificate: &common.ConfigCertificate{CA: ca, Cert: cert, Key: key},
+	})
+	if err != nil {
+		cleanup()
+		t.Fatal(err)
+	}
+
+	file := path.Join(dir, "config.json")
+	err = ioutil.WriteFile(file, data, 0600)
+	if err != nil {
+		cleanup()
+		t.Fatal(err)
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(auth.Leaf)
+
+	s := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{*srv},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pool,
+	})))
+	cynosure.RegisterAPIServer(s, api)
+	go func() {
+		_ = s.Serve(l)
+	}()
+
+	server := l.Addr().String()
+	return file, func() {
+		s.Stop()
+		cleanup()
+
+		remoteMan.Lock()
+		delete(remoteMan.clients, server)
+		remoteMan.Unlock()
+	}
+}
+
+// remoteBroker returns a broker that checks the processes of the server in the config file.
+func remoteBroker(t *testing.T, file string) *broker {
+	b, err := create(common.StringMap{"type": "config", "file": file})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b.(*broker)
+}
+
+func TestRemoteCheck(t *testing.T) {
+	api := &fakeAPI{list: []*cynosure.Process{
+		{Pid: 10, State: cynosure.Process_Running, Ready: true},
+		{Pid: 11, State: cynosure.Process_Running},
+	}}
+	file, done := serveRemote(t, api, "api.cynosure")
+	defer done()
+
+	b := remoteBroker(t, file)
+
+	tests := []struct {
+		name string
+		wait string
+		ok   bool
+		msg  string
+	}{
+		{"ready", `{"namespace":"ns","name":"web"}`, true, "cyno ns/web 1/1 ready"},
+		{"ready count", `{"namespace":"ns","name":"web","replicas":2}`, false, "cyno ns/web 1/2 ready waiting"},
+		{"running", `{"namespace":"ns","name":"web","condition":"running","replicas":2}`, true, "cyno ns/web 2/2 running"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := b.Dep(tt.wait)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg, ok := d.Check()
+			if ok != tt.ok || msg != tt.msg {
+				t.Errorf("got %v %q, want %v %q", ok, msg, tt.ok, tt.msg)
+			}
+		})
+	}
+}
+
+func TestRemoteFilters(t *testing.T) {
+	api := &fakeAPI{}
+	file, done := serveRemote(t, api, "api.cynosure")
+	defer done()
+
+	b := remoteBroker(t, file)
+
+	d, err := b.Dep(`{"namespace":"ns","name":"web","identifier":"web-1","selector":"tier=front, env!=dev"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg, ok := d.Check()
+	if ok || msg != "cyno ns/webweb-1{tier=front, env!=dev} no matching processes" {
+		t.Errorf("got %v %q", ok, msg)
+	}
+
+	calls := api.calls()
+	if len(calls) != 1 {
+		t.Fatalf("got %d calls, want 1", len(calls))
+	}
+
+	want := []*cynosure.Filter{
+		{Type: cynosure.Filter_Namespace, Values: []string{"ns"}},
+		{Type: cynosure.Filter_Name, Values: []string{"web"}},
+		{Type: cynosure.Filter_Identifier, Values: []string{"web-1"}},
+		{Type: cynosure.Filter_Label, Key: "tier", Values: []string{"front"}},
+		{Type: cynosure.Filter_Label, Op: cynosure.Filter_NotIn, Key: "env", Values: []string{"dev"}},
+	}
+	if len(calls[0]) != len(want) {
+		t.Fatalf("got filters %v, want %v", calls[0], want)
+	}
+	for i, filter := range calls[0] {
+		if !proto.Equal(filter, want[i]) {
+			t.Errorf("got filter %v, want %v", filter, want[i])
+		}
+	}
+}
+
+func TestRemoteCache(t *testing.T) {
+	api := &fakeAPI{list: []*cynosure.Process{
+		{Pid: 10, State: cynosure.Process_Running, Ready: true},
+	}}
+	file, done := serveRemote(t, api, "api.cynosure")
+	defer done()
+
+	// Brokers for the same server share a client.
+	b1 := remoteBroker(t, file)
+	b2 := remoteBroker(t, file)
+
+	c1, err := remoteMan.GetClient(b1.config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c2, err := remoteMan.GetClient(b2.config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c1 != c2 {
+		t.Error("expected brokers for the same server to share a client")
+	}
+
+	ready, err := b1.Dep(`{"namespace":"ns","name":"web"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	running, err := b2.Dep(`{"namespace":"ns","name":"web","condition":"running"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := b1.Dep(`{"namespace":"ns","name":"db"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Dependencies with the same filters share a response, while different filters don't.
+	for i := 0; i < 3; i++ {
+		_, _ = ready.Check()
+		_, _ = running.Check()
+		_, _ = other.Check()
+	}
+	if n := len(api.calls()); n != 2 {
+		t.Errorf("got %d calls, want 2", n)
+	}
+
+	// Responses expire after the TTL.
+	c1.Lock()
+	for _, p := range c1.processes {
+		p.ts = p.ts.Add(-remoteCacheTTL - time.Second)
+	}
+	c1.Unlock()
+
+	_, _ = ready.Check()
+	if n := len(api.calls()); n != 3 {
+		t.Errorf("got %d calls after expiry, want 3", n)
+	}
+}
+
+func TestRemoteServerName(t *testing.T) {
+	api := &fakeAPI{}
+	file, done := serveRemote(t, api, "other.cynosure")
+	defer done()
+
+	d, err := remoteBroker(t, file).Dep(`{"namespace":"ns","name":"web"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The server's certificate must be for the API name, even though it is signed by the trusted authority.
+	msg, ok := d.Check()
+	if ok || !strings.HasPrefix(msg, "cyno ns/web failed to get remote process list") {
+		t.Errorf("got %v %q", ok, msg)
+	}
+	if n := len(api.calls()); n != 0 {
+		t.Errorf("got %d calls, want 0", n)
+	}
+}
@@ -2,6 +2,7 @@ package deps
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/norganna/cynosure/common"
@@ -19,6 +20,8 @@ type Depender interface {
 }
 
 // DepList contains a set of dependencies and checks for each, which if any are successful meet the requirement.
+//
+// A requirement may instead have a single Expr, which must pass.
 type DepList map[string][]Depender
 
 // Add adds a new dependency to the named requirement.
@@ -26,6 +29,38 @@ func (d DepList) Add(name string, dep Depender) {
 	d[name] = append(d[name], dep)
 }
 
+// Result is the outcome of checking a requirement.
+type Result struct {
+	Name    string
+	OK      bool
+	Message string
+
+	// Deps are the results of each of the requirement's dependencies (in order).
+	Deps []DepResult
+
+	// Expr is the evaluated expression (if the requirement's dependency is an Expr).
+	Expr *ExprResult
+}
+
+// DepResult is the outcome of checking a single dependency.
+//
+// Dependencies after the first that passes are not checked.
+type DepResult struct {
+	Checked bool
+	OK      bool
+	Message string
+}
+
+// Names returns the names of the requirements in order.
+func (d DepList) Names() []string {
+	names := make([]string, 0, len(d))
+	for name := range d {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // Check checks the list of dependencies and works out if they are met or not.
 func (d DepList) Check() (messages []string, ok bool) {
 	if d == nil {
@@ -33,28 +68,59 @@ func (d DepList) Check() (messages []string, ok bool) {
 	}
 
 	all := true
-	for name, deps := range d {
-		var msg []string
+	for _, name := range d.Names() {
+		m, ok := d.CheckOne(name)
+		if !ok {
+			all = false
+		}
+		messages = append(messages, m)
+	}
+	return messages, all
+}
+
+// CheckOne checks the dependencies of the named requirement, any of which can meet it.
+func (d DepList) CheckOne(name string) (message string, ok bool) {
+	r := d.Result(name)
+	return r.Message, r.OK
+}
+
+// Result checks the dependencies of the named requirement and returns the outcome of each.
+func (d DepList) Result(name string) Result {
+	deps := d[name]
+	r := Result{
+		Name: name,
+		Deps: make([]DepResult, len(deps)),
+	}
+
+	var msg []string
+	for i, dep := range deps {
 		var m string
 		var ok bool
-
-		for _, dep := range deps {
+		if e, isExpr := dep.(*Expr); isExpr {
+			r.Expr = e.Evaluate()
+			m, ok = r.Expr.Message, r.Expr.OK
+		} else {
 			m, ok = dep.Check()
-			if ok {
-				msg = []string{m}
-				break
-			}
+		}
 
-			msg = append(msg, m)
+		r.Deps[i] = DepResult{
+			Checked: true,
+			OK:      ok,
+			Message: m,
 		}
 
-		if !ok {
-			m = fmt.Sprintf("[FAIL] %s: %s", name, strings.Join(msg, "; "))
-			all = false
-		} else {
-			m = fmt.Sprintf("[ OK ] %s: %s", name, msg[0])
+		if ok {
+			r.OK = true
+			msg = []string{m}
+			break
 		}
-		messages = append(messages, m)
+		msg = append(msg, m)
 	}
-	return messages, all
+
+	if !r.OK {
+		r.Message = fmt.Sprintf("[FAIL] %s: %s", name, strings.Join(msg, "; "))
+	} else {
+		r.Message = fmt.Sprintf("[ OK ] %s: %s", name, msg[0])
+	}
+	return r
 }
@@ -0,0 +1,275 @@
+// Package dns is a provider that checks host names resolve.
+//
+// Accepts config:
+//    resolver: ADDRESS
+//
+// The ADDRESS is the default DNS server to query, of the form HOST[:PORT] (default is the system resolver).
+//
+// The "wait" parameter will be either a plain host name, or JSON of the form:
+//    {
+//      "name": NAME,
+//      "type": TYPE,
+//      "expect": ANSWER,
+//      "resolver": ADDRESS
+//    }
+//
+// If only a name is supplied, it will expand to:
+//
+//    {"name": NAME}
+//
+// Default values (if none specified):
+//    type = "A"
+//
+// TYPE is one of "A", "AAAA", "CNAME", "MX", "NS", "PTR", "SRV" or "TXT". For "PTR", the NAME is an IP address, and for
+// "SRV" it is the full service name (e.g. "_http._tcp.example.com").
+//
+// Waits for NAME to have at least one answer of the TYPE. If ANSWER is given, one of the answers must match it (for
+// "MX" and "SRV" the answer is the target host name, and "TXT" answers must contain it).
+package dns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/norganna/cynosure/common"
+	"github.com/norganna/cynosure/deps"
+)
+
+// Kind contains the kind string of this provider.
+const Kind = "dns"
+
+// timeout is how long a lookup has to complete.
+const timeout = 2 * time.Second
+
+func init() {
+	deps.RegisterProvider(Kind, create)
+}
+
+// create returns the Broker.
+func create(config common.StringMap) (deps.Broker, error) {
+	b := &broker{}
+
+	if addr := config["resolver"]; addr != "" {
+		var err error
+		b.resolver, err = resolverAddress(addr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return b, nil
+}
+
+// resolverAddress returns the HOST:PORT of the DNS server at the address (which may be missing the PORT).
+func resolverAddress(addr string) (string, error) {
+	if _, _, err := net.SplitHostPort(addr); err == nil {
+		return addr, nil
+	}
+
+	addr = strings.Trim(addr, "[]")
+	if net.ParseIP(addr) == nil && strings.Contains(addr, ":") {
+		return "", common.ErrorMsg("failed to parse resolver address %s", addr)
+	}
+	return net.JoinHostPort(addr, "53"), nil
+}
+
+type broker struct {
+	resolver string
+}
+
+type request struct {
+	Name     string `json:"name,omitempty"`
+	Type     string `json:"type,omitempty"`
+	Expect   string `json:"expect,omitempty"`
+	Resolver string `json:"resolver,omitempty"`
+}
+
+// lookups contains the function that finds the answers for each record type.
+var lookups = map[string]func(ctx context.Context, r *net.Resolver, name string) ([]string, error){
+	"A":     lookupIP("ip4"),
+	"AAAA":  lookupIP("ip6"),
+	"CNAME": lookupCNAME,
+	"MX":    lookupMX,
+	"NS":    lookupNS,
+	"PTR":   lookupPTR,
+	"SRV":   lookupSRV,
+	"TXT":   lookupTXT,
+}
+
+func (b *broker) Dep(wait string) (deps.Depender, error) {
+	r := &request{}
+	if wait == "" {
+		return nil, common.ErrorMsg("no name to resolve")
+	}
+
+	if wait[0:1] != "{" {
+		r.Name = wait
+	} else {
+		err := json.Unmarshal([]byte(wait), r)
+		if err != nil {
+			return nil, common.Error(err, "failed to parse dependency condition JSON")
+		}
+	}
+
+	if r.Name == "" {
+		return nil, common.ErrorMsg("no name to resolve")
+	}
+
+	r.Type = strings.ToUpper(r.Type)
+	if r.Type == "" {
+		r.Type = "A"
+	}
+	lookup, ok := lookups[r.Type]
+	if !ok {
+		return nil, common.ErrorMsg("unsupported record type %s", r.Type)
+	}
+
+	d := &dep{
+		r:        r,
+		lookup:   lookup,
+		resolver: &net.Resolver{},
+	}
+
+	addr := b.resolver
+	if r.Resolver != "" {
+		var err error
+		addr, err = resolverAddress(r.Resolver)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if addr != "" {
+		d.resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, network, addr)
+			},
+		}
+	}
+
+	return d, nil
+}
+
+type dep struct {
+	r        *request
+	lookup   func(ctx context.Context, r *net.Resolver, name string) ([]string, error)
+	resolver *net.Resolver
+}
+
+func (d *dep) Check() (msg string, ok bool) {
+	msg = fmt.Sprintf("%s %s %s", Kind, d.r.Type, d.r.Name)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	answers, err := d.lookup(ctx, d.resolver, d.r.Name)
+	if err != nil {
+		if err, ok := err.(*net.DNSError); ok && err.IsNotFound {
+			return msg + " not found", false
+		}
+		return msg + " " + err.Error(), false
+	}
+	if len(answers) == 0 {
+		return msg + " no answers", false
+	}
+
+	if d.r.Expect == "" {
+		return msg + " " + strings.Join(answers, ", "), true
+	}
+
+	for _, answer := range answers {
+		if d.matches(answer) {
+			return msg + " " + answer, true
+		}
+	}
+	return msg + " waiting for " + d.r.Expect + " (got " + strings.Join(answers, ", ") + ")", false
+}
+
+// matches returns whether the answer is the expected one.
+func (d *dep) matches(answer string) bool {
+	if d.r.Type == "TXT" {
+		return strings.Contains(answer, d.r.Expect)
+	}
+
+	if ip := net.ParseIP(d.r.Expect); ip != nil {
+		return ip.Equal(net.ParseIP(answer))
+	}
+	return strings.EqualFold(strings.TrimSuffix(answer, "."), strings.TrimSuffix(d.r.Expect, "."))
+}
+
+func lookupIP(network string) func(ctx context.Context, r *net.Resolver, name string) ([]string, error) {
+	return func(ctx context.Context, r *net.Resolver, name string) ([]string, error) {
+		addrs, err := r.LookupIPAddr(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+
+		var answers []string
+		for _, addr := range addrs {
+			if (addr.IP.To4() != nil) == (network == "ip4") {
+				answers = append(answers, addr.IP.String())
+			}
+		}
+		return answers, nil
+	}
+}
+
+func lookupCNAME(ctx context.Context, r *net.Resolver, name string) ([]string, error) {
+	cname, err := r.LookupCNAME(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return []string{cname}, nil
+}
+
+func lookupMX(ctx context.Context, r *net.Resolver, name string) ([]string, error) {
+	records, err := r.LookupMX(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	answers := make([]string, len(records))
+	for i, record := range records {
+		answers[i] = record.Host
+	}
+	return answers, nil
+}
+
+func lookupNS(ctx context.Context, r *net.Resolver, name string) ([]string, error) {
+	records, err := r.LookupNS(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	answers := make([]string, len(records))
+	for i, record := range records {
+		answers[i] = record.Host
+	}
+	return answers, nil
+}
+
+func lookupPTR(ctx context.Context, r *net.Resolver, name string) ([]string, error) {
+	return r.LookupAddr(ctx, name)
+}
+
+func lookupSRV(ctx context.Context, r *net.Resolver, name string) ([]string, error) {
+	_, records, err := r.LookupSRV(ctx, "", "", name)
+	if err != nil {
+		return nil, err
+	}
+
+	answers := make([]string, len(records))
+	for i, record := range records {
+		answers[i] = record.Target
+	}
+	return answers, nil
+}
+
+func lookupTXT(ctx context.Context, r *net.Resolver, name string) ([]string, error) {
+	return r.LookupTXT(ctx, name)
+}
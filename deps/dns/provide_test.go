@@ -0,0 +1,247 @@
+package dns
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/norganna/cynosure/common"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// fakeDNS is a DNS server that answers from a fixed set of records.
+type fakeDNS struct {
+	// records[name][type]
+	records map[string]map[dnsmessage.Type][]dnsmessage.ResourceBody
+	// cnames[name] = target
+	cnames map[string]string
+}
+
+func (f *fakeDNS) serve(pc net.PacketConn) {
+	buf := make([]byte, 512)
+	for {
+		n, addr, err := pc.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+
+		req := &dnsmessage.Message{}
+		if err := req.Unpack(buf[:n]); err != nil || len(req.Questions) != 1 {
+			continue
+		}
+
+		res, err := f.answer(req).Pack()
+		if err != nil {
+			continue
+		}
+		_, _ = pc.WriteTo(res, addr)
+	}
+}
+
+// answer returns the response to the request, following a CNAME to its target.
+func (f *fakeDNS) answer(req *dnsmessage.Message) *dnsmessage.Message {
+	q := req.Questions[0]
+	res := &dnsmessage.Message{
+		Header:    dnsmessage.Header{ID: req.ID, Response: true, Authoritative: true, RecursionAvailable: true},
+		Questions: req.Questions,
+	}
+
+	name := q.Name.String()
+	if target, ok := f.cnames[name]; ok {
+		res.Answers = append(res.Answers, resource(name, &dnsmessage.CNAMEResource{CNAME: dnsmessage.MustNewName(target)}))
+		if q.Type == dnsmessage.TypeCNAME {
+			return res
+		}
+		name = target
+	}
+
+	records, ok := f.records[name]
+	if !ok {
+		res.RCode = dnsmessage.RCodeNameError
+		return res
+	}
+	for _, body := range records[q.Type] {
+		res.Answers = append(res.Answers, resource(name, body))
+	}
+	return res
+}
+
+func resource(name string, body dnsmessage.ResourceBody) dnsmessage.Resource {
+	return dnsmessage.Resource{
+		Header: dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName(name), Class: dnsmessage.ClassINET, TTL: 60},
+		Body:   body,
+	}
+}
+
+// serve starts the fake DNS server on a local port.
+func serve(t *testing.T) (string, func()) {
+	f := &fakeDNS{
+		records: map[string]map[dnsmessage.Type][]dnsmessage.ResourceBody{
+			"web.test.": {
+				dnsmessage.TypeA: {
+					&dnsmessage.AResource{A: [4]byte{10, 0, 0, 1}},
+					&dnsmessage.AResource{A: [4]byte{10, 0, 0, 2}},
+				},
+				dnsmessage.TypeAAAA: {
+					&dnsmessage.AAAAResource{AAAA: [16]byte{0xfd, 15: 1}},
+				},
+				dnsmessage.TypeTXT: {
+					&dnsmessage.TXTResource{TXT: []string{"state=ready"}},
+				},
+			},
+			"v6.test.": {
+				dnsmessage.TypeAAAA: {
+					&dnsmessage.AAAAResource{AAAA: [16]byte{0xfd, 15: 2}},
+				},
+			},
+			"test.": {
+				dnsmessage.TypeMX: {
+					&dnsmessage.MXResource{Pref: 10, MX: dnsmessage.MustNewName("mx.test.")},
+				},
+				dnsmessage.TypeNS: {
+					&dnsmessage.NSResource{NS: dnsmessage.MustNewName("ns1.test.")},
+				},
+			},
+			"_http._tcp.web.test.": {
+				dnsmessage.TypeSRV: {
+					&dnsmessage.SRVResource{Port: 80, Target: dnsmessage.MustNewName("web.test.")},
+				},
+			},
+			"1.0.0.10.in-addr.arpa.": {
+				dnsmessage.TypePTR: {
+					&dnsmessage.PTRResource{PTR: dnsmessage.MustNewName("web.test.")},
+				},
+			},
+		},
+		cnames: map[string]string{
+			"alias.test.": "web.test.",
+		},
+	}
+
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go f.serve(pc)
+
+	return pc.LocalAddr().String(), func() {
+		_ = pc.Close()
+	}
+}
+
+func TestCheck(t *testing.T) {
+	addr, done := serve(t)
+	defer done()
+
+	b, err := create(common.StringMap{"resolver": addr})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		wait string
+		ok   bool
+		msg  string
+	}{
+		{"a", "web.test.", true, "dns A web.test. 10.0.0.1, 10.0.0.2"},
+		{"a expect", `{"name":"web.test.","expect":"10.0.0.2"}`, true, "dns A web.test. 10.0.0.2"},
+		{"a waiting", `{"name":"web.test.","expect":"10.0.0.3"}`, false, "dns A web.test. waiting for 10.0.0.3 (got 10.0.0.1, 10.0.0.2)"},
+		{"aaaa", `{"name":"web.test.","type":"aaaa","expect":"fd00::1"}`, true, "dns AAAA web.test. fd00::1"},
+		{"a of v6 only", "v6.test.", false, "dns A v6.test. no answers"},
+		{"not found", "missing.test.", false, "dns A missing.test. not found"},
+		{"cname", `{"name":"alias.test.","type":"CNAME","expect":"WEB.test"}`, true, "dns CNAME alias.test. web.test."},
+		{"a of cname", "alias.test.", true, "dns A alias.test. 10.0.0.1, 10.0.0.2"},
+		{"mx", `{"name":"test.","type":"MX","expect":"mx.test"}`, true, "dns MX test. mx.test."},
+		{"ns", `{"name":"test.","type":"NS"}`, true, "dns NS test. ns1.test."},
+		{"srv", `{"name":"_http._tcp.web.test.","type":"SRV","expect":"web.test."}`, true, "dns SRV _http._tcp.web.test. web.test."},
+		{"ptr", `{"name":"10.0.0.1","type":"PTR"}`, true, "dns PTR 10.0.0.1 web.test."},
+		{"txt", `{"name":"web.test.","type":"TXT","expect":"ready"}`, true, "dns TXT web.test. state=ready"},
+		{"txt waiting", `{"name":"web.test.","type":"TXT","expect":"stopped"}`, false, "dns TXT web.test. waiting for stopped (got state=ready)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := b.Dep(tt.wait)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg, ok := d.Check()
+			if ok != tt.ok || msg != tt.msg {
+				t.Errorf("got %v %q, want %v %q", ok, msg, tt.ok, tt.msg)
+			}
+		})
+	}
+}
+
+func TestCheckResolver(t *testing.T) {
+	addr, done := serve(t)
+	defer done()
+
+	// The broker resolver is not serving, but the dependency uses its own.
+	down, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = down.Close()
+
+	b, err := create(common.StringMap{"resolver": down.LocalAddr().String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := b.Dep(`{"name":"web.test.","resolver":"` + addr + `"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg, ok := d.Check(); !ok {
+		t.Errorf("got %q", msg)
+	}
+
+	d, err = b.Dep("web.test.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg, ok := d.Check(); ok || strings.HasSuffix(msg, " not found") {
+		t.Errorf("got %v %q from a resolver that is down", ok, msg)
+	}
+}
+
+func TestResolverAddress(t *testing.T) {
+	tests := []struct {
+		addr  string
+		want  string
+		fails bool
+	}{
+		{"10.0.0.53", "10.0.0.53:53", false},
+		{"10.0.0.53:5353", "10.0.0.53:5353", false},
+		{"dns.local", "dns.local:53", false},
+		{"fd00::53", "[fd00::53]:53", false},
+		{"[fd00::53]", "[fd00::53]:53", false},
+		{"[fd00::53]:5353", "[fd00::53]:5353", false},
+		{"not:an:address", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := resolverAddress(tt.addr)
+		if (err != nil) != tt.fails || got != tt.want {
+			t.Errorf("%s: got %q %v, want %q", tt.addr, got, err, tt.want)
+		}
+	}
+}
+
+func TestDepInvalid(t *testing.T) {
+	b := &broker{}
+	for _, wait := range []string{
+		"",
+		`{}`,
+		`{"name":"web.test.","type":"SOA"}`,
+		`{"name":"web.test.","resolver":"not:an:address"}`,
+		`{"name":`,
+	} {
+		if _, err := b.Dep(wait); err == nil {
+			t.Errorf("%s: expected an error", wait)
+		}
+	}
+}
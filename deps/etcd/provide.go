@@ -2,11 +2,51 @@
 //
 // Accepts config:
 //    address: ADDRESS
+//    username: USER
+//    password: PASS
+//    api: PATH
+//    ca: PATH
+//    cert: PATH
+//    key: PATH
+//    insecure: "true"
 //
-// Pass the ADDRESS to the etcd you wish to interrogate.
+// Pass the ADDRESS to the etcd you wish to interrogate, e.g. "127.0.0.1:2379" or "https://etcd:2379". Multiple
+// addresses can be separated by commas, and will be tried in turn. If no scheme is given, "https" is used when any of
+// the TLS options are set, otherwise "http".
+//
+// The etcd v3 API is used through its JSON gateway, which is at the API PATH (default "/v3", use "/v3beta" for etcd
+// 3.3). If a USER is given, the client will authenticate with the PASS. The TLS options are described in
+// `deps.TLSConfig`.
+//
+// The "wait" parameter will be either a plain key, or JSON of one of the forms:
+//    {"key": KEY, "equals": VALUE, "contains": FIND}
+//    {"prefix": PREFIX, "count": COUNT}
+//
+// If only a key is supplied, it will expand to:
+//
+//    {"key": KEY}
+//
+// Default values (if none specified):
+//    count = 1
+//
+// A key waits for the KEY to exist, and (if specified) for its value to be exactly VALUE and/or contain FIND.
+//
+// A prefix waits for there to be at least COUNT keys that start with PREFIX (such as those registered by services).
+//
+// Results are cached for a couple of seconds, so many dependencies on the same keys won't all query etcd.
 package etcd
 
 import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/norganna/cynosure/common"
 	"github.com/norganna/cynosure/deps"
 )
@@ -14,37 +54,355 @@ import (
 // Kind contains the kind string of this provider.
 const Kind = "etcd"
 
+// timeout is how long a request to etcd has to complete.
+const timeout = 5 * time.Second
+
+// cacheTTL is how long a range result is reused for.
+const cacheTTL = 2 * time.Second
+
 func init() {
 	deps.RegisterProvider(Kind, create)
 }
 
 // create returns the Broker.
 func create(config common.StringMap) (deps.Broker, error) {
-	b := &broker{}
 	addr := config["address"]
 	if addr == "" {
 		return nil, common.ErrorMsg("must supply a etcd server api endpoint address")
 	}
 
+	tlsConfig, err := deps.TLSConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	api := config["api"]
+	if api == "" {
+		api = "/v3"
+	}
+	api = "/" + strings.Trim(api, "/")
+
+	b := &broker{
+		username: config["username"],
+		password: config["password"],
+		client: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: tlsConfig,
+			},
+		},
+		ranges: map[string]*rangeResult{},
+	}
+
+	for _, a := range strings.Split(addr, ",") {
+		a = strings.TrimSpace(a)
+		if a == "" {
+			continue
+		}
+		if !strings.Contains(a, "://") {
+			if tlsConfig != nil {
+				a = "https://" + a
+			} else {
+				a = "http://" + a
+			}
+		}
+		b.addresses = append(b.addresses, strings.TrimRight(a, "/")+api)
+	}
+
 	return b, nil
 }
 
 type broker struct {
-	address string
+	sync.RWMutex
+
+	addresses []string
+	username  string
+	password  string
+	client    *http.Client
+
+	token  string
+	ranges map[string]*rangeResult
+}
+
+type request struct {
+	Key      string  `json:"key,omitempty"`
+	Equals   *string `json:"equals,omitempty"`
+	Contains string  `json:"contains,omitempty"`
+	Prefix   string  `json:"prefix,omitempty"`
+	Count    int64   `json:"count,omitempty"`
 }
 
 func (b *broker) Dep(wait string) (deps.Depender, error) {
+	r := &request{}
+	if wait == "" {
+		return nil, common.ErrorMsg("no etcd key to wait for")
+	}
+
+	if wait[0:1] != "{" {
+		r.Key = wait
+	} else {
+		err := json.Unmarshal([]byte(wait), r)
+		if err != nil {
+			return nil, common.Error(err, "failed to parse dependency condition JSON")
+		}
+	}
+
+	if r.Key == "" && r.Prefix == "" {
+		return nil, common.ErrorMsg("must supply an etcd key or prefix to wait for")
+	}
+	if r.Key != "" && r.Prefix != "" {
+		return nil, common.ErrorMsg("cannot wait for both an etcd key and prefix")
+	}
+
+	if r.Count < 1 {
+		r.Count = 1
+	}
+
 	return &dep{
-		b:    b,
-		wait: wait,
+		b: b,
+		r: r,
 	}, nil
 }
 
 type dep struct {
-	b    *broker
-	wait string
+	b *broker
+	r *request
 }
 
 func (d *dep) Check() (msg string, ok bool) {
-	panic("not implemented")
+	if d.r.Prefix != "" {
+		return d.checkPrefix()
+	}
+	return d.checkKey()
+}
+
+func (d *dep) checkKey() (msg string, ok bool) {
+	msg = fmt.Sprintf("%s key %s", Kind, d.r.Key)
+
+	res, err := d.b.GetRange(&rangeRequest{
+		Key: []byte(d.r.Key),
+	})
+	if err != nil {
+		return msg + " " + err.Error(), false
+	}
+
+	if len(res.Kvs) == 0 {
+		return msg + " not found", false
+	}
+
+	value := res.Kvs[0].Value
+	var waiting []string
+	if d.r.Equals != nil && string(value) != *d.r.Equals {
+		waiting = append(waiting, "value")
+	}
+	if d.r.Contains != "" && !bytes.Contains(value, []byte(d.r.Contains)) {
+		waiting = append(waiting, "value text")
+	}
+
+	if len(waiting) > 0 {
+		return msg + " waiting for " + strings.Join(waiting, ", "), false
+	}
+	return msg + " good", true
+}
+
+func (d *dep) checkPrefix() (msg string, ok bool) {
+	msg = fmt.Sprintf("%s prefix %s", Kind, d.r.Prefix)
+
+	res, err := d.b.GetRange(&rangeRequest{
+		Key:       []byte(d.r.Prefix),
+		RangeEnd:  prefixEnd([]byte(d.r.Prefix)),
+		CountOnly: true,
+	})
+	if err != nil {
+		return msg + " " + err.Error(), false
+	}
+
+	status := fmt.Sprintf(" %d/%d keys", res.Count, d.r.Count)
+	if res.Count < d.r.Count {
+		return msg + status + " waiting", false
+	}
+	return msg + status, true
+}
+
+// prefixEnd returns the range end that covers all of the keys with the prefix.
+func prefixEnd(prefix []byte) []byte {
+	end := append([]byte(nil), prefix...)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	// The prefix is all 0xff, so range to the end of the keys.
+	return []byte{0}
+}
+
+// rangeRequest is the etcd `RangeRequest` (byte slices are base64 encoded by encoding/json as the gateway expects).
+type rangeRequest struct {
+	Key       []byte `json:"key,omitempty"`
+	RangeEnd  []byte `json:"range_end,omitempty"`
+	CountOnly bool   `json:"count_only,omitempty"`
+}
+
+type keyValue struct {
+	Key   []byte `json:"key,omitempty"`
+	Value []byte `json:"value,omitempty"`
+}
+
+type rangeResult struct {
+	ts time.Time
+
+	Kvs   []*keyValue `json:"kvs,omitempty"`
+	Count int64       `json:"count,string,omitempty"`
+}
+
+// GetRange returns the (possibly cached) result of the range request.
+func (b *broker) GetRange(req *rangeRequest) (res *rangeResult, err error) {
+	now := time.Now()
+	key := base64.StdEncoding.EncodeToString(req.Key) + " " +
+		base64.StdEncoding.EncodeToString(req.RangeEnd) + " " +
+		fmt.Sprint(req.CountOnly)
+
+	func() {
+		b.RLock()
+		defer b.RUnlock()
+
+		if r, ok := b.ranges[key]; ok {
+			if now.Sub(r.ts) <= cacheTTL {
+				res = r
+			}
+		}
+	}()
+
+	if res != nil {
+		return res, nil
+	}
+
+	res = &rangeResult{ts: now}
+	err = b.post("/kv/range", req, res)
+	if err != nil {
+		return nil, err
+	}
+
+	b.Lock()
+	defer b.Unlock()
+
+	b.ranges[key] = res
+	return res, nil
+}
+
+// post sends the request to the first etcd address that responds, decoding the response into v.
+func (b *broker) post(path string, req, v interface{}) (err error) {
+	for _, addr := range b.addresses {
+		err = b.postAddress(addr, path, req, v, true)
+		if err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+func (b *broker) postAddress(addr, path string, req, v interface{}, retry bool) error {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	token, err := b.authenticate(addr)
+	if err != nil {
+		return err
+	}
+
+	hr, err := http.NewRequest(http.MethodPost, addr+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	hr.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		hr.Header.Set("Authorization", token)
+	}
+
+	res, err := b.client.Do(hr)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode == http.StatusUnauthorized && token != "" && retry {
+		// The token has probably expired, so get a new one.
+		b.Lock()
+		b.token = ""
+		b.Unlock()
+		return b.postAddress(addr, path, req, v, false)
+	}
+	if res.StatusCode != http.StatusOK {
+		return common.ErrorMsg("etcd returned %s: %s", res.Status, strings.TrimSpace(string(body)))
+	}
+
+	err = json.Unmarshal(body, v)
+	if err != nil {
+		return common.Error(err, "failed to parse etcd response")
+	}
+	return nil
+}
+
+// authenticate returns the auth token to use for requests (or "" if there is no username).
+func (b *broker) authenticate(addr string) (string, error) {
+	if b.username == "" {
+		return "", nil
+	}
+
+	b.RLock()
+	token := b.token
+	b.RUnlock()
+
+	if token != "" {
+		return token, nil
+	}
+
+	data, err := json.Marshal(map[string]string{
+		"name":     b.username,
+		"password": b.password,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	res, err := b.client.Post(addr+"/auth/authenticate", "application/json", bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return "", err
+	}
+	if res.StatusCode != http.StatusOK {
+		return "", common.ErrorMsg("etcd authentication returned %s: %s", res.Status, strings.TrimSpace(string(body)))
+	}
+
+	auth := &struct {
+		Token string `json:"token"`
+	}{}
+	err = json.Unmarshal(body, auth)
+	if err != nil {
+		return "", common.Error(err, "failed to parse etcd authentication response")
+	}
+
+	b.Lock()
+	b.token = auth.Token
+	b.Unlock()
+
+	return auth.Token, nil
 }
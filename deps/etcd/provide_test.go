@@ -0,0 +1,330 @@
+package etcd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/norganna/cynosure/common"
+)
+
+// fakeEtcd is an httptest stand-in for the etcd v3 JSON gateway.
+type fakeEtcd struct {
+	sync.Mutex
+
+	keys map[string]string
+	// requests[path] = count
+	requests map[string]int
+	// token is the currently valid token (tokens are only required if it is set).
+	token  string
+	issued int
+}
+
+func (f *fakeEtcd) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.requests[r.URL.Path]++
+
+	switch r.URL.Path {
+	case "/v3/auth/authenticate":
+		auth := map[string]string{}
+		_ = json.NewDecoder(r.Body).Decode(&auth)
+		if auth["name"] != "root" || auth["password"] != "secret" {
+			http.Error(w, `{"error":"authentication failed, invalid user ID or password"}`, http.StatusBadRequest)
+			return
+		}
+		f.issued++
+		f.token = fmt.Sprintf("token%d", f.issued)
+		_, _ = fmt.Fprintf(w, `{"token":%q}`, f.token)
+	case "/v3/kv/range":
+		if f.token != "" && r.Header.Get("Authorization") != f.token {
+			http.Error(w, `{"error":"invalid auth token"}`, http.StatusUnauthorized)
+			return
+		}
+
+		req := &rangeRequest{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		res := &rangeResult{}
+		for k, v := range f.keys {
+			key := []byte(k)
+			if req.RangeEnd == nil && k != string(req.Key) {
+				continue
+			}
+			if req.RangeEnd != nil && (bytes.Compare(key, req.Key) < 0 || bytes.Compare(key, req.RangeEnd) >= 0) {
+				continue
+			}
+			res.Count++
+			if !req.CountOnly {
+				res.Kvs = append(res.Kvs, &keyValue{Key: key, Value: []byte(v)})
+			}
+		}
+		_ = json.NewEncoder(w).Encode(res)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (f *fakeEtcd) count(path string) int {
+	f.Lock()
+	defer f.Unlock()
+
+	return f.requests[path]
+}
+
+// newFake starts a fake etcd and returns a broker for it with the extra config.
+func newFake(t *testing.T, config common.StringMap) (*fakeEtcd, *broker, func()) {
+	f := &fakeEtcd{
+		keys: map[string]string{
+			"config/mode":       "live",
+			"services/web/1":    "10.0.0.1",
+			"services/web/2":    "10.0.0.2",
+			"services/webhooks": "10.0.0.3",
+		},
+		requests: map[string]int{},
+	}
+	server := httptest.NewServer(f)
+
+	if config == nil {
+		config = common.StringMap{}
+	}
+	if config["address"] == "" {
+		config["address"] = strings.TrimPrefix(server.URL, "http://")
+	}
+
+	b, err := create(config)
+	if err != nil {
+		server.Close()
+		t.Fatal(err)
+	}
+	return f, b.(*broker), server.Close
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name string
+		wait string
+		ok   bool
+		msg  string
+	}{
+		{"key", "config/mode", true, "etcd key config/mode good"},
+		{"key equals", `{"key":"config/mode","equals":"live"}`, true, "etcd key config/mode good"},
+		{"key equals other", `{"key":"config/mode","equals":"maintenance"}`, false, "etcd key config/mode waiting for value"},
+		{"key equals empty", `{"key":"config/mode","equals":""}`, false, "etcd key config/mode waiting for value"},
+		{"key contains", `{"key":"config/mode","contains":"iv"}`, true, "etcd key config/mode good"},
+		{"key both", `{"key":"config/mode","equals":"x","contains":"x"}`, false, "etcd key config/mode waiting for value, value text"},
+		{"key missing", "config/other", false, "etcd key config/other not found"},
+		{"prefix", `{"prefix":"services/web/"}`, true, "etcd prefix services/web/ 2/1 keys"},
+		{"prefix count", `{"prefix":"services/web","count":3}`, true, "etcd prefix services/web 3/3 keys"},
+		{"prefix waiting", `{"prefix":"services/web/","count":3}`, false, "etcd prefix services/web/ 2/3 keys waiting"},
+		{"prefix none", `{"prefix":"services/db/"}`, false, "etcd prefix services/db/ 0/1 keys waiting"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, b, done := newFake(t, nil)
+			defer done()
+
+			d, err := b.Dep(tt.wait)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg, ok := d.Check()
+			if ok != tt.ok || msg != tt.msg {
+				t.Errorf("got %v %q, want %v %q", ok, msg, tt.ok, tt.msg)
+			}
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	f, b, done := newFake(t, common.StringMap{"username": "root", "password": "secret"})
+	defer done()
+
+	d, err := b.Dep("config/mode")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if msg, ok := d.Check(); !ok {
+		t.Fatalf("got %q", msg)
+	}
+	if b.token != "token1" {
+		t.Errorf("got token %q", b.token)
+	}
+
+	// An expired token is replaced, and the request retried.
+	f.Lock()
+	f.token = "revoked"
+	f.Unlock()
+	b.Lock()
+	b.ranges = map[string]*rangeResult{}
+	b.Unlock()
+
+	if msg, ok := d.Check(); !ok {
+		t.Fatalf("got %q after the token expired", msg)
+	}
+	if b.token != "token2" || f.count("/v3/auth/authenticate") != 2 {
+		t.Errorf("got token %q after %d authentications", b.token, f.count("/v3/auth/authenticate"))
+	}
+
+	// A bad password is reported.
+	b.Lock()
+	b.token = ""
+	b.password = "wrong"
+	b.ranges = map[string]*rangeResult{}
+	b.Unlock()
+
+	msg, ok := d.Check()
+	if ok || !strings.HasPrefix(msg, "etcd key config/mode etcd authentication returned 400 Bad Request: ") {
+		t.Errorf("got %v %q", ok, msg)
+	}
+}
+
+func TestAddresses(t *testing.T) {
+	// The first address isn't listening, so the second is used.
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	f, _, done := newFake(t, nil)
+	server := httptest.NewServer(f)
+	defer server.Close()
+	done()
+
+	b, err := create(common.StringMap{"address": down.URL + ", " + server.URL + "/ ,", "api": "v3/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{down.URL + "/v3", server.URL + "/v3"}
+	if got := b.(*broker).addresses; strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("got addresses %v, want %v", got, want)
+	}
+
+	d, err := b.Dep("config/mode")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg, ok := d.Check(); !ok {
+		t.Errorf("got %q", msg)
+	}
+
+	// With no address responding, the error from the last one is reported.
+	server.Close()
+	b.(*broker).ranges = map[string]*rangeResult{}
+	if msg, ok := d.Check(); ok || !strings.Contains(msg, server.URL+"/v3/kv/range") {
+		t.Errorf("got %v %q", ok, msg)
+	}
+}
+
+func TestCache(t *testing.T) {
+	f, b, done := newFake(t, nil)
+	defer done()
+
+	key, err := b.Dep("config/mode")
+	if err != nil {
+		t.Fatal(err)
+	}
+	equals, err := b.Dep(`{"key":"config/mode","equals":"live"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prefix, err := b.Dep(`{"prefix":"config/"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Dependencies on the same range share a result, while different ranges don't.
+	for i := 0; i < 3; i++ {
+		_, _ = key.Check()
+		_, _ = equals.Check()
+		_, _ = prefix.Check()
+	}
+	if n := f.count("/v3/kv/range"); n != 2 {
+		t.Errorf("got %d requests, want 2", n)
+	}
+
+	// Results expire after the TTL.
+	b.Lock()
+	for _, r := range b.ranges {
+		r.ts = r.ts.Add(-cacheTTL - time.Second)
+	}
+	b.Unlock()
+
+	_, _ = key.Check()
+	if n := f.count("/v3/kv/range"); n != 3 {
+		t.Errorf("got %d requests after expiry, want 3", n)
+	}
+}
+
+func TestPrefixEnd(t *testing.T) {
+	tests := []struct {
+		prefix string
+		end    string
+	}{
+		{"services/", "services0"},
+		{"a", "b"},
+		{"a\xff", "b"},
+		{"a\xff\xff", "b"},
+		{"\xff", "\x00"},
+	}
+
+	for _, tt := range tests {
+		if end := string(prefixEnd([]byte(tt.prefix))); end != tt.end {
+			t.Errorf("%q: got %q, want %q", tt.prefix, end, tt.end)
+		}
+	}
+}
+
+func TestCreate(t *testing.T) {
+	tests := []struct {
+		config    common.StringMap
+		addresses []string
+		fails     bool
+	}{
+		{common.StringMap{"address": "127.0.0.1:2379"}, []string{"http://127.0.0.1:2379/v3"}, false},
+		{common.StringMap{"address": "https://etcd:2379/", "api": "/v3beta"}, []string{"https://etcd:2379/v3beta"}, false},
+		{common.StringMap{"address": "a:1,b:2", "insecure": "true"}, []string{"https://a:1/v3", "https://b:2/v3"}, false},
+		{common.StringMap{}, nil, true},
+		{common.StringMap{"address": "a:1", "ca": "/nonexistent/ca.pem"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		b, err := create(tt.config)
+		if (err != nil) != tt.fails {
+			t.Errorf("%v: got error %v", tt.config, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if got := b.(*broker).addresses; strings.Join(got, " ") != strings.Join(tt.addresses, " ") {
+			t.Errorf("%v: got addresses %v, want %v", tt.config, got, tt.addresses)
+		}
+	}
+}
+
+func TestDepInvalid(t *testing.T) {
+	b := &broker{}
+	for _, wait := range []string{
+		"",
+		`{}`,
+		`{"count":2}`,
+		`{"key":"a","prefix":"b"}`,
+		`{"key":`,
+	} {
+		if _, err := b.Dep(wait); err == nil {
+			t.Errorf("%s: expected an error", wait)
+		}
+	}
+}
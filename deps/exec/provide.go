@@ -0,0 +1,232 @@
+// Package exec is a provider that runs a command to check something.
+//
+// Accepts config:
+//    timeout: MILLISECONDS
+//
+// The MILLISECONDS is the default time that a command has to complete (default 10000).
+//
+// The "wait" parameter will be either a plain command line, or JSON of the form:
+//    {
+//      "command": COMMAND,
+//      "image": IMAGE,
+//      "env": [
+//        "KEY=VALUE",
+//        ...
+//      ],
+//      "timeout": MILLISECONDS
+//    }
+//
+// If only a command line is supplied, it will expand to:
+//
+//    {"command": COMMAND}
+//
+// The COMMAND is split into words (which may be quoted with ' or ") and run directly, not through a shell, e.g.
+// `pg_isready -h db -p 5432`. The check passes when it exits with code 0, and the last line of its output is used as
+// the check message.
+//
+// The command is run with a minimal environment of `PATH` and the given "env" values, in its own process group which
+// is killed if it doesn't complete in time.
+//
+// If an IMAGE (of the form `NAME:VERSION`) is given, the command is run with the image's file system as its root,
+// which requires the server to be running as root. The command is found using the image's standard `PATH` folders.
+package exec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	osexec "os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/norganna/cynosure/common"
+	"github.com/norganna/cynosure/deps"
+	"github.com/norganna/cynosure/process"
+)
+
+// Kind contains the kind string of this provider.
+const Kind = "exec"
+
+// defaultTimeout is how long a command has to complete if the config does not specify.
+const defaultTimeout = 10 * time.Second
+
+func init() {
+	deps.RegisterProvider(Kind, create)
+}
+
+// create returns the Broker.
+func create(config common.StringMap) (deps.Broker, error) {
+	b := &broker{
+		timeout: defaultTimeout,
+	}
+
+	if t := config["timeout"]; t != "" {
+		ms, err := strconv.ParseInt(t, 10, 64)
+		if err != nil {
+			return nil, common.Error(err, "failed to parse timeout %s", t)
+		}
+		b.timeout = time.Duration(ms) * time.Millisecond
+	}
+
+	return b, nil
+}
+
+type broker struct {
+	timeout time.Duration
+}
+
+type request struct {
+	Command string   `json:"command,omitempty"`
+	Image   string   `json:"image,omitempty"`
+	Env     []string `json:"env,omitempty"`
+	Timeout int64    `json:"timeout,omitempty"`
+}
+
+func (b *broker) Dep(wait string) (deps.Depender, error) {
+	r := &request{}
+	if wait == "" {
+		return nil, common.ErrorMsg("no command to run")
+	}
+
+	if wait[0:1] != "{" {
+		r.Command = wait
+	} else {
+		err := json.Unmarshal([]byte(wait), r)
+		if err != nil {
+			return nil, common.Error(err, "failed to parse dependency condition JSON")
+		}
+	}
+
+	args, err := splitWords(r.Command)
+	if err != nil {
+		return nil, common.Error(err, "failed to parse command %s", r.Command)
+	}
+	if len(args) == 0 {
+		return nil, common.ErrorMsg("no command to run")
+	}
+
+	timeout := b.timeout
+	if r.Timeout > 0 {
+		timeout = time.Duration(r.Timeout) * time.Millisecond
+	}
+
+	return &dep{
+		r:       r,
+		args:    args,
+		timeout: timeout,
+	}, nil
+}
+
+type dep struct {
+	r       *request
+	args    []string
+	timeout time.Duration
+}
+
+func (d *dep) Check() (msg string, ok bool) {
+	msg = fmt.Sprintf("%s %s", Kind, d.args[0])
+
+	cmd := osexec.Command(d.args[0], d.args[1:]...)
+	env := []string{"PATH=" + os.Getenv("PATH")}
+
+	if d.r.Image != "" {
+		root, err := process.ImageRoot(d.r.Image)
+		if err != nil {
+			return msg + " " + err.Error(), false
+		}
+
+		// The command is found within the image, rather than on the server.
+		entry, err := process.ImageLookPath(root, d.args[0])
+		if err != nil {
+			return msg + " " + err.Error(), false
+		}
+
+		cmd = &osexec.Cmd{
+			Path: entry,
+			Args: d.args,
+			Dir:  "/",
+			SysProcAttr: &syscall.SysProcAttr{
+				Chroot: root,
+			},
+		}
+		env = []string{"PATH=" + process.ImagePath}
+	}
+
+	var out bytes.Buffer
+	cmd.Env = append(env, d.r.Env...)
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.timeout)
+	defer cancel()
+
+	err := process.RunGroup(ctx, cmd)
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Sprintf("%s timed out after %s", msg, d.timeout), false
+	}
+
+	last := lastLine(out.Bytes())
+	if err != nil {
+		if last != "" {
+			return msg + " " + last, false
+		}
+		return msg + " " + err.Error(), false
+	}
+
+	if last != "" {
+		return msg + " " + last, true
+	}
+	return msg + " passed", true
+}
+
+// lastLine returns the last non-blank line of the output.
+func lastLine(out []byte) string {
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+// splitWords splits the command line into words, allowing for quoting and escaping.
+func splitWords(line string) (words []string, err error) {
+	var word strings.Builder
+	var quote rune
+	inWord, escaped := false, false
+
+	for _, c := range line {
+		switch {
+		case escaped:
+			word.WriteRune(c)
+			escaped = false
+		case c == '\\' && quote != '\'':
+			escaped, inWord = true, true
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else {
+				word.WriteRune(c)
+			}
+		case c == '\'' || c == '"':
+			quote, inWord = c, true
+		case c == ' ' || c == '\t' || c == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(c)
+			inWord = true
+		}
+	}
+
+	if quote != 0 || escaped {
+		return nil, common.ErrorMsg("unterminated quote or escape")
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
@@ -0,0 +1,88 @@
+package exec
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestSplitWords(t *testing.T) {
+	tests := []struct {
+		line  string
+		words []string
+		fails bool
+	}{
+		{"pg_isready -h db", []string{"pg_isready", "-h", "db"}, false},
+		{`sh -c 'echo "hi there"'`, []string{"sh", "-c", `echo "hi there"`}, false},
+		{`echo "a \"b\"" c\ d ''`, []string{"echo", `a "b"`, "c d", ""}, false},
+		{"  spaced \t out  ", []string{"spaced", "out"}, false},
+		{"", nil, false},
+		{`echo 'open`, nil, true},
+		{`echo trailing\`, nil, true},
+	}
+
+	for _, tt := range tests {
+		words, err := splitWords(tt.line)
+		if (err != nil) != tt.fails {
+			t.Errorf("%s: got error %v", tt.line, err)
+			continue
+		}
+		if len(words) != len(tt.words) {
+			t.Errorf("%s: got %q, want %q", tt.line, words, tt.words)
+			continue
+		}
+		for i := range words {
+			if words[i] != tt.words[i] {
+				t.Errorf("%s: got %q, want %q", tt.line, words, tt.words)
+				break
+			}
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	_ = os.Setenv("CYNOSURE_TEST_SECRET", "leaked")
+	defer func() {
+		_ = os.Unsetenv("CYNOSURE_TEST_SECRET")
+	}()
+
+	b, err := create(map[string]string{"timeout": "300"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		wait string
+		ok   bool
+		msg  string
+	}{
+		{"passes", "true", true, "exec true passed"},
+		{"last line", `sh -c "echo starting; echo ready"`, true, "exec sh ready"},
+		{"fails", "false", false, "exec false exit status 1"},
+		{"fails with output", `sh -c "echo not ready; exit 2"`, false, "exec sh not ready"},
+		{"not found", "no-such-command-here", false, `exec no-such-command-here exec: "no-such-command-here": executable file not found in $PATH`},
+		{"minimal env", `{"command":"sh -c 'echo ${CYNOSURE_TEST_SECRET:-unset} $EXTRA'","env":["EXTRA=given"]}`, true, "exec sh unset given"},
+		{"times out", "sleep 5", false, "exec sleep timed out after 300ms"},
+		{"child times out", `sh -c "sleep 5 & sleep 5"`, false, "exec sh timed out after 300ms"},
+		{"own timeout", `{"command":"sleep 5","timeout":100}`, false, "exec sleep timed out after 100ms"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := b.Dep(tt.wait)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			start := time.Now()
+			msg, ok := d.Check()
+			if elapsed := time.Now().Sub(start); elapsed > time.Second {
+				t.Errorf("check took %s", elapsed)
+			}
+			if ok != tt.ok || msg != tt.msg {
+				t.Errorf("got %v %q, want %v %q", ok, msg, tt.ok, tt.msg)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package deps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/norganna/cynosure/common"
+)
+
+// Op is the operator of an Expr.
+type Op int
+
+// Operators.
+const (
+	// OpLeaf passes if the Dep passes.
+	OpLeaf Op = iota
+	// OpAnd passes if all of the Args pass.
+	OpAnd
+	// OpOr passes if any of the Args pass.
+	OpOr
+	// OpNot passes if its single Args item fails.
+	OpNot
+	// OpQuorum passes if at least Quorum of the Args pass.
+	OpQuorum
+)
+
+// Standard error messages.
+var (
+	ErrExprNoDep     = common.ErrorMsg("leaf expression has no dependency")
+	ErrExprNoArgs    = common.ErrorMsg("expression has no operands")
+	ErrExprNotArgs   = common.ErrorMsg("not expression must have exactly one operand")
+	ErrExprQuorum    = common.ErrorMsg("quorum is more than the number of operands")
+	ErrExprUnknownOp = common.ErrorMsg("unknown expression operator")
+)
+
+// Expr is a boolean expression over dependencies, which is itself a Depender.
+type Expr struct {
+	Op     Op
+	Dep    Depender
+	Args   []*Expr
+	Quorum int
+}
+
+var _ Depender = (*Expr)(nil)
+
+// ExprResult is the outcome of evaluating an Expr, with the outcome of each of its operands.
+//
+// Operands after the outcome is known are not checked.
+type ExprResult struct {
+	Op      Op
+	Quorum  int
+	Checked bool
+	OK      bool
+	Message string
+
+	Args []*ExprResult
+}
+
+// Validate checks the expression is well formed.
+func (e *Expr) Validate() error {
+	switch e.Op {
+	case OpLeaf:
+		if e.Dep == nil {
+			return ErrExprNoDep
+		}
+		return nil
+	case OpAnd, OpOr:
+		if len(e.Args) == 0 {
+			return ErrExprNoArgs
+		}
+	case OpNot:
+		if len(e.Args) != 1 {
+			return ErrExprNotArgs
+		}
+	case OpQuorum:
+		if len(e.Args) == 0 {
+			return ErrExprNoArgs
+		}
+		if e.Quorum > len(e.Args) {
+			return common.Error(ErrExprQuorum, "failed to validate quorum of %d", e.Quorum)
+		}
+	default:
+		return common.Error(ErrExprUnknownOp, "failed to validate operator %d", e.Op)
+	}
+
+	for _, arg := range e.Args {
+		err := arg.Validate()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Check evaluates the expression and returns its message and whether it passed.
+func (e *Expr) Check() (string, bool) {
+	r := e.Evaluate()
+	return r.Message, r.OK
+}
+
+// Evaluate checks the expression and returns the outcome of it and its operands.
+func (e *Expr) Evaluate() *ExprResult {
+	r := e.unchecked()
+	r.Checked = true
+
+	switch e.Op {
+	case OpLeaf:
+		r.Message, r.OK = e.Dep.Check()
+	case OpAnd:
+		r.OK = true
+		var msg []string
+		for i, arg := range e.Args {
+			ar := arg.Evaluate()
+			r.Args[i] = ar
+			if !ar.OK {
+				// The first failure is blocking the expression.
+				r.OK = false
+				msg = []string{ar.Message}
+				break
+			}
+			msg = append(msg, ar.Message)
+		}
+		r.Message = group(msg, " and ")
+	case OpOr:
+		var msg []string
+		for i, arg := range e.Args {
+			ar := arg.Evaluate()
+			r.Args[i] = ar
+			if ar.OK {
+				r.OK = true
+				msg = []string{ar.Message}
+				break
+			}
+			msg = append(msg, ar.Message)
+		}
+		r.Message = group(msg, " or ")
+	case OpNot:
+		ar := e.Args[0].Evaluate()
+		r.Args[0] = ar
+		r.OK = !ar.OK
+		r.Message = "not (" + ar.Message + ")"
+	case OpQuorum:
+		passed, failed := 0, 0
+		var msg []string
+		for i, arg := range e.Args {
+			ar := arg.Evaluate()
+			r.Args[i] = ar
+			msg = append(msg, ar.Message)
+			if ar.OK {
+				passed++
+			} else {
+				failed++
+			}
+			if passed >= r.Quorum || len(e.Args)-failed < r.Quorum {
+				break
+			}
+		}
+		r.OK = passed >= r.Quorum
+		r.Message = fmt.Sprintf("%d of %d passed (need %d) (%s)", passed, len(e.Args), r.Quorum, strings.Join(msg, "; "))
+	}
+	return r
+}
+
+// unchecked returns the result of the expression before it has been checked.
+func (e *Expr) unchecked() *ExprResult {
+	r := &ExprResult{
+		Op:   e.Op,
+		Args: make([]*ExprResult, len(e.Args)),
+	}
+
+	if e.Op == OpQuorum {
+		r.Quorum = e.Quorum
+		if r.Quorum < 1 {
+			r.Quorum = len(e.Args)/2 + 1
+		}
+	}
+
+	for i, arg := range e.Args {
+		r.Args[i] = arg.unchecked()
+	}
+	return r
+}
+
+// group joins the messages, wrapping them in brackets if there is more than one.
+func group(msg []string, sep string) string {
+	if len(msg) == 1 {
+		return msg[0]
+	}
+	return "(" + strings.Join(msg, sep) + ")"
+}
@@ -0,0 +1,170 @@
+package deps
+
+import (
+	"testing"
+
+	"github.com/norganna/cynosure/common"
+)
+
+// fakeDep is a dependency with a fixed outcome that counts its checks.
+type fakeDep struct {
+	name   string
+	ok     bool
+	checks int
+}
+
+func (d *fakeDep) Check() (string, bool) {
+	d.checks++
+	if d.ok {
+		return d.name + " up", true
+	}
+	return d.name + " down", false
+}
+
+// leaves returns a leaf expression for each of the named dependencies, which pass if their name is upper case.
+func leaves(names ...string) ([]*Expr, map[string]*fakeDep) {
+	var exprs []*Expr
+	fakes := map[string]*fakeDep{}
+	for _, name := range names {
+		d := &fakeDep{name: name, ok: name[0] >= 'A' && name[0] <= 'Z'}
+		fakes[name] = d
+		exprs = append(exprs, &Expr{Op: OpLeaf, Dep: d})
+	}
+	return exprs, fakes
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name    string
+		op      Op
+		quorum  int
+		deps    []string
+		ok      bool
+		msg     string
+		checked []string
+	}{
+		{"and passes", OpAnd, 0, []string{"A", "B"}, true, "(A up and B up)", []string{"A", "B"}},
+		{"and blocked", OpAnd, 0, []string{"A", "b", "C"}, false, "b down", []string{"A", "b"}},
+		{"or passes", OpOr, 0, []string{"a", "B", "C"}, true, "B up", []string{"a", "B"}},
+		{"or fails", OpOr, 0, []string{"a", "b"}, false, "(a down or b down)", []string{"a", "b"}},
+		{"not passes", OpNot, 0, []string{"a"}, true, "not (a down)", []string{"a"}},
+		{"not fails", OpNot, 0, []string{"A"}, false, "not (A up)", []string{"A"}},
+		{"quorum met", OpQuorum, 2, []string{"A", "b", "C", "D"}, true, "2 of 4 passed (need 2) (A up; b down; C up)", []string{"A", "b", "C"}},
+		{"quorum impossible", OpQuorum, 2, []string{"a", "b", "C"}, false, "0 of 3 passed (need 2) (a down; b down)", []string{"a", "b"}},
+		{"quorum majority", OpQuorum, 0, []string{"A", "b", "C"}, true, "2 of 3 passed (need 2) (A up; b down; C up)", []string{"A", "b", "C"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, fakes := leaves(tt.deps...)
+			e := &Expr{Op: tt.op, Quorum: tt.quorum, Args: args}
+			if err := e.Validate(); err != nil {
+				t.Fatal(err)
+			}
+
+			msg, ok := e.Check()
+			if ok != tt.ok || msg != tt.msg {
+				t.Errorf("got %v %q, want %v %q", ok, msg, tt.ok, tt.msg)
+			}
+
+			// Operands after the outcome is known are not checked.
+			checked := map[string]bool{}
+			for _, name := range tt.checked {
+				checked[name] = true
+			}
+			for name, d := range fakes {
+				if (d.checks > 0) != checked[name] {
+					t.Errorf("%s checked %d times", name, d.checks)
+				}
+			}
+		})
+	}
+}
+
+func TestEvaluateTree(t *testing.T) {
+	// (A and b) or not c or quorum 2 of (d, E, F)
+	ab, _ := leaves("A", "b")
+	c, _ := leaves("c")
+	def, _ := leaves("d", "E", "F")
+	e := &Expr{Op: OpOr, Args: []*Expr{
+		{Op: OpAnd, Args: ab},
+		{Op: OpNot, Args: []*Expr{{Op: OpNot, Args: c}}},
+		{Op: OpQuorum, Quorum: 2, Args: def},
+	}}
+	if err := e.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	r := e.Evaluate()
+	if !r.OK || r.Message != "2 of 3 passed (need 2) (d down; E up; F up)" {
+		t.Errorf("got %v %q", r.OK, r.Message)
+	}
+
+	// The reported tree shows each branch and what blocked it.
+	and, not, quorum := r.Args[0], r.Args[1], r.Args[2]
+	want := []struct {
+		name    string
+		r       *ExprResult
+		checked bool
+		ok      bool
+		msg     string
+	}{
+		{"and", and, true, false, "b down"},
+		{"and A", and.Args[0], true, true, "A up"},
+		{"and b", and.Args[1], true, false, "b down"},
+		{"not not", not, true, false, "not (not (c down))"},
+		{"not", not.Args[0], true, true, "not (c down)"},
+		{"not c", not.Args[0].Args[0], true, false, "c down"},
+		{"quorum", quorum, true, true, "2 of 3 passed (need 2) (d down; E up; F up)"},
+		{"quorum d", quorum.Args[0], true, false, "d down"},
+	}
+	for _, w := range want {
+		if w.r.Checked != w.checked || w.r.OK != w.ok || w.r.Message != w.msg {
+			t.Errorf("%s: got %v %v %q, want %v %v %q", w.name, w.r.Checked, w.r.OK, w.r.Message, w.checked, w.ok, w.msg)
+		}
+	}
+	if quorum.Quorum != 2 || quorum.Op != OpQuorum {
+		t.Errorf("got quorum result %v", quorum)
+	}
+
+	// Once an operand passes, the rest are reported as not checked.
+	ab[1].Dep.(*fakeDep).ok = true
+	r = e.Evaluate()
+	if !r.OK || !r.Args[0].Checked || r.Args[1].Checked || r.Args[2].Checked || r.Args[2].Args[0].Checked {
+		t.Error("operands after the outcome was known were reported as checked")
+	}
+	if r.Args[2].Quorum != 2 {
+		t.Errorf("got unchecked quorum %d", r.Args[2].Quorum)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	two, _ := leaves("A", "B")
+	tests := []struct {
+		name string
+		e    *Expr
+		err  error
+	}{
+		{"leaf", two[0], nil},
+		{"leaf without dep", &Expr{Op: OpLeaf}, ErrExprNoDep},
+		{"and without args", &Expr{Op: OpAnd}, ErrExprNoArgs},
+		{"or without args", &Expr{Op: OpOr}, ErrExprNoArgs},
+		{"not with two args", &Expr{Op: OpNot, Args: two}, ErrExprNotArgs},
+		{"quorum without args", &Expr{Op: OpQuorum, Quorum: 1}, ErrExprNoArgs},
+		{"quorum too large", &Expr{Op: OpQuorum, Quorum: 3, Args: two}, ErrExprQuorum},
+		{"unknown op", &Expr{Op: Op(99), Args: two}, ErrExprUnknownOp},
+		{"nested", &Expr{Op: OpAnd, Args: []*Expr{two[0], {Op: OpOr}}}, ErrExprNoArgs},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.e.Validate()
+			if ce, ok := err.(*common.CynoError); ok && ce.Err != nil {
+				err = ce.Err
+			}
+			if err != tt.err {
+				t.Errorf("got error %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
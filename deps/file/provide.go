@@ -0,0 +1,112 @@
+// Package file is a provider that checks files exist.
+//
+// Accepts no config.
+//
+// The "wait" parameter will be either a plain file path, or JSON of the form:
+//    {
+//      "path": PATH,
+//      "contains": FIND,
+//      "directory": BOOL
+//    }
+//
+// If only a path is supplied, it will expand to:
+//
+//    {"path": PATH}
+//
+// Waits for PATH to exist. If FIND is given, the file must contain it. If BOOL is true, the PATH must be a directory,
+// otherwise it must not be.
+package file
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/norganna/cynosure/common"
+	"github.com/norganna/cynosure/deps"
+)
+
+// Kind contains the kind string of this provider.
+const Kind = "file"
+
+func init() {
+	deps.RegisterProvider(Kind, create)
+}
+
+// create returns the Broker.
+func create(_ common.StringMap) (deps.Broker, error) {
+	b := &broker{}
+	return b, nil
+}
+
+type broker struct{}
+
+type request struct {
+	Path      string `json:"path,omitempty"`
+	Contains  string `json:"contains,omitempty"`
+	Directory bool   `json:"directory,omitempty"`
+}
+
+func (b *broker) Dep(wait string) (deps.Depender, error) {
+	r := &request{}
+	if wait == "" {
+		return nil, common.ErrorMsg("no file to wait for")
+	}
+
+	if wait[0:1] != "{" {
+		r.Path = wait
+	} else {
+		err := json.Unmarshal([]byte(wait), r)
+		if err != nil {
+			return nil, common.Error(err, "failed to parse dependency condition JSON")
+		}
+	}
+
+	if r.Path == "" {
+		return nil, common.ErrorMsg("no file to wait for")
+	}
+	if r.Directory && r.Contains != "" {
+		return nil, common.ErrorMsg("cannot wait for a directory to contain text")
+	}
+
+	return &dep{
+		r: r,
+	}, nil
+}
+
+type dep struct {
+	r *request
+}
+
+func (d *dep) Check() (msg string, ok bool) {
+	msg = fmt.Sprintf("%s %s", Kind, d.r.Path)
+
+	info, err := os.Stat(d.r.Path)
+	if os.IsNotExist(err) {
+		return msg + " not found", false
+	}
+	if err != nil {
+		return msg + " " + err.Error(), false
+	}
+
+	if d.r.Directory != info.IsDir() {
+		if info.IsDir() {
+			return msg + " is a directory", false
+		}
+		return msg + " is not a directory", false
+	}
+
+	if d.r.Contains != "" {
+		data, err := ioutil.ReadFile(d.r.Path)
+		if err != nil {
+			return msg + " " + err.Error(), false
+		}
+		if !bytes.Contains(data, []byte(d.r.Contains)) {
+			return msg + " waiting for text", false
+		}
+	}
+
+	return msg + " found", true
+}
@@ -0,0 +1,88 @@
+package file
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	dir, err := ioutil.TempDir("", "file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	ready := filepath.Join(dir, "ready")
+	if err := ioutil.WriteFile(ready, []byte("state: started\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(dir, "missing")
+
+	b, err := create(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		wait string
+		ok   bool
+		msg  string
+	}{
+		{"file", ready, true, "found"},
+		{"json", `{"path":"` + ready + `"}`, true, "found"},
+		{"contains", `{"path":"` + ready + `","contains":"started"}`, true, "found"},
+		{"waiting for text", `{"path":"` + ready + `","contains":"stopped"}`, false, "waiting for text"},
+		{"not found", missing, false, "not found"},
+		{"directory", `{"path":"` + dir + `","directory":true}`, true, "found"},
+		{"is a directory", dir, false, "is a directory"},
+		{"is not a directory", `{"path":"` + ready + `","directory":true}`, false, "is not a directory"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := b.Dep(tt.wait)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg, ok := d.Check()
+			path := d.(*dep).r.Path
+			if ok != tt.ok || msg != "file "+path+" "+tt.msg {
+				t.Errorf("got %v %q, want %v %q", ok, strings.TrimPrefix(msg, "file "+path+" "), tt.ok, tt.msg)
+			}
+		})
+	}
+
+	// A file that appears later is found.
+	d, err := b.Dep(missing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(missing, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if msg, ok := d.Check(); !ok {
+		t.Errorf("got %q once created", msg)
+	}
+}
+
+func TestDepInvalid(t *testing.T) {
+	b := &broker{}
+	for _, wait := range []string{
+		"",
+		`{}`,
+		`{"contains":"text"}`,
+		`{"path":"/tmp","directory":true,"contains":"text"}`,
+		`{"path":`,
+	} {
+		if _, err := b.Dep(wait); err == nil {
+			t.Errorf("%s: expected an error", wait)
+		}
+	}
+}
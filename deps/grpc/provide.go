@@ -0,0 +1,259 @@
+// Package grpc is a provider that checks services with the gRPC health checking protocol (`grpc.health.v1.Health`).
+//
+// Accepts config:
+//    security: "plaintext", "tls" or "mtls"
+//    file: PATH
+//    server: NAME
+//    timeout: MILLISECONDS
+//    ca: PATH
+//    cert: PATH
+//    key: PATH
+//    insecure: "true"
+//
+// If security is "plaintext" (default), will connect without TLS.
+//
+// If security is "tls", will connect with TLS, trusting the system certificates (or those in the ca PATH).
+//
+// If security is "mtls", will connect with TLS and present a client certificate. The certificates are either taken
+// from the cynosure client config file at the file PATH (as generated by `cynosure config`), or from the ca, cert and
+// key PATHs.
+//
+// The ca, cert, key and insecure options are described in `deps.TLSConfig`. The NAME is the name that the server
+// certificate must be valid for (default is the host being connected to). The MILLISECONDS is the time that each
+// check has to complete (default 1000).
+//
+// The "wait" parameter will be either of the form:
+//   [HOST]:PORT[/SERVICE]
+//
+// or JSON of the form:
+//    {"address": [HOST]:PORT, "service": SERVICE}
+//
+// HOST is either an IPv4, IPv6 address or resolvable host name (default = 127.0.0.1).
+// PORT is a port number.
+// SERVICE is the name of the service to check (default is the overall health of the server).
+//
+// A check only passes when the server replies that the service is `SERVING`.
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/norganna/cynosure/common"
+	"github.com/norganna/cynosure/deps"
+	rpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	health "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// Kind contains the kind string of this provider.
+const Kind = "grpc"
+
+// Security options.
+const (
+	SecurityPlaintext = "plaintext"
+	SecurityTLS       = "tls"
+	SecurityMTLS      = "mtls"
+)
+
+// defaultTimeout is how long a check has to complete if the config does not specify.
+const defaultTimeout = time.Second
+
+func init() {
+	deps.RegisterProvider(Kind, create)
+}
+
+// create returns the Broker.
+func create(config common.StringMap) (br deps.Broker, err error) {
+	b := &broker{
+		serverName: config["server"],
+		timeout:    defaultTimeout,
+		conns:      map[string]*rpc.ClientConn{},
+	}
+
+	if t := config["timeout"]; t != "" {
+		ms, err := strconv.ParseInt(t, 10, 64)
+		if err != nil {
+			return nil, common.Error(err, "failed to parse timeout %s", t)
+		}
+		b.timeout = time.Duration(ms) * time.Millisecond
+	}
+
+	security := config["security"]
+	switch security {
+	case "", SecurityPlaintext:
+	case SecurityTLS, SecurityMTLS:
+		if file := config["file"]; file != "" {
+			b.tls, err = configTLS(file)
+		} else {
+			b.tls, err = deps.TLSConfig(config)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if b.tls == nil {
+			b.tls = &tls.Config{}
+		}
+		if security == SecurityMTLS && len(b.tls.Certificates) == 0 {
+			return nil, common.ErrorMsg("must supply a client certificate for mtls")
+		}
+	default:
+		return nil, common.ErrorMsg("unknown security %s", security)
+	}
+
+	return b, nil
+}
+
+// configTLS returns the TLS config that uses the certificates from the cynosure client config file.
+func configTLS(file string) (*tls.Config, error) {
+	config, err := common.LoadConfig(common.Logger(), file)
+	if err != nil {
+		return nil, err
+	}
+
+	crt, err := config.ClientCert(365)
+	if err != nil {
+		return nil, common.Error(err, "failed to get client certificate")
+	}
+	if crt == nil {
+		return nil, common.ErrorMsg("no client certificate in config")
+	}
+
+	pool, err := config.CertPool()
+	if err != nil {
+		return nil, common.Error(err, "failed to obtain a certificate pool")
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{*crt},
+		RootCAs:      pool,
+	}, nil
+}
+
+type broker struct {
+	sync.Mutex
+
+	tls        *tls.Config
+	serverName string
+	timeout    time.Duration
+
+	// conns[address]
+	conns map[string]*rpc.ClientConn
+}
+
+// conn returns the (shared) connection to the address, dialling it if required.
+func (b *broker) conn(address, host string) (*rpc.ClientConn, error) {
+	b.Lock()
+	defer b.Unlock()
+
+	if conn, ok := b.conns[address]; ok {
+		return conn, nil
+	}
+
+	opt := rpc.WithInsecure()
+	if b.tls != nil {
+		c := b.tls.Clone()
+		c.ServerName = b.serverName
+		if c.ServerName == "" {
+			c.ServerName = host
+		}
+		opt = rpc.WithTransportCredentials(credentials.NewTLS(c))
+	}
+
+	// Without blocking, the connection is made (and remade) in the background as it is used.
+	conn, err := rpc.Dial("passthrough:///"+address, opt)
+	if err != nil {
+		return nil, common.Error(err, "failed to dial %s", address)
+	}
+
+	b.conns[address] = conn
+	return conn, nil
+}
+
+type request struct {
+	Address string `json:"address,omitempty"`
+	Service string `json:"service,omitempty"`
+}
+
+func (b *broker) Dep(wait string) (deps.Depender, error) {
+	r := &request{}
+	if wait == "" {
+		return nil, common.ErrorMsg("no address to check")
+	}
+
+	if wait[0:1] != "{" {
+		parts := strings.SplitN(wait, "/", 2)
+		r.Address = parts[0]
+		if len(parts) == 2 {
+			r.Service = parts[1]
+		}
+	} else {
+		err := json.Unmarshal([]byte(wait), r)
+		if err != nil {
+			return nil, common.Error(err, "failed to parse dependency condition JSON")
+		}
+	}
+
+	host, port, err := net.SplitHostPort(r.Address)
+	if err != nil {
+		return nil, common.Error(err, "failed to parse host/port %s", r.Address)
+	}
+	if host == "" {
+		host = "127.0.0.1"
+	}
+
+	return &dep{
+		b:       b,
+		r:       r,
+		host:    host,
+		address: net.JoinHostPort(host, port),
+	}, nil
+}
+
+type dep struct {
+	b       *broker
+	r       *request
+	host    string
+	address string
+}
+
+func (d *dep) Check() (msg string, ok bool) {
+	msg = fmt.Sprintf("%s %s", Kind, d.address)
+	if d.r.Service != "" {
+		msg += "/" + d.r.Service
+	}
+
+	conn, err := d.b.conn(d.address, d.host)
+	if err != nil {
+		return msg + " " + err.Error(), false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.b.timeout)
+	defer cancel()
+
+	res, err := health.NewHealthClient(conn).Check(ctx, &health.HealthCheckRequest{
+		Service: d.r.Service,
+	})
+	if err != nil {
+		s := status.Convert(err)
+		switch s.Code() {
+		case codes.Unimplemented:
+			return msg + " health checking not implemented", false
+		case codes.DeadlineExceeded:
+			return msg + " timeout", false
+		}
+		return msg + " " + s.Message(), false
+	}
+
+	state := res.GetStatus()
+	return msg + " " + strings.ToLower(state.String()), state == health.HealthCheckResponse_SERVING
+}
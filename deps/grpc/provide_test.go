@@ -0,0 +1,183 @@
+package grpc
+
+import (
+	"net"
+	"testing"
+
+	"github.com/norganna/cynosure/common"
+	rpc "google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// serve starts a gRPC server on a local port, with a health service if one is given.
+func serve(t *testing.T, hs *health.Server) (string, func()) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := rpc.NewServer()
+	if hs != nil {
+		healthpb.RegisterHealthServer(s, hs)
+	}
+	go func() {
+		_ = s.Serve(l)
+	}()
+
+	return l.Addr().String(), s.Stop
+}
+
+func TestCheck(t *testing.T) {
+	hs := health.NewServer()
+	hs.SetServingStatus("web", healthpb.HealthCheckResponse_SERVING)
+	hs.SetServingStatus("db", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	addr, done := serve(t, hs)
+	defer done()
+
+	_, port, _ := net.SplitHostPort(addr)
+
+	b, err := create(common.StringMap{"timeout": "500"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		wait string
+		ok   bool
+		msg  string
+	}{
+		{"server", addr, true, "grpc " + addr + " serving"},
+		{"default host", ":" + port, true, "grpc 127.0.0.1:" + port + " serving"},
+		{"service", addr + "/web", true, "grpc " + addr + "/web serving"},
+		{"json", `{"address":"` + addr + `","service":"web"}`, true, "grpc " + addr + "/web serving"},
+		{"not serving", addr + "/db", false, "grpc " + addr + "/db not_serving"},
+		{"unknown service", addr + "/other", false, "grpc " + addr + "/other unknown service"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := b.Dep(tt.wait)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg, ok := d.Check()
+			if ok != tt.ok || msg != tt.msg {
+				t.Errorf("got %v %q, want %v %q", ok, msg, tt.ok, tt.msg)
+			}
+		})
+	}
+
+	// The status is checked each time, with all of the checks of an address sharing a connection.
+	d, err := b.Dep(addr + "/db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hs.SetServingStatus("db", healthpb.HealthCheckResponse_SERVING)
+	if msg, ok := d.Check(); !ok {
+		t.Errorf("got %q once serving", msg)
+	}
+	if n := len(b.(*broker).conns); n != 1 {
+		t.Errorf("got %d connections, want 1", n)
+	}
+}
+
+func TestCheckUnavailable(t *testing.T) {
+	// A server without the health service.
+	addr, done := serve(t, nil)
+	defer done()
+
+	// A listener that never speaks gRPC.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = l.Close()
+	}()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			defer func() {
+				_ = conn.Close()
+			}()
+		}
+	}()
+
+	b, err := create(common.StringMap{"timeout": "200"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		wait string
+		msg  string
+	}{
+		{"unimplemented", addr, "grpc " + addr + " health checking not implemented"},
+		{"timeout", l.Addr().String(), "grpc " + l.Addr().String() + " timeout"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := b.Dep(tt.wait)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg, ok := d.Check()
+			if ok || msg != tt.msg {
+				t.Errorf("got %v %q, want %q", ok, msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	tests := []struct {
+		config common.StringMap
+		tls    bool
+		fails  bool
+	}{
+		{common.StringMap{}, false, false},
+		{common.StringMap{"security": "plaintext"}, false, false},
+		{common.StringMap{"security": "tls"}, true, false},
+		{common.StringMap{"security": "tls", "insecure": "true"}, true, false},
+		{common.StringMap{"security": "mtls"}, false, true},
+		{common.StringMap{"security": "tls", "ca": "/nonexistent/ca.pem"}, false, true},
+		{common.StringMap{"security": "ssh"}, false, true},
+		{common.StringMap{"timeout": "soon"}, false, true},
+	}
+
+	for _, tt := range tests {
+		b, err := create(tt.config)
+		if (err != nil) != tt.fails {
+			t.Errorf("%v: got error %v", tt.config, err)
+			continue
+		}
+		if err == nil && (b.(*broker).tls != nil) != tt.tls {
+			t.Errorf("%v: got tls %v", tt.config, b.(*broker).tls)
+		}
+	}
+}
+
+func TestDepInvalid(t *testing.T) {
+	b := &broker{}
+	for _, wait := range []string{
+		"",
+		"nohost",
+		"/service",
+		`{"service":"web"}`,
+		`{"address":`,
+	} {
+		if _, err := b.Dep(wait); err == nil {
+			t.Errorf("%s: expected an error", wait)
+		}
+	}
+}
@@ -38,6 +38,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -87,6 +88,13 @@ func (b *broker) Dep(wait string) (deps.Depender, error) {
 		}
 	}
 
+	if r.Method == "" {
+		r.Method = http.MethodGet
+	}
+	if r.Expect.Code == 0 && r.Expect.Body == "" && r.Expect.Headers == nil {
+		r.Expect.Code = http.StatusOK
+	}
+
 	u, err := url.Parse(r.URL)
 	if err != nil {
 		return nil, common.Error(err, "failed to parse URL %s", r.URL)
@@ -106,7 +114,7 @@ type dep struct {
 func (d *dep) Check() (msg string, ok bool) {
 	msg = fmt.Sprintf("%s %s", Kind, d.u.Host)
 
-	var reader *bytes.Buffer
+	var reader io.Reader
 	if d.r.Body != "" {
 		reader = bytes.NewBuffer([]byte(d.r.Body))
 	}
@@ -126,6 +134,9 @@ func (d *dep) Check() (msg string, ok bool) {
 	if err != nil {
 		return msg + " " + err.Error(), false
 	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
 
 	var waiting []string
 
@@ -162,5 +173,5 @@ func (d *dep) Check() (msg string, ok bool) {
 		return msg + " waiting for " + strings.Join(waiting, ", "), false
 	}
 
-	return msg + " good", ok
+	return msg + " good", true
 }
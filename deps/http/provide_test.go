@@ -0,0 +1,62 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.URL.Path != "/post" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		switch r.URL.Path {
+		case "/ok", "/post":
+			w.Header().Set("X-State", "warm")
+			_, _ = w.Write([]byte("status: serving"))
+		case "/starting":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name string
+		wait string
+		ok   bool
+		msg  string
+	}{
+		{"plain url", srv.URL + "/ok", true, "good"},
+		{"defaults to 200", srv.URL + "/starting", false, "waiting for code 200"},
+		{"expected code", `{"url": "` + srv.URL + `/missing", "expect": {"code": 404}}`, true, "good"},
+		{"body", `{"url": "` + srv.URL + `/ok", "expect": {"body": "serving"}}`, true, "good"},
+		{"missing body", `{"url": "` + srv.URL + `/ok", "expect": {"body": "stopped"}}`, false, "waiting for body text"},
+		{"header", `{"url": "` + srv.URL + `/ok", "expect": {"headers": {"X-State": "warm"}}}`, true, "good"},
+		{"method", `{"url": "` + srv.URL + `/post", "method": "POST", "body": "x"}`, true, "good"},
+		{"refused", "http://127.0.0.1:1/", false, "refused"},
+	}
+
+	b, err := create(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := b.Dep(tt.wait)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg, ok := d.Check()
+			if ok != tt.ok || !strings.Contains(msg, tt.msg) {
+				t.Errorf("got %v %q, want %v containing %q", ok, msg, tt.ok, tt.msg)
+			}
+		})
+	}
+}
@@ -31,10 +31,17 @@ func NewInstance(identity, namespace, kind string, config common.StringMap) erro
 }
 
 // Instance returns the instance with the given identity/namespace.
+//
+// If the instance is a NamespacedBroker, the returned Broker is relative to the namespace.
 func Instance(identity, namespace string) Broker {
 	ii := im.instances[identity]
-	if b, ok := ii[namespace]; ok {
-		return b
+	b, ok := ii[namespace]
+	if !ok {
+		b = ii[""]
 	}
-	return ii[""]
+
+	if nb, ok := b.(NamespacedBroker); ok {
+		return nb.InNamespace(namespace)
+	}
+	return b
 }
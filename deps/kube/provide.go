@@ -2,62 +2,126 @@
 //
 // Accepts config:
 //    type: "in-cluster", "json" or "config"
-//    config: PATH
+//    config: JSON
 //    file: PATH
+//    namespace: NAMESPACE
 //
 // If type is "in-cluster", will use the in-cluster credentials to connect to the cluster.
 //
 // If type is "config", will use the kube config file at the specified PATH.
+//
+// If type is "json", will use the kube config JSON supplied in the config value.
+//
+// The NAMESPACE is the kubernetes namespace to look in when a wait doesn't specify one (default "default").
+//
+// The "wait" parameter will be either a plain `NAME` or `KIND/NAME`, or JSON of the form:
+//    {
+//      "kind": KIND,
+//      "name": NAME,
+//      "selector": SELECTOR,
+//      "namespace": NAMESPACE,
+//      "replicas": COUNT
+//    }
+//
+// If only a name is supplied, it will expand to:
+//
+//    {"kind": "deployment", "name": NAME}
+//
+// KIND is one of:
+//    "deployment", "statefulset" or "daemonset" - waits for COUNT of the NAME workload's replicas to be ready
+//        (default all of the desired replicas).
+//    "job" - waits for the NAME job to complete.
+//    "pod" - waits for COUNT pods that match the label SELECTOR (e.g. "app=web,tier!=cache") to be ready (default 1).
+//    "service" - waits for the NAME service to have COUNT ready endpoint addresses (default 1).
+//
+// The client is created when first checked, and any failure to create it is reported in the check message.
+//
+// Objects are listed for a whole namespace at a time, and the lists are cached for a few seconds so many dependencies
+// in the same namespace won't all query the cluster.
 package kube
 
 import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/norganna/cynosure/common"
 	"github.com/norganna/cynosure/deps"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
 )
 
 // Kind contains the kind string of this provider.
 const Kind = "kube"
 
+// Object kinds that can be waited for.
+const (
+	KindDeployment  = "deployment"
+	KindStatefulSet = "statefulset"
+	KindDaemonSet   = "daemonset"
+	KindJob         = "job"
+	KindPod         = "pod"
+	KindService     = "service"
+)
+
+// cacheTTL is how long a namespace's list of a kind of object is reused for.
+const cacheTTL = 10 * time.Second
+
 func init() {
 	deps.RegisterProvider(Kind, create)
 }
 
 // create returns the Broker.
 func create(config common.StringMap) (deps.Broker, error) {
-	b := &broker{}
-
-	return b, nil
-}
-
-type broker struct {
-}
+	var key string
+	switch t := config["type"]; t {
+	case "in-cluster":
+		key = t
+	case "config":
+		key = "file=" + config["file"]
+	case "json":
+		key = "json=" + config["config"]
+	default:
+		return nil, deps.ErrUnknownConfig
+	}
 
-func (b *broker) Dep(wait string) (deps.Depender, error) {
-	return &dep{
-		b:    b,
-		wait: wait,
+	return &broker{
+		key:       key,
+		namespace: config["namespace"],
 	}, nil
 }
 
-type dep struct {
-	b    *broker
-	wait string
-}
-
-func (d *dep) Check() (msg string, ok bool) {
-	panic("not implemented")
+// NewBroker returns a Broker that uses the supplied client set (which may be a fake for testing).
+func NewBroker(cs kubernetes.Interface, namespace string) deps.Broker {
+	return &broker{
+		client:    newClient(cs),
+		namespace: namespace,
+	}
 }
 
-/*
-
 type kubeManager struct {
 	sync.RWMutex
 
 	clients map[string]*kubeClient
 }
 
+var kubeMan = &kubeManager{
+	clients: map[string]*kubeClient{},
+}
+
+// GetClient returns the (shared) client for the config, creating it if required.
 func (m *kubeManager) GetClient(config string) (client *kubeClient, err error) {
-	func () {
+	func() {
 		m.RLock()
 		defer m.RUnlock()
 
@@ -73,13 +137,18 @@ func (m *kubeManager) GetClient(config string) (client *kubeClient, err error) {
 	m.Lock()
 	defer m.Unlock()
 
+	// Another caller may have created the client while waiting for the lock.
+	if c, ok := m.clients[config]; ok {
+		return c, nil
+	}
+
 	var c *rest.Config
 	var data []byte
 
 	if config == "in-cluster" {
 		c, err = rest.InClusterConfig()
 		if err != nil {
-			return nil, err
+			return nil, common.Error(err, "failed to load in-cluster kube config")
 		}
 	} else {
 		if strings.HasPrefix(config, "file=") {
@@ -105,11 +174,7 @@ func (m *kubeManager) GetClient(config string) (client *kubeClient, err error) {
 		return nil, common.Error(err, "failed to create kube client set from config")
 	}
 
-	client = &kubeClient{
-		cs:   cs,
-		deps: map[string]*kubeDeployments{},
-	}
-
+	client = newClient(cs)
 	m.clients[config] = client
 	return client, nil
 }
@@ -117,131 +182,306 @@ func (m *kubeManager) GetClient(config string) (client *kubeClient, err error) {
 type kubeClient struct {
 	sync.RWMutex
 
-	cs   *kubernetes.Clientset
-	deps map[string]*kubeDeployments
+	cs kubernetes.Interface
+
+	// lists[namespace][kind]
+	lists map[string]map[string]*kubeList
 }
 
-func (c *kubeClient) GetDeployments(ns string) (deps *kubeDeployments, err error) {
+type kubeList struct {
+	ts   time.Time
+	list runtime.Object
+}
+
+func newClient(cs kubernetes.Interface) *kubeClient {
+	return &kubeClient{
+		cs:    cs,
+		lists: map[string]map[string]*kubeList{},
+	}
+}
+
+// GetList returns the (possibly cached) list of objects of the kind in the namespace.
+func (c *kubeClient) GetList(ns, kind string) (list runtime.Object, err error) {
 	now := time.Now()
 
-	func () {
+	func() {
 		c.RLock()
 		defer c.RUnlock()
 
-		if d, ok := c.deps[ns]; ok {
-			if now.Sub(d.ts) <= 10*time.Second {
-				deps = d
+		if l, ok := c.lists[ns][kind]; ok {
+			if now.Sub(l.ts) <= cacheTTL {
+				list = l.list
 			}
 		}
 	}()
 
-	if deps != nil {
-		return deps, nil
+	if list != nil {
+		return list, nil
+	}
+
+	opts := metav1.ListOptions{}
+	switch kind {
+	case KindDeployment:
+		list, err = c.cs.AppsV1().Deployments(ns).List(opts)
+	case KindStatefulSet:
+		list, err = c.cs.AppsV1().StatefulSets(ns).List(opts)
+	case KindDaemonSet:
+		list, err = c.cs.AppsV1().DaemonSets(ns).List(opts)
+	case KindJob:
+		list, err = c.cs.BatchV1().Jobs(ns).List(opts)
+	case KindPod:
+		list, err = c.cs.CoreV1().Pods(ns).List(opts)
+	case KindService:
+		list, err = c.cs.CoreV1().Endpoints(ns).List(opts)
+	default:
+		return nil, common.ErrorMsg("unknown kind %s", kind)
+	}
+	if err != nil {
+		return nil, common.Error(err, "failed to get kube %s list", kind)
 	}
 
 	c.Lock()
 	defer c.Unlock()
 
-	deps = &kubeDeployments{
-		ts: now,
+	if c.lists[ns] == nil {
+		c.lists[ns] = map[string]*kubeList{}
 	}
-
-	deps.list, err = c.cs.AppsV1().Deployments(ns).List(metav1.ListOptions{})
-	if err != nil {
-		return nil, common.Error(err, "failed to get kube deployment list")
+	c.lists[ns][kind] = &kubeList{
+		ts:   now,
+		list: list,
 	}
-
-	c.deps[ns] = deps
-	return deps, nil
+	return list, nil
 }
 
-type kubeDeployments struct {
-	ts time.Time
-	list *appsv1.DeploymentList
+type broker struct {
+	sync.Mutex
+
+	key       string
+	client    *kubeClient
+	namespace string
 }
 
-var kubeMan = &kubeManager{
-	clients: map[string]*kubeClient{},
+// getClient returns the client of the broker, getting it from the manager on first use.
+func (b *broker) getClient() (*kubeClient, error) {
+	b.Lock()
+	defer b.Unlock()
+
+	if b.client == nil {
+		client, err := kubeMan.GetClient(b.key)
+		if err != nil {
+			return nil, err
+		}
+		b.client = client
+	}
+	return b.client, nil
 }
 
-type kube struct {
-	client   *kubeClient
-	deps     []*ReqNsItem
-	messages []string
+type request struct {
+	Kind      string `json:"kind,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Selector  string `json:"selector,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Replicas  int32  `json:"replicas,omitempty"`
 }
 
-var _ Depender = (*kube)(nil)
+func (b *broker) Dep(wait string) (deps.Depender, error) {
+	r := &request{}
+	if wait == "" {
+		return nil, common.ErrorMsg("no kube object to wait for")
+	}
 
-func NewKube(config string) (Depender, error) {
-	client, err := kubeMan.GetClient(config)
-	if err != nil {
-		return nil, err
+	if wait[0:1] != "{" {
+		r.Name = wait
+		if parts := strings.SplitN(wait, "/", 2); len(parts) == 2 {
+			r.Kind, r.Name = parts[0], parts[1]
+		}
+	} else {
+		err := json.Unmarshal([]byte(wait), r)
+		if err != nil {
+			return nil, common.Error(err, "failed to parse dependency condition JSON")
+		}
 	}
 
-	k := &kube{
-		client: client,
+	r.Kind = strings.ToLower(r.Kind)
+	if r.Kind == "" {
+		r.Kind = KindDeployment
+	}
+	if r.Namespace == "" {
+		r.Namespace = b.namespace
+	}
+	if r.Namespace == "" {
+		r.Namespace = metav1.NamespaceDefault
 	}
-	return k, nil
-}
 
-func (d *kube) Check() bool {
-	if len(d.deps) == 0 {
-		return true
+	d := &dep{
+		b: b,
+		r: r,
+	}
+
+	switch r.Kind {
+	case KindDeployment, KindStatefulSet, KindDaemonSet, KindJob, KindService:
+		if r.Name == "" {
+			return nil, common.ErrorMsg("must supply the name of the %s", r.Kind)
+		}
+	case KindPod:
+		if r.Selector == "" {
+			return nil, common.ErrorMsg("must supply a label selector for pods")
+		}
+		var err error
+		d.selector, err = labels.Parse(r.Selector)
+		if err != nil {
+			return nil, common.Error(err, "failed to parse label selector %s", r.Selector)
+		}
+	default:
+		return nil, common.ErrorMsg("unknown kind %s", r.Kind)
 	}
 
+	return d, nil
+}
+
+type dep struct {
+	b        *broker
+	r        *request
+	selector labels.Selector
+}
+
+func (d *dep) Check() (msg string, ok bool) {
+	what := d.r.Name
+	if d.r.Kind == KindPod {
+		what = "{" + d.r.Selector + "}"
+	}
+	msg = fmt.Sprintf("%s %s %s/%s", Kind, d.r.Kind, d.r.Namespace, what)
 
+	client, err := d.b.getClient()
+	if err != nil {
+		return msg + " " + err.Error(), false
+	}
 
-	pods, err := d.cs.AppsV1().Deployments(d.ns).List(metav1.ListOptions{})
+	list, err := client.GetList(d.r.Namespace, d.r.Kind)
 	if err != nil {
-		panic(err.Error())
+		return msg + " " + err.Error(), false
+	}
+
+	var status string
+	switch l := list.(type) {
+	case *appsv1.DeploymentList:
+		status, ok = d.deployment(l)
+	case *appsv1.StatefulSetList:
+		status, ok = d.statefulSet(l)
+	case *appsv1.DaemonSetList:
+		status, ok = d.daemonSet(l)
+	case *batchv1.JobList:
+		status, ok = d.job(l)
+	case *corev1.PodList:
+		status, ok = d.pods(l)
+	case *corev1.EndpointsList:
+		status, ok = d.service(l)
+	}
+	return msg + " " + status, ok
+}
+
+// replicas returns the status of the ready replicas compared to those wanted.
+func (d *dep) replicas(ready, desired int32) (string, bool) {
+	want := d.r.Replicas
+	if want < 1 {
+		want = desired
+	}
+	if want < 1 {
+		want = 1
 	}
 
-	waiting := map[string]string{}
+	status := fmt.Sprintf("%d/%d ready", ready, want)
+	if ready < want {
+		return status + " waiting", false
+	}
+	return status, true
+}
 
-depLoop:
-	for _, dep := range d.deps {
-		for _, pod := range pods.Items {
-			if pod.Name == dep {
-				if pod.Status.ReadyReplicas > 0 {
-					continue depLoop
-				}
-				waiting[dep] = "STARTING"
-				continue depLoop
+func (d *dep) deployment(l *appsv1.DeploymentList) (string, bool) {
+	for _, item := range l.Items {
+		if item.Name == d.r.Name {
+			desired := int32(1)
+			if item.Spec.Replicas != nil {
+				desired = *item.Spec.Replicas
 			}
+			return d.replicas(item.Status.ReadyReplicas, desired)
 		}
-		waiting[dep] = "PENDING"
 	}
+	return "not found", false
+}
 
-	var messages []string
-	for _, dep := range d.deps {
-		if state, exists := waiting[dep]; exists {
-			messages = append(messages, fmt.Sprintf("%s [%s]", dep, state))
-			continue
+func (d *dep) statefulSet(l *appsv1.StatefulSetList) (string, bool) {
+	for _, item := range l.Items {
+		if item.Name == d.r.Name {
+			desired := int32(1)
+			if item.Spec.Replicas != nil {
+				desired = *item.Spec.Replicas
+			}
+			return d.replicas(item.Status.ReadyReplicas, desired)
 		}
-		messages = append(messages, fmt.Sprintf("\n  %s [OK]", dep))
 	}
+	return "not found", false
+}
 
-	d.messages = messages
-
-	if len(waiting) > 0 {
-		return false
+func (d *dep) daemonSet(l *appsv1.DaemonSetList) (string, bool) {
+	for _, item := range l.Items {
+		if item.Name == d.r.Name {
+			return d.replicas(item.Status.NumberReady, item.Status.DesiredNumberScheduled)
+		}
 	}
-	return true
+	return "not found", false
 }
 
-func (d *kube) Messages() []string {
-	return d.messages
+func (d *dep) job(l *batchv1.JobList) (string, bool) {
+	for _, item := range l.Items {
+		if item.Name != d.r.Name {
+			continue
+		}
+
+		for _, cond := range item.Status.Conditions {
+			if cond.Status != corev1.ConditionTrue {
+				continue
+			}
+			switch cond.Type {
+			case batchv1.JobComplete:
+				return "complete", true
+			case batchv1.JobFailed:
+				return "failed: " + cond.Message, false
+			}
+		}
+		return fmt.Sprintf("%d active, %d succeeded waiting", item.Status.Active, item.Status.Succeeded), false
+	}
+	return "not found", false
 }
 
-func (d *kube) Add(items ...interface{}) error {
-	for _, item := range items {
-		dep, ok := item.(string)
-		if !ok {
-			return ErrIncorrectType
+func (d *dep) pods(l *corev1.PodList) (string, bool) {
+	var ready int32
+	for _, item := range l.Items {
+		if !d.selector.Matches(labels.Set(item.Labels)) {
+			continue
+		}
+
+		for _, cond := range item.Status.Conditions {
+			if cond.Type == corev1.PodReady && cond.Status == corev1.ConditionTrue {
+				ready++
+				break
+			}
 		}
-		d.deps = append(d.deps, dep)
 	}
-	return nil
+	return d.replicas(ready, 1)
 }
 
-*/
+func (d *dep) service(l *corev1.EndpointsList) (string, bool) {
+	for _, item := range l.Items {
+		if item.Name != d.r.Name {
+			continue
+		}
+
+		var ready int32
+		for _, subset := range item.Subsets {
+			ready += int32(len(subset.Addresses))
+		}
+		return d.replicas(ready, 1)
+	}
+	return "not found", false
+}
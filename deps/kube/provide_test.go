@@ -0,0 +1,182 @@
+package kube
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/norganna/cynosure/common"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func meta(ns, name string, labels map[string]string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{Namespace: ns, Name: name, Labels: labels}
+}
+
+func replicas(n int32) *int32 {
+	return &n
+}
+
+func pod(name string, labels map[string]string, ready bool) *corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return &corev1.Pod{
+		ObjectMeta: meta("apps", name, labels),
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+		},
+	}
+}
+
+func objects() []runtime.Object {
+	return []runtime.Object{
+		&appsv1.Deployment{
+			ObjectMeta: meta("apps", "web", nil),
+			Spec:       appsv1.DeploymentSpec{Replicas: replicas(3)},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: 2},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: meta("other", "web", nil),
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: 1},
+		},
+		&appsv1.StatefulSet{
+			ObjectMeta: meta("apps", "db", nil),
+			Spec:       appsv1.StatefulSetSpec{Replicas: replicas(2)},
+			Status:     appsv1.StatefulSetStatus{ReadyReplicas: 2},
+		},
+		&appsv1.DaemonSet{
+			ObjectMeta: meta("apps", "agent", nil),
+			Status:     appsv1.DaemonSetStatus{DesiredNumberScheduled: 4, NumberReady: 3},
+		},
+		&batchv1.Job{
+			ObjectMeta: meta("apps", "migrate", nil),
+			Status: batchv1.JobStatus{
+				Succeeded:  1,
+				Conditions: []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}},
+			},
+		},
+		&batchv1.Job{
+			ObjectMeta: meta("apps", "seed", nil),
+			Status: batchv1.JobStatus{
+				Conditions: []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "backoff limit exceeded"}},
+			},
+		},
+		&batchv1.Job{
+			ObjectMeta: meta("apps", "backup", nil),
+			Status:     batchv1.JobStatus{Active: 1},
+		},
+		pod("web-1", map[string]string{"app": "web", "tier": "front"}, true),
+		pod("web-2", map[string]string{"app": "web", "tier": "front"}, false),
+		pod("web-3", map[string]string{"app": "web", "tier": "cache"}, true),
+		&corev1.Endpoints{
+			ObjectMeta: meta("apps", "api", nil),
+			Subsets: []corev1.EndpointSubset{
+				{Addresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}, {IP: "10.0.0.2"}}},
+				{Addresses: []corev1.EndpointAddress{{IP: "10.0.0.3"}}},
+			},
+		},
+		&corev1.Endpoints{
+			ObjectMeta: meta("apps", "idle", nil),
+		},
+	}
+}
+
+func TestCheck(t *testing.T) {
+	b := NewBroker(fake.NewSimpleClientset(objects()...), "apps")
+
+	tests := []struct {
+		name string
+		wait string
+		ok   bool
+		msg  string
+	}{
+		{"deployment", "web", false, "kube deployment apps/web 2/3 ready waiting"},
+		{"deployment count", `{"name":"web","replicas":2}`, true, "kube deployment apps/web 2/2 ready"},
+		{"deployment namespace", `{"name":"web","namespace":"other"}`, true, "kube deployment other/web 1/1 ready"},
+		{"deployment missing", "deployment/api", false, "kube deployment apps/api not found"},
+		{"statefulset", "statefulset/db", true, "kube statefulset apps/db 2/2 ready"},
+		{"daemonset", "DaemonSet/agent", false, "kube daemonset apps/agent 3/4 ready waiting"},
+		{"daemonset count", `{"kind":"daemonset","name":"agent","replicas":3}`, true, "kube daemonset apps/agent 3/3 ready"},
+		{"job complete", "job/migrate", true, "kube job apps/migrate complete"},
+		{"job failed", "job/seed", false, "kube job apps/seed failed: backoff limit exceeded"},
+		{"job active", "job/backup", false, "kube job apps/backup 1 active, 0 succeeded waiting"},
+		{"pod selector", `{"kind":"pod","selector":"app=web"}`, true, "kube pod apps/{app=web} 2/1 ready"},
+		{"pod selector count", `{"kind":"pod","selector":"app=web,tier!=cache","replicas":2}`, false, "kube pod apps/{app=web,tier!=cache} 1/2 ready waiting"},
+		{"pod selector none", `{"kind":"pod","selector":"app=db"}`, false, "kube pod apps/{app=db} 0/1 ready waiting"},
+		{"service endpoints", `{"kind":"service","name":"api","replicas":3}`, true, "kube service apps/api 3/3 ready"},
+		{"service no endpoints", "service/idle", false, "kube service apps/idle 0/1 ready waiting"},
+		{"service missing", "service/none", false, "kube service apps/none not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := b.Dep(tt.wait)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg, ok := d.Check()
+			if ok != tt.ok || msg != tt.msg {
+				t.Errorf("got %v %q, want %v %q", ok, msg, tt.ok, tt.msg)
+			}
+		})
+	}
+}
+
+func TestDepInvalid(t *testing.T) {
+	b := NewBroker(fake.NewSimpleClientset(), "")
+	for _, wait := range []string{
+		"",
+		"replicaset/web",
+		`{"kind":"job"}`,
+		`{"kind":"pod"}`,
+		`{"kind":"pod","selector":"app in"}`,
+		`{"name":`,
+	} {
+		if _, err := b.Dep(wait); err == nil {
+			t.Errorf("%s: expected an error", wait)
+		}
+	}
+}
+
+func TestCreateLazily(t *testing.T) {
+	tests := []struct {
+		name   string
+		config common.StringMap
+		msg    string
+	}{
+		{"in-cluster", common.StringMap{"type": "in-cluster"}, "failed to load in-cluster kube config"},
+		{"missing file", common.StringMap{"type": "config", "file": "/nonexistent/kube.config"}, "failed to read kube config file"},
+		{"bad json", common.StringMap{"type": "json", "config": "{"}, "failed to load kube config"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Outside of a cluster (or with a bad config) the broker is still created, and its checks fail.
+			b, err := create(tt.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			d, err := b.Dep("web")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg, ok := d.Check()
+			if ok || !strings.HasPrefix(msg, "kube deployment default/web "+tt.msg) {
+				t.Errorf("got %v %q", ok, msg)
+			}
+		})
+	}
+
+	if _, err := create(common.StringMap{"type": "unknown"}); err == nil {
+		t.Error("expected an error for an unknown type")
+	}
+}
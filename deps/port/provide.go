@@ -75,9 +75,11 @@ func (d *dep) Check() (msg string, ok bool) {
 	msg = fmt.Sprintf("%s %s:%s/%s", Kind, d.host, d.port, strings.ToUpper(d.proto))
 
 	conn, err := net.DialTimeout(d.proto, fmt.Sprintf("%s:%s", d.host, d.port), time.Second)
-	defer func() {
-		_ = conn.Close()
-	}()
+	if conn != nil {
+		defer func() {
+			_ = conn.Close()
+		}()
+	}
 
 	if err, ok := err.(*net.OpError); ok && err.Timeout() {
 		return msg + " timeout", false
@@ -0,0 +1,57 @@
+package port
+
+import (
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = l.Close()
+	}()
+
+	// Find a port that nothing is listening on.
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closedAddr := closed.Addr().String()
+	_ = closed.Close()
+
+	_, port, _ := net.SplitHostPort(l.Addr().String())
+
+	tests := []struct {
+		name string
+		wait string
+		ok   bool
+		msg  string
+	}{
+		{"open", l.Addr().String(), true, "open"},
+		{"default host", ":" + port + "/TCP", true, "127.0.0.1:" + port + "/TCP open"},
+		{"closed", closedAddr, false, "refused"},
+	}
+
+	b, err := create(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := b.Dep(tt.wait)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg, ok := d.Check()
+			if ok != tt.ok || !strings.Contains(msg, tt.msg) {
+				t.Errorf("got %v %q, want %v containing %q", ok, msg, tt.ok, tt.msg)
+			}
+		})
+	}
+}
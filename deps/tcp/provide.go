@@ -0,0 +1,467 @@
+// Package tcp is a checker that talks the protocol of the service listening on a TCP port.
+//
+// Accepts config:
+//    timeout: MILLISECONDS
+//
+// The MILLISECONDS is the time that each check has to complete (default 1000).
+//
+// The "wait" parameter will be either of the form:
+//   PROTOCOL://[HOST]:PORT
+//
+// or JSON of the form:
+//    {
+//      "protocol": PROTOCOL,
+//      "address": [HOST]:PORT,
+//      "user": USER,
+//      "database": DATABASE,
+//      "password": PASSWORD,
+//      "send": SEND,
+//      "expect": FIND
+//    }
+//
+// HOST is either an IPv4, IPv6 address or resolvable host name (default = 127.0.0.1).
+// PORT is a port number.
+// PROTOCOL is one of:
+//    "redis" - sends a `PING` (after an `AUTH` with the PASSWORD if given) and expects a `PONG`.
+//    "postgres" - negotiates SSL and sends a startup message as the USER (default "postgres") for the DATABASE, and
+//        checks the server is not starting up or shutting down (in the same way as `pg_isready`). A request for a
+//        password is treated as accepting connections.
+//    "mysql" - reads the initial handshake packet and checks it is not an error.
+//    "memcached" - sends a `version` request and expects a `VERSION` response.
+//    "generic" - sends the SEND text (if any) and expects to receive the FIND text.
+//
+// Examples:
+//   redis://:6379
+//   postgres://db.local:5432
+//   {"protocol": "generic", "address": "smtp.local:25", "expect": "220 "}
+package tcp
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/norganna/cynosure/common"
+	"github.com/norganna/cynosure/deps"
+)
+
+// Kind contains the kind string of this provider.
+const Kind = "tcp"
+
+// Protocols.
+const (
+	ProtocolRedis     = "redis"
+	ProtocolPostgres  = "postgres"
+	ProtocolMySQL     = "mysql"
+	ProtocolMemcached = "memcached"
+	ProtocolGeneric   = "generic"
+)
+
+// defaultTimeout is how long a check has to complete if the config does not specify.
+const defaultTimeout = time.Second
+
+// maxExpect is the most data that will be read looking for the expected text.
+const maxExpect = 64 * 1024
+
+func init() {
+	deps.RegisterProvider(Kind, create)
+}
+
+// create returns the Broker.
+func create(config common.StringMap) (deps.Broker, error) {
+	b := &broker{
+		timeout: defaultTimeout,
+	}
+
+	if t := config["timeout"]; t != "" {
+		ms, err := strconv.ParseInt(t, 10, 64)
+		if err != nil {
+			return nil, common.Error(err, "failed to parse timeout %s", t)
+		}
+		b.timeout = time.Duration(ms) * time.Millisecond
+	}
+
+	return b, nil
+}
+
+type broker struct {
+	timeout time.Duration
+}
+
+type request struct {
+	Protocol string `json:"protocol,omitempty"`
+	Address  string `json:"address,omitempty"`
+	User     string `json:"user,omitempty"`
+	Database string `json:"database,omitempty"`
+	Password string `json:"password,omitempty"`
+	Send     string `json:"send,omitempty"`
+	Expect   string `json:"expect,omitempty"`
+}
+
+func (b *broker) Dep(wait string) (deps.Depender, error) {
+	r := &request{}
+	if wait == "" {
+		return nil, common.ErrorMsg("no address to check")
+	}
+
+	if wait[0:1] != "{" {
+		parts := strings.SplitN(wait, "://", 2)
+		if len(parts) != 2 {
+			return nil, common.ErrorMsg("failed to find protocol in %s", wait)
+		}
+		r.Protocol, r.Address = parts[0], parts[1]
+	} else {
+		err := json.Unmarshal([]byte(wait), r)
+		if err != nil {
+			return nil, common.Error(err, "failed to parse dependency condition JSON")
+		}
+	}
+
+	host, port, err := net.SplitHostPort(r.Address)
+	if err != nil {
+		return nil, common.Error(err, "failed to parse host/port %s", r.Address)
+	}
+	if host == "" {
+		host = "127.0.0.1"
+	}
+
+	d := &dep{
+		r:       r,
+		address: net.JoinHostPort(host, port),
+		timeout: b.timeout,
+	}
+
+	r.Protocol = strings.ToLower(r.Protocol)
+	switch r.Protocol {
+	case ProtocolRedis:
+		d.check = d.redis
+	case ProtocolPostgres:
+		d.check = d.postgres
+	case ProtocolMySQL:
+		d.check = d.mysql
+	case ProtocolMemcached:
+		d.check = d.memcached
+	case ProtocolGeneric:
+		if r.Send == "" && r.Expect == "" {
+			return nil, common.ErrorMsg("must supply text to send or expect")
+		}
+		d.check = d.generic
+	default:
+		return nil, common.ErrorMsg("unknown protocol %s", r.Protocol)
+	}
+
+	return d, nil
+}
+
+type dep struct {
+	r       *request
+	address string
+	timeout time.Duration
+	check   func(conn net.Conn) (string, bool)
+}
+
+func (d *dep) Check() (msg string, ok bool) {
+	msg = fmt.Sprintf("%s %s://%s", Kind, d.r.Protocol, d.address)
+
+	conn, err := net.DialTimeout("tcp", d.address, d.timeout)
+	if err, ok := err.(*net.OpError); ok && err.Timeout() {
+		return msg + " timeout", false
+	}
+	if err != nil {
+		return msg + " " + err.Error(), false
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	_ = conn.SetDeadline(time.Now().Add(d.timeout))
+
+	status, ok := d.check(conn)
+	return msg + " " + status, ok
+}
+
+// failed returns the status for an error during the exchange.
+func failed(err error) (string, bool) {
+	if err, ok := err.(net.Error); ok && err.Timeout() {
+		return "timeout", false
+	}
+	return err.Error(), false
+}
+
+func (d *dep) redis(conn net.Conn) (string, bool) {
+	r := bufio.NewReader(conn)
+
+	if d.r.Password != "" {
+		_, err := conn.Write(redisCommand("AUTH", d.r.Password))
+		if err != nil {
+			return failed(err)
+		}
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return failed(err)
+		}
+		if line = strings.TrimSpace(line); line != "+OK" {
+			return "auth " + strings.TrimPrefix(line, "-"), false
+		}
+	}
+
+	_, err := conn.Write(redisCommand("PING"))
+	if err != nil {
+		return failed(err)
+	}
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return failed(err)
+	}
+
+	line = strings.TrimSpace(line)
+	switch {
+	case line == "+PONG":
+		return "pong", true
+	case strings.HasPrefix(line, "-NOAUTH"):
+		// The server is serving, we just aren't allowed to use it.
+		return "pong (auth required)", true
+	case strings.HasPrefix(line, "-"):
+		return line[1:], false
+	}
+	return "unexpected response " + strconv.Quote(line), false
+}
+
+func redisCommand(args ...string) []byte {
+	var buf bytes.Buffer
+	_, _ = fmt.Fprintf(&buf, "*%d\r\n", len(args))
+	for _, arg := range args {
+		_, _ = fmt.Fprintf(&buf, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	return buf.Bytes()
+}
+
+// Postgres protocol codes.
+const (
+	postgresSSLRequest      = 80877103
+	postgresProtocolVersion = 196608
+	postgresCannotConnect   = "57P03"
+	postgresAuthOK          = 0
+)
+
+// postgresAuthMethods are the descriptions of the authentication requests.
+var postgresAuthMethods = map[uint32]string{
+	2:  "kerberos",
+	3:  "password",
+	5:  "md5 password",
+	7:  "gss",
+	9:  "sspi",
+	10: "sasl",
+}
+
+func (d *dep) postgres(conn net.Conn) (string, bool) {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint32(msg[0:], 8)
+	binary.BigEndian.PutUint32(msg[4:], postgresSSLRequest)
+	_, err := conn.Write(msg)
+	if err != nil {
+		return failed(err)
+	}
+
+	resp := make([]byte, 1)
+	_, err = io.ReadFull(conn, resp)
+	if err != nil {
+		return failed(err)
+	}
+
+	switch resp[0] {
+	case 'S':
+		// We only want to talk to the server, so the certificate doesn't need to be trusted.
+		tc := tls.Client(conn, &tls.Config{InsecureSkipVerify: true})
+		err = tc.Handshake()
+		if err != nil {
+			return "ssl " + err.Error(), false
+		}
+		conn = tc
+	case 'N':
+	default:
+		return fmt.Sprintf("unexpected ssl response %q", resp[0]), false
+	}
+
+	user := d.r.User
+	if user == "" {
+		user = "postgres"
+	}
+	params := "user\x00" + user + "\x00"
+	if d.r.Database != "" {
+		params += "database\x00" + d.r.Database + "\x00"
+	}
+	params += "\x00"
+
+	msg = make([]byte, 8, 8+len(params))
+	binary.BigEndian.PutUint32(msg[0:], uint32(8+len(params)))
+	binary.BigEndian.PutUint32(msg[4:], postgresProtocolVersion)
+	msg = append(msg, params...)
+	_, err = conn.Write(msg)
+	if err != nil {
+		return failed(err)
+	}
+
+	// After a successful authentication the server may still refuse the connection (e.g. a standby that isn't
+	// accepting connections), so keep reading until it is ready for a query.
+	for {
+		code, body, err := readPostgres(conn)
+		if err != nil {
+			return failed(err)
+		}
+
+		switch code {
+		case 'R':
+			if len(body) < 4 {
+				return "invalid authentication request", false
+			}
+			auth := binary.BigEndian.Uint32(body)
+			if auth == postgresAuthOK {
+				continue
+			}
+
+			// The server is asking for credentials, so it is accepting connections (as `pg_isready` reports).
+			method, ok := postgresAuthMethods[auth]
+			if !ok {
+				method = fmt.Sprintf("authentication method %d", auth)
+			}
+			return "accepting connections (" + method + " required)", true
+		case 'E':
+			fields := map[byte]string{}
+			for _, field := range bytes.Split(body, []byte{0}) {
+				if len(field) > 1 {
+					fields[field[0]] = string(field[1:])
+				}
+			}
+
+			if fields['C'] == postgresCannotConnect {
+				return fields['M'], false
+			}
+			// Any other error (such as a bad password) means the server is accepting connections.
+			return "accepting connections (" + fields['M'] + ")", true
+		case 'Z':
+			return "accepting connections", true
+		case 'S', 'K', 'N':
+			// Parameter status, backend key data and notices before the server is ready.
+		default:
+			return fmt.Sprintf("unexpected response %q", code), false
+		}
+	}
+}
+
+// readPostgres reads a message from the server, returning its type code and body.
+func readPostgres(conn net.Conn) (byte, []byte, error) {
+	hdr := make([]byte, 5)
+	_, err := io.ReadFull(conn, hdr)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	n := int(binary.BigEndian.Uint32(hdr[1:])) - 4
+	if n < 0 || n > maxExpect {
+		return 0, nil, common.ErrorMsg("invalid %q message length %d", hdr[0], n)
+	}
+
+	body := make([]byte, n)
+	_, err = io.ReadFull(conn, body)
+	if err != nil {
+		return 0, nil, err
+	}
+	return hdr[0], body, nil
+}
+
+func (d *dep) mysql(conn net.Conn) (string, bool) {
+	hdr := make([]byte, 4)
+	_, err := io.ReadFull(conn, hdr)
+	if err != nil {
+		return failed(err)
+	}
+
+	n := int(hdr[0]) | int(hdr[1])<<8 | int(hdr[2])<<16
+	if n < 1 || n > maxExpect {
+		return "invalid handshake packet", false
+	}
+	body := make([]byte, n)
+	_, err = io.ReadFull(conn, body)
+	if err != nil {
+		return failed(err)
+	}
+
+	switch body[0] {
+	case 0x0a:
+		version := body[1:]
+		if i := bytes.IndexByte(version, 0); i >= 0 {
+			version = version[:i]
+		}
+		return "version " + string(version), true
+	case 0xff:
+		// Error packet: 2 byte code, then (optionally) a `#` and 5 byte SQL state, then the message.
+		if len(body) < 3 {
+			return "error", false
+		}
+		code := binary.LittleEndian.Uint16(body[1:])
+		text := body[3:]
+		if len(text) > 6 && text[0] == '#' {
+			text = text[6:]
+		}
+		return fmt.Sprintf("error %d: %s", code, text), false
+	}
+	return fmt.Sprintf("unsupported protocol version %d", body[0]), false
+}
+
+func (d *dep) memcached(conn net.Conn) (string, bool) {
+	_, err := conn.Write([]byte("version\r\n"))
+	if err != nil {
+		return failed(err)
+	}
+
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return failed(err)
+	}
+
+	line = strings.TrimSpace(line)
+	if strings.HasPrefix(line, "VERSION ") {
+		return "version " + line[8:], true
+	}
+	return "unexpected response " + strconv.Quote(line), false
+}
+
+func (d *dep) generic(conn net.Conn) (string, bool) {
+	if d.r.Send != "" {
+		_, err := conn.Write([]byte(d.r.Send))
+		if err != nil {
+			return failed(err)
+		}
+	}
+
+	if d.r.Expect == "" {
+		return "sent", true
+	}
+
+	expect := []byte(d.r.Expect)
+	var received []byte
+	buf := make([]byte, 4096)
+	for len(received) < maxExpect {
+		n, err := conn.Read(buf)
+		received = append(received, buf[:n]...)
+		if bytes.Contains(received, expect) {
+			return "received expected text", true
+		}
+		if err == io.EOF {
+			return "closed before expected text", false
+		}
+		if err != nil {
+			return failed(err)
+		}
+	}
+	return "expected text not found", false
+}
@@ -0,0 +1,284 @@
+package tcp
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"io"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// serve accepts a single connection on a local port, handling it with the fake server.
+func serve(t *testing.T, fake func(conn net.Conn)) (string, func()) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer func() {
+			_ = conn.Close()
+		}()
+		_ = conn.SetDeadline(time.Now().Add(2 * time.Second))
+		fake(conn)
+	}()
+
+	return l.Addr().String(), func() {
+		_ = l.Close()
+	}
+}
+
+// reply reads a line and then writes the response.
+func reply(responses ...string) func(conn net.Conn) {
+	return func(conn net.Conn) {
+		r := bufio.NewReader(conn)
+		for _, response := range responses {
+			// Each redis command is several lines, so read up to the last argument.
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			if strings.HasPrefix(line, "*") {
+				for i := 0; i < 2*int(line[1]-'0'); i++ {
+					if _, err := r.ReadString('\n'); err != nil {
+						return
+					}
+				}
+			}
+			_, _ = io.WriteString(conn, response)
+		}
+	}
+}
+
+// postgresMessage returns a message from the server.
+func postgresMessage(code byte, body []byte) []byte {
+	msg := make([]byte, 5, 5+len(body))
+	msg[0] = code
+	binary.BigEndian.PutUint32(msg[1:], uint32(4+len(body)))
+	return append(msg, body...)
+}
+
+func postgresAuth(method uint32) []byte {
+	body := make([]byte, 4)
+	binary.BigEndian.PutUint32(body, method)
+	return postgresMessage('R', body)
+}
+
+func postgresError(code, message string) []byte {
+	return postgresMessage('E', []byte("SFATAL\x00C"+code+"\x00M"+message+"\x00\x00"))
+}
+
+// postgres is a fake postgres server that answers the SSL request with ssl, and the startup message with the replies.
+func postgres(t *testing.T, ssl byte, replies ...[]byte) func(conn net.Conn) {
+	return func(conn net.Conn) {
+		request := make([]byte, 8)
+		if _, err := io.ReadFull(conn, request); err != nil {
+			return
+		}
+		if binary.BigEndian.Uint32(request[4:]) != postgresSSLRequest {
+			t.Errorf("got %v, want an SSL request", request)
+			return
+		}
+		_, _ = conn.Write([]byte{ssl})
+
+		if ssl == 'S' {
+			tc := tls.Server(conn, &tls.Config{Certificates: []tls.Certificate{certificate(t)}})
+			if err := tc.Handshake(); err != nil {
+				return
+			}
+			conn = tc
+		}
+
+		hdr := make([]byte, 8)
+		if _, err := io.ReadFull(conn, hdr); err != nil {
+			return
+		}
+		params := make([]byte, binary.BigEndian.Uint32(hdr)-8)
+		if _, err := io.ReadFull(conn, params); err != nil {
+			return
+		}
+		if binary.BigEndian.Uint32(hdr[4:]) != postgresProtocolVersion || !strings.HasPrefix(string(params), "user\x00app\x00") {
+			t.Errorf("got startup message %q", params)
+			return
+		}
+
+		for _, r := range replies {
+			_, _ = conn.Write(r)
+		}
+	}
+}
+
+// certificate returns a self signed certificate.
+func certificate(t *testing.T) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "postgres"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+// mysqlPacket returns a packet with the body.
+func mysqlPacket(body []byte) []byte {
+	n := len(body)
+	return append([]byte{byte(n), byte(n >> 8), byte(n >> 16), 0}, body...)
+}
+
+func write(data ...[]byte) func(conn net.Conn) {
+	return func(conn net.Conn) {
+		for _, d := range data {
+			_, _ = conn.Write(d)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name string
+		wait string
+		fake func(conn net.Conn)
+		ok   bool
+		msg  string
+	}{
+		{"redis pong", "redis", reply("+PONG\r\n"), true, "pong"},
+		{"redis noauth", "redis", reply("-NOAUTH Authentication required.\r\n"), true, "pong (auth required)"},
+		{"redis loading", "redis", reply("-LOADING Redis is loading the dataset in memory\r\n"), false, "LOADING Redis is loading the dataset in memory"},
+		{"redis auth", `{"protocol":"redis","password":"secret"}`, reply("+OK\r\n", "+PONG\r\n"), true, "pong"},
+		{"redis bad auth", `{"protocol":"redis","password":"wrong"}`, reply("-WRONGPASS invalid password\r\n"), false, "auth WRONGPASS invalid password"},
+		{"redis unexpected", "redis", reply("$4\r\n"), false, `unexpected response "$4"`},
+
+		{"postgres ready", `{"protocol":"postgres","user":"app"}`,
+			postgres(t, 'N', postgresAuth(postgresAuthOK), postgresMessage('S', []byte("server_version\x0012\x00")), postgresMessage('K', make([]byte, 8)), postgresMessage('Z', []byte("I"))),
+			true, "accepting connections"},
+		{"postgres ssl", `{"protocol":"postgres","user":"app"}`,
+			postgres(t, 'S', postgresAuth(postgresAuthOK), postgresMessage('Z', []byte("I"))),
+			true, "accepting connections"},
+		{"postgres password", `{"protocol":"postgres","user":"app"}`,
+			postgres(t, 'N', postgresAuth(3)),
+			true, "accepting connections (password required)"},
+		{"postgres sasl", `{"protocol":"postgres","user":"app"}`,
+			postgres(t, 'S', postgresAuth(10)),
+			true, "accepting connections (sasl required)"},
+		{"postgres starting", `{"protocol":"postgres","user":"app"}`,
+			postgres(t, 'N', postgresError(postgresCannotConnect, "the database system is starting up")),
+			false, "the database system is starting up"},
+		{"postgres standby", `{"protocol":"postgres","user":"app"}`,
+			postgres(t, 'S', postgresAuth(postgresAuthOK), postgresError(postgresCannotConnect, "the database system is not accepting connections")),
+			false, "the database system is not accepting connections"},
+		{"postgres no role", `{"protocol":"postgres","user":"app"}`,
+			postgres(t, 'N', postgresError("28000", `role "app" does not exist`)),
+			true, `accepting connections (role "app" does not exist)`},
+		{"postgres bad ssl response", `{"protocol":"postgres","user":"app"}`,
+			write([]byte("X")),
+			false, `unexpected ssl response 'X'`},
+		{"postgres huge error", `{"protocol":"postgres","user":"app"}`,
+			postgres(t, 'N', []byte{'E', 0x7f, 0xff, 0xff, 0xff}),
+			false, `invalid 'E' message length 2147483643`},
+
+		{"mysql handshake", "mysql", write(mysqlPacket([]byte("\x0a8.0.21\x00rest"))), true, "version 8.0.21"},
+		{"mysql error", "mysql", write(mysqlPacket([]byte("\xff\x69\x04#HY000Host is blocked"))), false, "error 1129: Host is blocked"},
+		{"mysql old protocol", "mysql", write(mysqlPacket([]byte("\x09old"))), false, "unsupported protocol version 9"},
+		{"mysql empty", "mysql", write([]byte{0, 0, 0, 0}), false, "invalid handshake packet"},
+
+		{"memcached", "memcached", reply("VERSION 1.6.9\r\n"), true, "version 1.6.9"},
+		{"memcached error", "memcached", reply("ERROR\r\n"), false, `unexpected response "ERROR"`},
+
+		{"generic expect", `{"protocol":"generic","expect":"220 "}`, write([]byte("220 smtp ready\r\n")), true, "received expected text"},
+		{"generic split expect", `{"protocol":"generic","expect":"220 "}`, write([]byte("22"), []byte("0 smtp")), true, "received expected text"},
+		{"generic send", `{"protocol":"generic","send":"HELO\r\n","expect":"250"}`, reply("250 hello\r\n"), true, "received expected text"},
+		{"generic closed", `{"protocol":"generic","expect":"220 "}`, write([]byte("554 go away\r\n")), false, "closed before expected text"},
+		{"generic send only", `{"protocol":"generic","send":"ping"}`, reply(), true, "sent"},
+	}
+
+	b, err := create(map[string]string{"timeout": "1000"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, done := serve(t, tt.fake)
+			defer done()
+
+			wait := tt.wait
+			if strings.HasPrefix(wait, "{") {
+				wait = strings.Replace(wait, "{", `{"address":"`+addr+`",`, 1)
+			} else {
+				wait += "://" + addr
+			}
+
+			d, err := b.Dep(wait)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg, ok := d.Check()
+			prefix := "tcp " + d.(*dep).r.Protocol + "://" + addr + " "
+			if ok != tt.ok || msg != prefix+tt.msg {
+				t.Errorf("got %v %q, want %v %q", ok, strings.TrimPrefix(msg, prefix), tt.ok, tt.msg)
+			}
+		})
+	}
+}
+
+func TestCheckTimeout(t *testing.T) {
+	b, err := create(map[string]string{"timeout": "100"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The server accepts the connection but never responds.
+	addr, done := serve(t, func(conn net.Conn) {
+		time.Sleep(time.Second)
+	})
+	defer done()
+
+	d, err := b.Dep("memcached://" + addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg, ok := d.Check()
+	if ok || !strings.HasSuffix(msg, " timeout") {
+		t.Errorf("got %v %q", ok, msg)
+	}
+}
+
+func TestDepInvalid(t *testing.T) {
+	b := &broker{}
+	for _, wait := range []string{
+		"",
+		"redis",
+		"redis://nohost",
+		"gopher://:70",
+		`{"protocol":"generic","address":":25"}`,
+		`{"protocol":`,
+	} {
+		if _, err := b.Dep(wait); err == nil {
+			t.Errorf("%s: expected an error", wait)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package deps
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+
+	"github.com/norganna/cynosure/common"
+)
+
+// TLSConfig returns the client TLS config specified by a broker config (or nil if it specifies none).
+//
+// Accepts config:
+//    ca: PATH
+//    cert: PATH
+//    key: PATH
+//    insecure: "true"
+//
+// The ca PATH is a PEM file of the certificates to trust (instead of the system ones), and the cert and key PATHs are
+// a PEM client certificate and key to present. If insecure is "true", the server certificate will not be verified.
+func TLSConfig(config common.StringMap) (*tls.Config, error) {
+	ca, cert, key := config["ca"], config["cert"], config["key"]
+	insecure := config["insecure"] == "true"
+	if ca == "" && cert == "" && key == "" && !insecure {
+		return nil, nil
+	}
+
+	c := &tls.Config{
+		InsecureSkipVerify: insecure,
+	}
+
+	if ca != "" {
+		data, err := ioutil.ReadFile(ca)
+		if err != nil {
+			return nil, common.Error(err, "could not read CA file %s", ca)
+		}
+
+		c.RootCAs = x509.NewCertPool()
+		if !c.RootCAs.AppendCertsFromPEM(data) {
+			return nil, common.ErrorMsg("no certificates found in CA file %s", ca)
+		}
+	}
+
+	if cert != "" || key != "" {
+		crt, err := tls.LoadX509KeyPair(cert, key)
+		if err != nil {
+			return nil, common.Error(err, "failed to load client certificate %s", cert)
+		}
+		c.Certificates = []tls.Certificate{crt}
+	}
+
+	return c, nil
+}
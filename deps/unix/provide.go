@@ -0,0 +1,105 @@
+// Package unix is a unix domain socket checker.
+//
+// Accepts no config.
+//
+// The "wait" parameter is of the form:
+//   PATH[/TYPE]
+//
+// PATH is the path of the socket file.
+// TYPE is either "STREAM", "DGRAM" or "SEQPACKET" (default = STREAM).
+//
+// Waits for the socket to exist and accept connections.
+//
+// Examples:
+//   /var/run/docker.sock
+//   /run/systemd/notify/DGRAM
+package unix
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/norganna/cynosure/common"
+	"github.com/norganna/cynosure/deps"
+)
+
+// Kind contains the kind string of this provider.
+const Kind = "unix"
+
+func init() {
+	deps.RegisterProvider(Kind, create)
+}
+
+// create returns the Broker.
+func create(_ common.StringMap) (deps.Broker, error) {
+	b := &broker{}
+	return b, nil
+}
+
+type broker struct{}
+
+// networks maps the socket TYPE to the network to dial.
+var networks = map[string]string{
+	"STREAM":    "unix",
+	"DGRAM":     "unixgram",
+	"SEQPACKET": "unixpacket",
+}
+
+func (b *broker) Dep(wait string) (deps.Depender, error) {
+	network := "unix"
+	if i := strings.LastIndex(wait, "/"); i >= 0 {
+		if n, ok := networks[wait[i+1:]]; ok {
+			network = n
+			wait = wait[:i]
+		}
+	}
+
+	if wait == "" {
+		return nil, common.ErrorMsg("no socket to wait for")
+	}
+
+	return &dep{
+		path:    wait,
+		network: network,
+	}, nil
+}
+
+type dep struct {
+	path    string
+	network string
+}
+
+func (d *dep) Check() (msg string, ok bool) {
+	msg = fmt.Sprintf("%s %s", Kind, d.path)
+
+	info, err := os.Stat(d.path)
+	if os.IsNotExist(err) {
+		return msg + " not found", false
+	}
+	if err != nil {
+		return msg + " " + err.Error(), false
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return msg + " is not a socket", false
+	}
+
+	conn, err := net.DialTimeout(d.network, d.path, time.Second)
+	if conn != nil {
+		defer func() {
+			_ = conn.Close()
+		}()
+	}
+
+	if err, ok := err.(*net.OpError); ok && err.Timeout() {
+		return msg + " timeout", false
+	}
+
+	if err != nil {
+		return msg + " " + err.Error(), false
+	}
+
+	return msg + " open", true
+}
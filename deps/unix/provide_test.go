@@ -0,0 +1,103 @@
+package unix
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	dir, err := ioutil.TempDir("", "unix")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	stream := filepath.Join(dir, "stream.sock")
+	l, err := net.Listen("unix", stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = l.Close()
+	}()
+
+	dgram := filepath.Join(dir, "dgram.sock")
+	pc, err := net.ListenPacket("unixgram", dgram)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = pc.Close()
+	}()
+
+	// A socket file that is no longer being listened on.
+	stale := filepath.Join(dir, "stale.sock")
+	sl, err := net.Listen("unix", stale)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sl.(*net.UnixListener).SetUnlinkOnClose(false)
+	_ = sl.Close()
+
+	plain := filepath.Join(dir, "plain")
+	if err := ioutil.WriteFile(plain, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := create(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		wait    string
+		path    string
+		network string
+		ok      bool
+		msg     string
+	}{
+		{"stream", stream, stream, "unix", true, "open"},
+		{"stream type", stream + "/STREAM", stream, "unix", true, "open"},
+		{"dgram", dgram + "/DGRAM", dgram, "unixgram", true, "open"},
+		{"wrong type", stream + "/DGRAM", stream, "unixgram", false, "dial unixgram " + stream + ": connect: protocol wrong type for socket"},
+		{"stale", stale, stale, "unix", false, "dial unix " + stale + ": connect: connection refused"},
+		{"not a socket", plain, plain, "unix", false, "is not a socket"},
+		{"not found", filepath.Join(dir, "missing.sock"), filepath.Join(dir, "missing.sock"), "unix", false, "not found"},
+		{"not a type", dir + "/stream", dir + "/stream", "unix", false, "not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := b.Dep(tt.wait)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d.(*dep).path != tt.path || d.(*dep).network != tt.network {
+				t.Errorf("got %s %s, want %s %s", d.(*dep).network, d.(*dep).path, tt.network, tt.path)
+			}
+
+			msg, ok := d.Check()
+			if ok != tt.ok || msg != "unix "+tt.path+" "+tt.msg {
+				t.Errorf("got %v %q, want %v %q", ok, msg, tt.ok, tt.msg)
+			}
+		})
+	}
+}
+
+func TestDepInvalid(t *testing.T) {
+	b := &broker{}
+	for _, wait := range []string{
+		"",
+		"/DGRAM",
+	} {
+		if _, err := b.Dep(wait); err == nil {
+			t.Errorf("%s: expected an error", wait)
+		}
+	}
+}
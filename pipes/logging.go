@@ -106,6 +106,15 @@ func (p *logging) Tail(n int) (lines []Liner, count int64) {
 	return lines, p.count
 }
 
+// Writer returns a writer that adds its lines to the log under the class (instead of `out` or `err`).
+func (p *logging) Writer(class string) io.Writer {
+	return &processor{
+		cb:     p.Add,
+		class:  class,
+		parent: p,
+	}
+}
+
 type line struct {
 	line    int64
 	class   string
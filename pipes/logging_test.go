@@ -0,0 +1,56 @@
+package pipes
+
+import (
+	"io"
+	"testing"
+)
+
+func TestWriter(t *testing.T) {
+	l := NewLogging()
+	init0 := l.Writer("init/0")
+
+	writes := []struct {
+		w    io.Writer
+		data string
+	}{
+		{init0, "migrating"},
+		{l.Out(), "server starting\n"},
+		{init0, " schema\nmigrated\n"},
+		{l.Writer("init/1"), "seeded\npartial"},
+		{l.Err(), "warning\n"},
+	}
+	for _, w := range writes {
+		if _, err := io.WriteString(w.w, w.data); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []struct {
+		class   string
+		message string
+	}{
+		{"out", "server starting"},
+		{"init/0", "migrating schema"},
+		{"init/0", "migrated"},
+		{"init/1", "seeded"},
+		{"err", "warning"},
+	}
+
+	lines, count := l.Head(10)
+	if count != int64(len(want)) || len(lines) != len(want) {
+		t.Fatalf("got %d lines (count %d), want %d", len(lines), count, len(want))
+	}
+	for i, w := range want {
+		if lines[i].Class() != w.class || lines[i].Message() != w.message {
+			t.Errorf("line %d: got %s %q, want %s %q", i, lines[i].Class(), lines[i].Message(), w.class, w.message)
+		}
+		if e := lines[i].Entry(); e.GetPos() != int64(i+1) || e.GetSource() != w.class {
+			t.Errorf("line %d: got entry %v", i, e)
+		}
+	}
+
+	tail, _ := l.Tail(2)
+	if len(tail) != 2 || tail[0].Message() != "seeded" || tail[1].Message() != "warning" {
+		t.Errorf("got tail %v", tail)
+	}
+}
@@ -41,6 +41,7 @@ type Logger interface {
 	Head(n int) (lines []Liner, count int64)
 	Since(t time.Time) (lines []Liner, count int64)
 	Tail(n int) (lines []Liner, count int64)
+	Writer(class string) io.Writer
 }
 
 // Liner is a log line entry.
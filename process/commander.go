@@ -1 +1,3 @@
 package process
+
+var envList = map[string][]string{}
@@ -0,0 +1,67 @@
+package process
+
+import (
+	"time"
+
+	"github.com/norganna/cynosure/proto/cynosure"
+)
+
+// Event reasons.
+const (
+	EventReady           = "Ready"
+	EventNotReady        = "NotReady"
+	EventReadinessPassed = "ReadinessPassed"
+	EventReadinessFailed = "ReadinessFailed"
+)
+
+// maxEvents is the number of events kept for each process.
+const maxEvents = 50
+
+// event records a notable change to the process.
+func (p *proc) event(reason, msg string) {
+	e := &cynosure.Event{
+		Time:    time.Now().UnixNano() / int64(time.Millisecond),
+		Reason:  reason,
+		Message: msg,
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	p.events = append(p.events, e)
+	if len(p.events) > maxEvents {
+		p.events = p.events[len(p.events)-maxEvents:]
+	}
+}
+
+// Events returns the recent events of the process (oldest first).
+func (p *proc) Events() []*cynosure.Event {
+	p.RLock()
+	defer p.RUnlock()
+
+	return append([]*cynosure.Event(nil), p.events...)
+}
+
+// watchReady records an event each time the process becomes ready or not-ready, until done is closed.
+func (p *proc) watchReady(done chan bool) {
+	ready := false
+	for {
+		select {
+		case <-done:
+			if ready {
+				p.event(EventNotReady, "process exited")
+			}
+			return
+		case <-time.After(readyPoll):
+		}
+
+		if now := p.Ready(); now != ready {
+			ready = now
+			if ready {
+				p.event(EventReady, "process is ready")
+			} else {
+				p.event(EventNotReady, "process is no longer ready")
+			}
+		}
+	}
+}
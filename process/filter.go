@@ -0,0 +1,51 @@
+package process
+
+import "github.com/norganna/cynosure/proto/cynosure"
+
+// Matches returns whether the process matches all of the filters.
+func Matches(p Processor, filters []*cynosure.Filter) bool {
+	for _, f := range filters {
+		if !matchFilter(p, f) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchFilter(p Processor, f *cynosure.Filter) bool {
+	var value string
+	var found bool
+
+	switch f.GetType() {
+	case cynosure.Filter_Namespace:
+		value, found = p.Namespace(), true
+	case cynosure.Filter_Group:
+		value, found = p.Group(), true
+	case cynosure.Filter_Name:
+		value, found = p.Name(), true
+	case cynosure.Filter_Identifier:
+		value, found = p.ID(), true
+	case cynosure.Filter_Label:
+		for _, kv := range p.Labels() {
+			if kv.GetKey() == f.GetKey() {
+				value, found = kv.GetValue(), true
+				break
+			}
+		}
+	}
+
+	in := false
+	if found {
+		for _, v := range f.GetValues() {
+			if v == value {
+				in = true
+				break
+			}
+		}
+	}
+
+	if f.GetOp() == cynosure.Filter_NotIn {
+		return !in
+	}
+	return in
+}
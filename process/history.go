@@ -0,0 +1,262 @@
+package process
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/norganna/cynosure/common"
+	"github.com/norganna/cynosure/proto/cynosure"
+)
+
+// Revision actions.
+const (
+	ActionStart    = "start"
+	ActionReplace  = "replace"
+	ActionRollback = "rollback"
+)
+
+// maxRevisions is the number of revisions kept for each named process.
+const maxRevisions = 50
+
+type revisionHistory struct {
+	sync.Mutex
+
+	// root is the folder the history is saved in (it is only kept in memory if empty).
+	root string
+
+	// revisions[namespace][name]
+	revisions map[string]map[string][]*cynosure.Revision
+}
+
+var history = &revisionHistory{
+	revisions: map[string]map[string][]*cynosure.Revision{},
+}
+
+// setHistoryRoot sets the folder that the revision history is saved in, so it is kept across restarts.
+func setHistoryRoot(root string) {
+	history.Lock()
+	defer history.Unlock()
+
+	history.root = root
+	history.revisions = map[string]map[string][]*cynosure.Revision{}
+}
+
+// RecordRevision adds the start request to the history of the named process in the namespace as a new revision.
+//
+// The name is that of the process being changed, which a replacement's request may not share.
+func RecordRevision(ns, name, action, identity string, source int64, req *cynosure.StartRequest) *cynosure.Revision {
+	history.Lock()
+	defer history.Unlock()
+
+	list := history.list(ns, name)
+	var number int64 = 1
+	if n := len(list); n > 0 {
+		number = list[n-1].Revision + 1
+	}
+
+	rev := &cynosure.Revision{
+		Revision: number,
+		Time:     time.Now().UnixNano() / int64(time.Millisecond),
+		Identity: identity,
+		Action:   action,
+		Source:   source,
+		Start:    req,
+	}
+
+	list = append(list, rev)
+	if len(list) > maxRevisions {
+		list = list[len(list)-maxRevisions:]
+	}
+	history.revisions[ns][name] = list
+
+	err := history.save(ns, name)
+	if err != nil {
+		fmt.Printf("Failed to save revision history: %s\n", err)
+	}
+
+	return rev
+}
+
+// History returns the revisions of the named process in the namespace (oldest first).
+func History(namespace, name string) []*cynosure.Revision {
+	history.Lock()
+	defer history.Unlock()
+
+	list := history.list(namespace, name)
+	return append([]*cynosure.Revision(nil), list...)
+}
+
+// list returns the revisions of the named process, loading them from the saved history the first time (must be
+// called with the lock held).
+func (h *revisionHistory) list(namespace, name string) []*cynosure.Revision {
+	names, ok := h.revisions[namespace]
+	if !ok {
+		names = map[string][]*cynosure.Revision{}
+		h.revisions[namespace] = names
+	}
+
+	list, ok := names[name]
+	if ok || h.root == "" {
+		return list
+	}
+
+	data, err := ioutil.ReadFile(h.file(namespace, name))
+	if err == nil {
+		saved := &cynosure.HistoryResponse{}
+		err = jsonpb.UnmarshalString(string(data), saved)
+		list = saved.GetRevisions()
+	}
+	if err != nil && !os.IsNotExist(err) {
+		fmt.Printf("Failed to load revision history: %s\n", err)
+	}
+
+	names[name] = list
+	return list
+}
+
+// save writes the revisions of the named process to the history folder (must be called with the lock held).
+func (h *revisionHistory) save(namespace, name string) error {
+	if h.root == "" {
+		return nil
+	}
+
+	err := os.MkdirAll(h.root, 0700)
+	if err != nil {
+		return common.Error(err, "failed to create history folder %s", h.root)
+	}
+
+	m := &jsonpb.Marshaler{OrigName: true}
+	data, err := m.MarshalToString(&cynosure.HistoryResponse{
+		Revisions: h.revisions[namespace][name],
+	})
+	if err != nil {
+		return common.Error(err, "failed to encode revisions of %s", name)
+	}
+
+	// Write to a temporary file first, so a crash can't leave a partially written history.
+	file := h.file(namespace, name)
+	err = ioutil.WriteFile(file+".tmp", []byte(data), 0600)
+	if err == nil {
+		err = os.Rename(file+".tmp", file)
+	}
+	if err != nil {
+		return common.Error(err, "failed to write history file %s", file)
+	}
+	return nil
+}
+
+// file returns the path of the history file of the named process.
+func (h *revisionHistory) file(namespace, name string) string {
+	return path.Join(h.root, url.QueryEscape(namespace)+","+url.QueryEscape(name)+".json")
+}
+
+// FindRevision returns the numbered revision of the named process (or the latest if number is 0).
+func FindRevision(namespace, name string, number int64) *cynosure.Revision {
+	list := History(namespace, name)
+	if len(list) == 0 {
+		return nil
+	}
+
+	if number == 0 {
+		return list[len(list)-1]
+	}
+
+	for _, rev := range list {
+		if rev.Revision == number {
+			return rev
+		}
+	}
+	return nil
+}
+
+// Diff returns the changes to the values between two start requests.
+func Diff(from, to *cynosure.StartRequest) ([]*cynosure.Change, error) {
+	a, err := flatten(from)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := flatten(to)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := map[string]bool{}
+	for path := range a {
+		paths[path] = true
+	}
+	for path := range b {
+		paths[path] = true
+	}
+
+	var keys []string
+	for path := range paths {
+		if a[path] != b[path] {
+			keys = append(keys, path)
+		}
+	}
+	sort.Strings(keys)
+
+	changes := make([]*cynosure.Change, len(keys))
+	for i, path := range keys {
+		changes[i] = &cynosure.Change{
+			Path: path,
+			From: a[path],
+			To:   b[path],
+		}
+	}
+	return changes, nil
+}
+
+// flatten returns the JSON encoded leaf values of the request, keyed by their path.
+func flatten(req *cynosure.StartRequest) (map[string]string, error) {
+	values := map[string]string{}
+	if req == nil {
+		return values, nil
+	}
+
+	m := &jsonpb.Marshaler{OrigName: true}
+	data, err := m.MarshalToString(req)
+	if err != nil {
+		return nil, common.Error(err, "failed to encode start request")
+	}
+
+	var obj interface{}
+	err = json.Unmarshal([]byte(data), &obj)
+	if err != nil {
+		return nil, common.Error(err, "failed to decode start request")
+	}
+
+	flattenValue(values, "", obj)
+	return values, nil
+}
+
+// pointerEscape escapes a key for use within a JSON pointer (RFC 6901).
+var pointerEscape = strings.NewReplacer("~", "~0", "/", "~1")
+
+// flattenValue adds the leaf values of v to values, keyed by their JSON pointer (e.g. `/command/args/0`).
+func flattenValue(values map[string]string, path string, v interface{}) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, vv := range t {
+			flattenValue(values, path+"/"+pointerEscape.Replace(k), vv)
+		}
+	case []interface{}:
+		for i, vv := range t {
+			flattenValue(values, path+"/"+strconv.Itoa(i), vv)
+		}
+	default:
+		data, _ := json.Marshal(t)
+		values[path] = string(data)
+	}
+}
@@ -0,0 +1,90 @@
+package process
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/norganna/cynosure/proto/cynosure"
+)
+
+func TestDiff(t *testing.T) {
+	from := &cynosure.StartRequest{
+		Command: &cynosure.Command{
+			Name:  "web",
+			Entry: "/bin/web",
+			Args:  []string{"-port", "80"},
+		},
+		Watches: map[string]*cynosure.Watch{
+			"a.b": {Match: "listening"},
+		},
+	}
+	to := &cynosure.StartRequest{
+		Command: &cynosure.Command{
+			Name:  "web",
+			Entry: "/bin/web",
+			Args:  []string{"-port", "8080", "-v"},
+		},
+		Watches: map[string]*cynosure.Watch{
+			"a.b": {Match: "ready"},
+			"c/d": {Match: "ready"},
+		},
+	}
+
+	changes, err := Diff(from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*cynosure.Change{
+		{Path: "/command/args/1", From: `"80"`, To: `"8080"`},
+		{Path: "/command/args/2", To: `"-v"`},
+		{Path: "/watches/a.b/match", From: `"listening"`, To: `"ready"`},
+		{Path: "/watches/c~1d/match", To: `"ready"`},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("got %d changes %v, want %d", len(changes), changes, len(want))
+	}
+	for i, c := range changes {
+		if c.Path != want[i].Path || c.From != want[i].From || c.To != want[i].To {
+			t.Errorf("change %d: got %v, want %v", i, c, want[i])
+		}
+	}
+}
+
+func TestHistorySaved(t *testing.T) {
+	dir, err := ioutil.TempDir("", "history")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = os.RemoveAll(dir)
+		setHistoryRoot("")
+	}()
+
+	setHistoryRoot(dir)
+	req := &cynosure.StartRequest{
+		Namespace: "ns/1",
+		Command:   &cynosure.Command{Name: "web"},
+	}
+	RecordRevision("ns/1", "web", ActionStart, "alice", 0, req)
+	RecordRevision("ns/1", "web", ActionReplace, "bob", 0, req)
+
+	// Setting the root again forgets the history in memory, as a restart would.
+	setHistoryRoot(dir)
+	list := History("ns/1", "web")
+	if len(list) != 2 {
+		t.Fatalf("got %d revisions after reload, want 2", len(list))
+	}
+	if list[1].Revision != 2 || list[1].Identity != "bob" || list[1].Action != ActionReplace {
+		t.Errorf("got revision %v", list[1])
+	}
+
+	rev := RecordRevision("ns/1", "web", ActionRollback, "alice", 1, req)
+	if rev.Revision != 3 {
+		t.Errorf("got revision number %d after reload, want 3", rev.Revision)
+	}
+	if FindRevision("ns/1", "web", 0).Revision != 3 {
+		t.Error("latest revision not found")
+	}
+}
@@ -0,0 +1,176 @@
+package process
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os/exec"
+	"time"
+
+	"github.com/norganna/cynosure/common"
+	"github.com/norganna/cynosure/pipes"
+	"github.com/norganna/cynosure/proto/cynosure"
+)
+
+// HookClass is the log class that the output of hooks is recorded under.
+const HookClass = "hook"
+
+// defaultHookTimeout is how long a hook has to complete if it does not specify.
+const defaultHookTimeout = 10 * time.Second
+
+// maxHookResults is the number of hook results kept for each process.
+const maxHookResults = 20
+
+// Standard error messages.
+var (
+	ErrHookTimedOut = common.ErrorMsg("hook timed out")
+)
+
+// postStart waits for the process to become ready and then runs the post-start hook (unless done is closed first).
+//
+// The result only applies to the run of the command with the generation, so a late result does not affect the next run.
+func (p *proc) postStart(done chan bool, generation int) {
+	hook := p.c.GetHooks().GetPostStart()
+	if hook == nil {
+		return
+	}
+
+	for !p.ready(false) {
+		select {
+		case <-done:
+			return
+		case <-time.After(readyPoll):
+		}
+	}
+
+	ok := p.runHook(cynosure.HookResult_PostStart, hook, nil)
+
+	p.Lock()
+	defer p.Unlock()
+
+	if p.generation != generation {
+		return
+	}
+	p.hookPending = false
+	if !ok && hook.GetNotReady() {
+		p.hookNotReady = true
+	}
+}
+
+// preStop runs the pre-stop hook (if any) before the process is asked to stop.
+func (p *proc) preStop() {
+	if hook := p.c.GetHooks().GetPreStop(); hook != nil {
+		p.runHook(cynosure.HookResult_PreStop, hook, nil)
+	}
+}
+
+// postExit runs the post-exit hook (if any) after the process exits with the code.
+func (p *proc) postExit(code int32) {
+	if hook := p.c.GetHooks().GetPostExit(); hook != nil {
+		p.runHook(cynosure.HookResult_PostExit, hook, []string{
+			fmt.Sprintf("CYNO_EXIT_CODE=%d", code),
+		})
+	}
+}
+
+// runHook runs the hook, recording the result against the process and in its log.
+func (p *proc) runHook(point cynosure.HookResult_Point, hook *cynosure.Hook, env []string) bool {
+	w := pipes.Default.Out()
+	if logging := p.Log(); logging != nil {
+		w = logging.Writer(HookClass)
+	}
+
+	timeout := defaultHookTimeout
+	if ms := hook.GetTimeout(); ms > 0 {
+		timeout = time.Duration(ms) * time.Millisecond
+	}
+
+	name := point.String()
+	_, _ = w.Write([]byte("Running " + name + " hook\n"))
+
+	start := time.Now()
+	var msg string
+	var err error
+	if hook.GetUrl() != "" {
+		msg, err = p.httpHook(hook, timeout)
+	} else {
+		msg, err = p.execHook(name, hook, env, timeout, w)
+	}
+
+	result := &cynosure.HookResult{
+		Point:    point,
+		Time:     start.UnixNano() / int64(time.Millisecond),
+		Duration: int64(time.Now().Sub(start) / time.Millisecond),
+		Success:  err == nil,
+		Message:  msg,
+	}
+	if err != nil {
+		result.Message = err.Error()
+	}
+	outcome := "succeeded"
+	if !result.Success {
+		outcome = "failed"
+	}
+	_, _ = w.Write([]byte("Hook " + name + " " + outcome + ": " + result.Message + "\n"))
+
+	p.Lock()
+	p.hooks = append(p.hooks, result)
+	if len(p.hooks) > maxHookResults {
+		p.hooks = p.hooks[len(p.hooks)-maxHookResults:]
+	}
+	p.Unlock()
+
+	return result.Success
+}
+
+func (p *proc) execHook(name string, hook *cynosure.Hook, env []string, timeout time.Duration, w io.Writer) (string, error) {
+	extra := append(append(append([]string(nil), p.c.GetEnv()...), hook.GetEnv()...), env...)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// The hook runs in its own process group, so anything it leaves running is killed with it at the timeout.
+	cmd := p.commandContext(ctx, name, hook.GetEntry(), hook.GetArgs(), extra, w, w)
+	err := RunGroup(ctx, cmd)
+	if ctx.Err() == context.DeadlineExceeded {
+		return "", ErrHookTimedOut
+	}
+	if err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			return "", common.Error(err, "hook exited with error")
+		}
+		return "", common.Error(err, "failed to run hook")
+	}
+	return "exited successfully", nil
+}
+
+func (p *proc) httpHook(hook *cynosure.Hook, timeout time.Duration) (string, error) {
+	method := hook.GetMethod()
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	url := expandPorts([]string{hook.GetUrl()}, p.ports)[0]
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return "", common.Error(err, "failed to create hook request")
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", common.Error(err, "failed to call hook")
+	}
+	_, _ = io.Copy(ioutil.Discard, res.Body)
+	_ = res.Body.Close()
+
+	if res.StatusCode >= 400 {
+		return "", common.ErrorMsg("hook returned status %d", res.StatusCode)
+	}
+	return res.Status, nil
+}
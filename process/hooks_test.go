@@ -0,0 +1,110 @@
+package process
+
+import (
+	"testing"
+	"time"
+
+	"github.com/norganna/cynosure/proto/cynosure"
+)
+
+// hooked returns a request for a long running command with a post-start hook running the shell script.
+func hooked(script string, notReady bool) *cynosure.StartRequest {
+	req := sleeper("60", 1)
+	req.Command.Hooks = &cynosure.Hooks{
+		PostStart: &cynosure.Hook{
+			Entry:    "/bin/sh",
+			Args:     []string{"-c", script},
+			NotReady: notReady,
+		},
+	}
+	return req
+}
+
+func TestPostStartReady(t *testing.T) {
+	tests := []struct {
+		name     string
+		script   string
+		notReady bool
+		ready    bool
+	}{
+		{"succeeds", "sleep 0.3", true, true},
+		{"fails", "sleep 0.3; exit 1", false, true},
+		{"fails not ready", "sleep 0.3; exit 1", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewProcess(hooked(tt.script, tt.notReady), "", 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer p.Close()
+			go p.Loop()
+
+			// While the hook is running the process is not ready.
+			time.Sleep(150 * time.Millisecond)
+			if p.PID() < 1 {
+				t.Fatal("process did not start")
+			}
+			if p.Ready() {
+				t.Error("ready before the post-start hook finished")
+			}
+
+			time.Sleep(500 * time.Millisecond)
+			if p.Ready() != tt.ready {
+				t.Errorf("got ready %v after the hook, want %v", p.Ready(), tt.ready)
+			}
+		})
+	}
+}
+
+func TestPostStartGeneration(t *testing.T) {
+	process, err := NewProcess(hooked("exit 1", true), "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer process.Close()
+
+	p := process.(*proc)
+	p.started = 1
+	p.generation = 2
+	p.hookPending = true
+
+	// The result of a hook started by an earlier run does not apply to this one.
+	p.postStart(make(chan bool), 1)
+	if p.hookNotReady || !p.hookPending {
+		t.Error("late post-start result was applied to the next run")
+	}
+
+	p.postStart(make(chan bool), 2)
+	if !p.hookNotReady || p.hookPending {
+		t.Error("post-start result was not applied to its own run")
+	}
+}
+
+func TestHookTimeout(t *testing.T) {
+	process, err := NewProcess(sleeper("60", 1), "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer process.Close()
+	p := process.(*proc)
+
+	// The backgrounded sleep keeps the output open, so it must be killed along with the hook.
+	hook := &cynosure.Hook{
+		Entry:   "/bin/sh",
+		Args:    []string{"-c", "sleep 5 & sleep 5"},
+		Timeout: 200,
+	}
+
+	start := time.Now()
+	if p.runHook(cynosure.HookResult_PreStop, hook, nil) {
+		t.Error("timed out hook succeeded")
+	}
+	if elapsed := time.Now().Sub(start); elapsed > time.Second {
+		t.Errorf("hook took %s to time out", elapsed)
+	}
+	if msg := p.hooks[len(p.hooks)-1].GetMessage(); msg != ErrHookTimedOut.Error() {
+		t.Errorf("got hook message %q", msg)
+	}
+}
@@ -0,0 +1,281 @@
+package process
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/norganna/cynosure/common"
+)
+
+// defaultImageVersion is the version of an image that is used if none is specified.
+const defaultImageVersion = "latest"
+
+// ImagePath is the `PATH` used to find commands within an image.
+const ImagePath = "/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"
+
+// maxImageLinks is the number of symlinks that may be followed when resolving a path within an image.
+const maxImageLinks = 40
+
+// Standard error messages.
+var (
+	ErrImageNotFound = common.ErrorMsg("image not found")
+	ErrImageName     = common.ErrorMsg("invalid image name")
+	ErrImageLink     = common.ErrorMsg("link target is outside of the image")
+	ErrImageSymlink  = common.ErrorMsg("refusing to write through a symlink")
+	ErrImageLoop     = common.ErrorMsg("too many levels of symlinks")
+)
+
+// imageLock stops an image being extracted more than once at the same time.
+var imageLock sync.Mutex
+
+// ImageRoot returns the folder containing the file system of the image (of the form `NAME:VERSION`).
+//
+// The image is stored at `${root}/images/NAME/VERSION.tar.gz`, and is extracted to the `${root}/images/NAME/VERSION`
+// folder the first time it is needed.
+func ImageRoot(image string) (string, error) {
+	name, version := image, defaultImageVersion
+	if parts := strings.SplitN(image, ":", 2); len(parts) == 2 {
+		name, version = parts[0], parts[1]
+	}
+	if !validImagePart(name) || !validImagePart(version) {
+		return "", common.Error(ErrImageName, "failed to find image %s", image)
+	}
+
+	rootLock.RLock()
+	dir := path.Join(imageRoot, name, version)
+	rootLock.RUnlock()
+
+	imageLock.Lock()
+	defer imageLock.Unlock()
+
+	if common.DirExists(dir) {
+		return dir, nil
+	}
+
+	archive := dir + ".tar.gz"
+	if !common.FileExists(archive) {
+		return "", common.Error(ErrImageNotFound, "failed to find image %s", image)
+	}
+
+	// Extract to a temporary folder first, so a failure doesn't leave a partial image.
+	tmp := dir + ".extracting"
+	_ = os.RemoveAll(tmp)
+
+	err := extractImage(archive, tmp)
+	if err == nil {
+		err = os.Rename(tmp, dir)
+	}
+	if err != nil {
+		_ = os.RemoveAll(tmp)
+		return "", common.Error(err, "failed to extract image %s", image)
+	}
+	return dir, nil
+}
+
+func validImagePart(part string) bool {
+	return part != "" && part != "." && part != ".." && !strings.ContainsAny(part, `/\`)
+}
+
+// extractImage extracts the tar.gz archive into the dir.
+func extractImage(archive, dir string) error {
+	f, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// Keep everything within the dir, however the entry is named.
+		target := filepath.Join(dir, filepath.Join("/", hdr.Name))
+		mode := os.FileMode(hdr.Mode).Perm()
+
+		// An earlier entry may have been a symlink to outside of the dir, which must not be written through.
+		err = noSymlinks(dir, target)
+		if err != nil {
+			return common.Error(err, "failed to extract %s", hdr.Name)
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(target, mode|0700)
+		case tar.TypeReg, tar.TypeRegA:
+			err = extractFile(tr, target, mode)
+		case tar.TypeSymlink:
+			// Absolute targets are within the image root, and relative ones must not climb out of it.
+			if !filepath.IsAbs(hdr.Linkname) && !within(dir, filepath.Join(filepath.Dir(target), hdr.Linkname)) {
+				err = ErrImageLink
+				break
+			}
+			err = os.MkdirAll(filepath.Dir(target), 0755)
+			if err == nil {
+				err = os.Symlink(hdr.Linkname, target)
+			}
+		case tar.TypeLink:
+			source := filepath.Join(dir, filepath.Join("/", hdr.Linkname))
+			err = noSymlinks(dir, source)
+			if err == nil {
+				err = os.MkdirAll(filepath.Dir(target), 0755)
+			}
+			if err == nil {
+				err = os.Link(source, target)
+			}
+		}
+		if err != nil {
+			return common.Error(err, "failed to extract %s", hdr.Name)
+		}
+	}
+}
+
+func extractFile(r io.Reader, target string, mode os.FileMode) error {
+	err := os.MkdirAll(filepath.Dir(target), 0755)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC|syscall.O_NOFOLLOW, mode)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(f, r)
+	if cErr := f.Close(); err == nil {
+		err = cErr
+	}
+	return err
+}
+
+// within returns whether the path is the dir or inside of it.
+func within(dir, p string) bool {
+	rel, err := filepath.Rel(dir, p)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, "../")
+}
+
+// noSymlinks returns an error if the path (or any folder leading to it) within the dir is an existing symlink.
+func noSymlinks(dir, p string) error {
+	rel, err := filepath.Rel(dir, p)
+	if err != nil || !within(dir, p) {
+		return ErrImageLink
+	}
+	if rel == "." {
+		return nil
+	}
+
+	current := dir
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		current = filepath.Join(current, part)
+
+		info, err := os.Lstat(current)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return ErrImageSymlink
+		}
+	}
+	return nil
+}
+
+// ImageLookPath searches for the file within the `ImagePath` folders of the image root, returning its path within the
+// image (for use once the root has been changed to the image).
+//
+// Symlinks are followed as they would be within the image, so they can't lead outside of it.
+func ImageLookPath(root, file string) (string, error) {
+	if strings.Contains(file, "/") {
+		if imageExecutable(root, file) {
+			return file, nil
+		}
+		return "", common.ErrorMsg("%s not found within the image", file)
+	}
+
+	for _, dir := range filepath.SplitList(ImagePath) {
+		p := path.Join(dir, file)
+		if imageExecutable(root, p) {
+			return p, nil
+		}
+	}
+	return "", common.ErrorMsg("%s not found in the image PATH", file)
+}
+
+// imageExecutable returns whether the path within the image is an executable file.
+func imageExecutable(root, p string) bool {
+	resolved, err := imageResolve(root, p)
+	if err != nil {
+		return false
+	}
+
+	info, err := os.Lstat(resolved)
+	return err == nil && info.Mode().IsRegular() && info.Mode().Perm()&0111 != 0
+}
+
+// imageResolve returns the path on the server of the path within the image, following any symlinks as they would
+// be within the image.
+func imageResolve(root, p string) (string, error) {
+	resolved := "/"
+	parts := strings.Split(strings.TrimPrefix(path.Join("/", p), "/"), "/")
+
+	for links := 0; len(parts) > 0; {
+		part := parts[0]
+		parts = parts[1:]
+		if part == "" {
+			continue
+		}
+
+		next := path.Join(resolved, part)
+		info, err := os.Lstat(filepath.Join(root, next))
+		if err != nil {
+			return "", err
+		}
+
+		if info.Mode()&os.ModeSymlink == 0 {
+			resolved = next
+			continue
+		}
+
+		links++
+		if links > maxImageLinks {
+			return "", ErrImageLoop
+		}
+
+		link, err := os.Readlink(filepath.Join(root, next))
+		if err != nil {
+			return "", err
+		}
+		if path.IsAbs(link) {
+			resolved = "/"
+		}
+		parts = append(strings.Split(strings.Trim(link, "/"), "/"), parts...)
+	}
+
+	return filepath.Join(root, resolved), nil
+}
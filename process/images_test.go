@@ -0,0 +1,195 @@
+package process
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// entry is an entry of a test archive.
+type entry struct {
+	name string
+	kind byte
+	link string
+	mode int64
+}
+
+// writeArchive writes a tar.gz of the entries to the path.
+func writeArchive(t *testing.T, archive string, entries []entry) {
+	f, err := os.Create(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e.name, Typeflag: e.kind, Linkname: e.link, Mode: e.mode}
+		if hdr.Mode == 0 {
+			hdr.Mode = 0644
+		}
+		body := ""
+		if e.kind == tar.TypeReg {
+			body = "data"
+			hdr.Size = int64(len(body))
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExtractImage(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []entry
+		err     error
+	}{
+		{"files", []entry{
+			{name: "bin/", kind: tar.TypeDir, mode: 0755},
+			{name: "bin/tool", kind: tar.TypeReg, mode: 0755},
+			{name: "../../escape", kind: tar.TypeReg},
+		}, nil},
+		{"absolute symlink", []entry{
+			{name: "usr/bin/tool", kind: tar.TypeReg},
+			{name: "bin", kind: tar.TypeSymlink, link: "/usr/bin"},
+		}, nil},
+		{"relative symlink", []entry{
+			{name: "usr/lib/a", kind: tar.TypeReg},
+			{name: "lib", kind: tar.TypeSymlink, link: "usr/lib"},
+			{name: "usr/lib/b", kind: tar.TypeSymlink, link: "../../lib/a"},
+		}, nil},
+		{"escaping symlink", []entry{
+			{name: "etc/passwd", kind: tar.TypeSymlink, link: "../../../../etc/passwd"},
+		}, ErrImageLink},
+		{"write through symlink", []entry{
+			{name: "out", kind: tar.TypeSymlink, link: "/"},
+			{name: "out/written", kind: tar.TypeReg},
+		}, ErrImageSymlink},
+		{"write to symlink", []entry{
+			{name: "file", kind: tar.TypeSymlink, link: "/other"},
+			{name: "file", kind: tar.TypeReg},
+		}, ErrImageSymlink},
+		{"hard link through symlink", []entry{
+			{name: "out", kind: tar.TypeSymlink, link: "/"},
+			{name: "copy", kind: tar.TypeLink, link: "out/secret"},
+		}, ErrImageSymlink},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base, err := ioutil.TempDir("", "image")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer func() {
+				_ = os.RemoveAll(base)
+			}()
+
+			// A file outside of the image, which must never be written or linked.
+			if err := ioutil.WriteFile(filepath.Join(base, "secret"), []byte("secret"), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			archive := filepath.Join(base, "image.tar.gz")
+			writeArchive(t, archive, tt.entries)
+
+			dir := filepath.Join(base, "root", "image")
+			err = extractImage(archive, dir)
+			if tt.err == nil && err != nil {
+				t.Fatalf("got error %v", err)
+			}
+			if tt.err != nil && (err == nil || !strings.Contains(err.Error(), tt.err.Error())) {
+				t.Fatalf("got error %v, want %v", err, tt.err)
+			}
+
+			// Nothing is written outside of the image.
+			found, _ := filepath.Glob(filepath.Join(base, "*"))
+			for _, f := range found {
+				switch filepath.Base(f) {
+				case "secret", "image.tar.gz", "root":
+				default:
+					t.Errorf("%s was written outside of the image", f)
+				}
+			}
+		})
+	}
+}
+
+func TestImageLookPath(t *testing.T) {
+	root, err := ioutil.TempDir("", "image")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = os.RemoveAll(root)
+	}()
+
+	files := map[string]os.FileMode{
+		"usr/bin/tool":   0755,
+		"usr/bin/data":   0644,
+		"opt/app/run":    0755,
+		"usr/sbin/.keep": 0644,
+	}
+	for name, mode := range files {
+		p := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, nil, mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	links := map[string]string{
+		// Absolute links are followed within the image, not on the server.
+		"sbin":             "/opt/app",
+		"usr/local":        "../../../../../../tmp",
+		"usr/sbin/loop":    "loop",
+		"usr/sbin/sh":      "/bin/sh",
+		"usr/sbin/climbed": "../../../../../../usr/bin/tool",
+	}
+	for name, link := range links {
+		if err := os.Symlink(link, filepath.Join(root, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		file  string
+		found string
+	}{
+		{"tool", "/usr/bin/tool"},
+		{"run", "/sbin/run"},
+		{"climbed", "/usr/sbin/climbed"},
+		{"/opt/app/run", "/opt/app/run"},
+		{"data", ""},
+		{"loop", ""},
+		{"sh", ""},
+		{"missing", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			found, err := ImageLookPath(root, tt.file)
+			if found != tt.found || (err == nil) != (tt.found != "") {
+				t.Errorf("got %q %v, want %q", found, err, tt.found)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package process
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+
+	"github.com/norganna/cynosure/common"
+	"github.com/norganna/cynosure/pipes"
+)
+
+// InitClass is the log class that the output of init commands is recorded under.
+const InitClass = "init"
+
+// runInit runs each of the init commands in order, stopping at the first that fails.
+//
+// Returns the exit code of the failed init command along with the error.
+func (p *proc) runInit() (int32, error) {
+	for i, init := range p.c.GetInit() {
+		name := init.GetName()
+		if name == "" {
+			name = strconv.Itoa(i + 1)
+		}
+
+		w := pipes.Default.Out()
+		if logging := p.Log(); logging != nil {
+			w = logging.Writer(InitClass)
+		}
+
+		env := append(append([]string(nil), p.c.GetEnv()...), init.GetEnv()...)
+		cmd := p.command(name, init.GetEntry(), init.GetArgs(), env, w, w)
+
+		fmt.Printf("Executing init: %s\n", name)
+		_, _ = w.Write([]byte("Running init " + name + "\n"))
+
+		p.Lock()
+		p.initCmd = cmd
+		p.Unlock()
+
+		err := runCmd(cmd)
+
+		p.Lock()
+		p.initCmd = nil
+		p.Unlock()
+
+		if err != nil {
+			var code int32 = -1
+			if exit, ok := err.(*exec.ExitError); ok {
+				code = int32(exit.ExitCode())
+			}
+			_, _ = w.Write([]byte("Init " + name + " failed: " + err.Error() + "\n"))
+			return code, common.Error(err, "init command %s failed", name)
+		}
+	}
+	return 0, nil
+}
+
+// stopInit kills any init command that is currently running.
+func (p *proc) stopInit() {
+	p.RLock()
+	cmd := p.initCmd
+	p.RUnlock()
+
+	if cmd != nil && cmd.Process != nil {
+		_ = cmd.Process.Kill()
+	}
+}
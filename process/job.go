@@ -0,0 +1,120 @@
+package process
+
+import (
+	"sync"
+	"time"
+
+	"github.com/norganna/cynosure/proto/cynosure"
+)
+
+var jobLock sync.RWMutex
+var jobTTL = time.Hour
+
+// SetJobTTL sets how long finished jobs are kept (so their status can be inspected) before being removed.
+func SetJobTTL(ttl time.Duration) {
+	jobLock.Lock()
+	defer jobLock.Unlock()
+
+	jobTTL = ttl
+}
+
+func finishedTTL() time.Duration {
+	jobLock.RLock()
+	defer jobLock.RUnlock()
+
+	return jobTTL
+}
+
+// State returns the completion state of the process (services are always running).
+func (p *proc) State() cynosure.Process_State {
+	p.RLock()
+	defer p.RUnlock()
+
+	return p.state
+}
+
+// startDeadline arranges for a job to be stopped and failed once its deadline passes.
+func (p *proc) startDeadline() {
+	if p.job == nil || p.job.GetDeadline() <= 0 {
+		return
+	}
+
+	timer := time.AfterFunc(time.Duration(p.job.GetDeadline())*time.Millisecond, func() {
+		if p.State() != cynosure.Process_Running {
+			return
+		}
+
+		close(p.expired)
+		p.stop()
+	})
+
+	p.Lock()
+	defer p.Unlock()
+
+	select {
+	case <-p.ch:
+		// Closed before the deadline was set, so it will not be stopped by Close.
+		timer.Stop()
+	default:
+		p.deadline = timer
+	}
+}
+
+// stopDeadline stops the job deadline (if any) from expiring.
+func (p *proc) stopDeadline() {
+	p.Lock()
+	defer p.Unlock()
+
+	if p.deadline != nil {
+		p.deadline.Stop()
+	}
+}
+
+// jobDone records the outcome of an attempt at running the job and returns whether the job has finished.
+func (p *proc) jobDone(ran bool, err error) bool {
+	select {
+	case <-p.expired:
+		p.finish(cynosure.Process_Failed, "deadline exceeded")
+		return true
+	default:
+	}
+
+	if !ran {
+		return false
+	}
+
+	if err == nil {
+		p.stopDeadline()
+		p.finish(cynosure.Process_Succeeded, "")
+		return true
+	}
+
+	p.RLock()
+	attempts := p.attempts
+	p.RUnlock()
+
+	if attempts > p.job.GetRetries() {
+		p.stopDeadline()
+		p.finish(cynosure.Process_Failed, "")
+		return true
+	}
+	return false
+}
+
+// finish sets the final state of the process, replacing the exit message if one is supplied.
+func (p *proc) finish(state cynosure.Process_State, msg string) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.state = state
+	if msg != "" {
+		p.exitMsg = msg
+	}
+	p.finished = time.Now().UnixNano() / int64(time.Millisecond)
+
+	kind := "Process"
+	if p.job != nil {
+		kind = "Job"
+	}
+	_, _ = p.Log().Out().Write([]byte(kind + " " + state.String() + ": " + p.exitMsg + "\n"))
+}
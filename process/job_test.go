@@ -0,0 +1,164 @@
+package process
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/norganna/cynosure/deps"
+	_ "github.com/norganna/cynosure/deps/always"
+	"github.com/norganna/cynosure/proto/cynosure"
+)
+
+// job returns a request for a job running the shell script, with a deadline in milliseconds.
+func job(script string, deadline int64) *cynosure.StartRequest {
+	return &cynosure.StartRequest{
+		Kind: cynosure.StartRequest_Job,
+		Job:  &cynosure.Job{Deadline: deadline},
+		Command: &cynosure.Command{
+			Name:  "job",
+			Entry: "/bin/sh",
+			Args:  []string{"-c", script},
+		},
+	}
+}
+
+func TestJobDeadline(t *testing.T) {
+	err := deps.NewInstance("never", "", "always", map[string]string{"state": "false"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		script  string
+		waiting bool
+		close   bool
+		state   cynosure.Process_State
+		message string
+	}{
+		{"succeeds before deadline", "exit 0", false, false, cynosure.Process_Succeeded, "exited successfully"},
+		{"fails before deadline", "exit 3", false, false, cynosure.Process_Failed, "exit status 3"},
+		{"exceeds deadline", "exec sleep 5", false, false, cynosure.Process_Failed, "deadline exceeded"},
+		{"closed while running", "exec sleep 5", false, true, cynosure.Process_Failed, "signal: interrupt"},
+		{"closed while waiting", "exit 0", true, true, cynosure.Process_Running, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := job(tt.script, 200)
+			if tt.waiting {
+				req.Command.Requirements = map[string]*cynosure.Deps{
+					"never": {Deps: []*cynosure.Dep{{Identity: "never"}}},
+				}
+			}
+
+			p, err := NewProcess(req, "", 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			done := make(chan bool)
+			go func() {
+				p.Loop()
+				close(done)
+			}()
+			if tt.close {
+				time.Sleep(50 * time.Millisecond)
+				p.Close()
+			}
+
+			select {
+			case <-done:
+			case <-time.After(3 * time.Second):
+				t.Fatal("job did not finish")
+			}
+
+			// Wait past the deadline, which must not change the outcome.
+			time.Sleep(300 * time.Millisecond)
+			info := p.Process()
+			if info.GetState() != tt.state {
+				t.Errorf("got state %s, want %s", info.GetState(), tt.state)
+			}
+			if info.GetExitMessage() != tt.message {
+				t.Errorf("got exit message %q, want %q", info.GetExitMessage(), tt.message)
+			}
+			if tt.state != cynosure.Process_Running && info.GetFinished() == 0 {
+				t.Error("finished time not recorded")
+			}
+			if tt.message != "deadline exceeded" {
+				select {
+				case <-p.(*proc).expired:
+					t.Error("deadline expired after the job finished")
+				default:
+				}
+			}
+			p.Close()
+		})
+	}
+}
+
+func TestJobRunError(t *testing.T) {
+	req := job("", 0)
+	req.Command.Entry = "/nonexistent/entry"
+
+	p, err := NewProcess(req, "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+
+	// Failing to run must fail the job (not exit the server).
+	p.Loop()
+	info := p.Process()
+	if info.GetState() != cynosure.Process_Failed || info.GetExitCode() != -1 {
+		t.Errorf("got state %s with exit code %d", info.GetState(), info.GetExitCode())
+	}
+
+	lines, _ := p.Log().Tail(10)
+	found := false
+	for _, line := range lines {
+		if strings.Contains(line.Message(), "Failed to run command") {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("run error not logged")
+	}
+}
+
+func TestJobTTL(t *testing.T) {
+	err := deps.NewInstance("never", "", "always", map[string]string{"state": "false"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	SetJobTTL(100 * time.Millisecond)
+	defer SetJobTTL(time.Hour)
+
+	m := NewProcessManager()
+	defer m.Quit()
+
+	_, jobs, err := m.Start(job("exit 0", 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A service that gave up waiting for its requirements has finished too, but is not a job.
+	service := sleeper("60", 1)
+	service.Command.Requirements = map[string]*cynosure.Deps{
+		"never": {Deps: []*cynosure.Dep{{Identity: "never"}}, Deadline: 100},
+	}
+	_, services, err := m.Start(service)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(time.Second)
+	if m.Get(jobs[0].ID()) != nil {
+		t.Error("finished job was kept past the TTL")
+	}
+	if p := m.Get(services[0].ID()); p == nil || p.State() != cynosure.Process_Failed {
+		t.Errorf("failed service was not kept (got %v)", p)
+	}
+}
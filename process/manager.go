@@ -1,91 +1,293 @@
 package process
 
-var envList = map[string][]string{}
+import (
+	"sort"
+	"sync"
+	"time"
 
-/*
+	"github.com/NorgannasAddOns/go-uuid"
+	"github.com/norganna/cynosure/common"
+	"github.com/norganna/cynosure/proto/cynosure"
+)
 
+// Standard error messages.
+var (
+	ErrNoCommand    = common.ErrorMsg("no command was supplied to start")
+	ErrUnknownGroup = common.ErrorMsg("unknown process group")
+	ErrBadReplicas  = common.ErrorMsg("replicas must not be negative")
+)
+
+// ProcessManager keeps track of the processes running on this server.
 type ProcessManager interface {
-	Create(c Commander) Process
-	Get(id string) Process
-	List() (list []Process)
+	Get(id string) Processor
+	Group(group string) []Processor
+	List(filters []*cynosure.Filter) []Processor
+	Named(namespace, name string) string
 	Quit()
-	Run()
+	Replace(target string, req *cynosure.StartRequest, deadline time.Duration, surge int) (group string, list []Processor, err error)
+	Scale(group string, replicas int) ([]Processor, error)
+	Start(req *cynosure.StartRequest) (group string, list []Processor, err error)
+	Stop(id string) bool
+}
+
+// Default is the process manager used by the server.
+var Default = NewProcessManager()
+
+type processGroup struct {
+	id        string
+	req       *cynosure.StartRequest
+	created   time.Time
+	members   map[int]Processor
+	replacing bool
 }
 
 type processManager struct {
 	sync.RWMutex
 
-	ch          chan bool
-	processList map[string]Process
+	groups      map[string]*processGroup
+	processList map[string]Processor
 }
 
 var _ ProcessManager = (*processManager)(nil)
 
+// NewProcessManager creates a new ProcessManager.
 func NewProcessManager() ProcessManager {
 	return &processManager{
-		ch:          make(chan bool),
-		processList: map[string]Process{},
+		groups:      map[string]*processGroup{},
+		processList: map[string]Processor{},
 	}
 }
 
-func (p *processManager) Create(c Commander) Process {
-	process := NewProcess(c)
-	p.set(process.ID(), process)
-	go process.Loop()
+func (m *processManager) Get(id string) Processor {
+	m.RLock()
+	defer m.RUnlock()
 
-	for i := 0; i < 10 && process.PID() == -1; i++ {
-		time.Sleep(5 * time.Millisecond)
+	return m.processList[id]
+}
+
+func (m *processManager) Group(group string) []Processor {
+	m.RLock()
+	defer m.RUnlock()
+
+	g, ok := m.groups[group]
+	if !ok {
+		return nil
 	}
-	return process
+	return g.list()
 }
 
-func (p *processManager) Get(id string) Process {
-	p.RLock()
-	defer p.RUnlock()
+func (m *processManager) List(filters []*cynosure.Filter) (list []Processor) {
+	m.RLock()
+	defer m.RUnlock()
 
-	if id == "" && len(p.processList) == 1 {
-		for id = range p.processList {
-			break
+	for _, process := range m.processList {
+		if Matches(process, filters) {
+			list = append(list, process)
 		}
 	}
 
-	return p.processList[id]
+	sort.Slice(list, func(i, j int) bool {
+		a, b := list[i], list[j]
+		if a.Namespace() != b.Namespace() {
+			return a.Namespace() < b.Namespace()
+		}
+		if a.Group() != b.Group() {
+			return a.Group() < b.Group()
+		}
+		return a.Index() < b.Index()
+	})
+	return list
 }
 
-func (p *processManager) List() (list []Process) {
-	p.RLock()
-	defer p.RUnlock()
+// Named returns the most recently started group running the named command in the namespace (or "" if none).
+func (m *processManager) Named(namespace, name string) string {
+	m.RLock()
+	defer m.RUnlock()
 
-	for _, process := range p.processList {
-		list = append(list, process)
+	var found *processGroup
+	for _, g := range m.groups {
+		if g.req.GetNamespace() != namespace || g.req.GetCommand().GetName() != name {
+			continue
+		}
+		if found == nil || g.created.After(found.created) {
+			found = g
+		}
 	}
 
-	return list
+	if found == nil {
+		return ""
+	}
+	return found.id
 }
 
-func (p *processManager) Quit() {
-	p.Lock()
-	defer p.Unlock()
+func (m *processManager) Quit() {
+	m.Lock()
+	defer m.Unlock()
 
-	for _, process := range p.processList {
+	for _, process := range m.processList {
 		process.Close()
 	}
-	close(p.ch)
+	m.processList = map[string]Processor{}
+	m.groups = map[string]*processGroup{}
+}
+
+func (m *processManager) Scale(group string, replicas int) ([]Processor, error) {
+	if replicas < 0 {
+		return nil, ErrBadReplicas
+	}
+
+	m.Lock()
+	g, ok := m.groups[group]
+	if !ok {
+		m.Unlock()
+		return nil, ErrUnknownGroup
+	}
+	if g.replacing {
+		m.Unlock()
+		return nil, ErrReplacing
+	}
+
+	started, err := m.scale(g, replicas)
+	m.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	waitStarted(started)
+	return m.Group(g.id), nil
 }
 
-func (p *processManager) Run() {
-	for {
-		select {
-		case <-p.ch:
-			return
+func (m *processManager) Start(req *cynosure.StartRequest) (group string, list []Processor, err error) {
+	c := req.GetCommand()
+	if c == nil {
+		return "", nil, ErrNoCommand
+	}
+
+	replicas := int(req.GetReplicas())
+	if req.GetKind() == cynosure.StartRequest_Job {
+		replicas = int(req.GetJob().GetParallelism())
+	}
+	if replicas < 1 {
+		replicas = 1
+	}
+
+	g := &processGroup{
+		id:      c.GetName() + "-" + uuid.New("g"),
+		req:     req,
+		created: time.Now(),
+		members: map[int]Processor{},
+	}
+
+	m.Lock()
+	m.groups[g.id] = g
+	started, err := m.scale(g, replicas)
+	if err != nil {
+		_, _ = m.scale(g, 0)
+		delete(m.groups, g.id)
+		m.Unlock()
+		return "", nil, err
+	}
+	m.Unlock()
+
+	waitStarted(started)
+	return g.id, m.Group(g.id), nil
+}
+
+func (m *processManager) Stop(id string) bool {
+	m.Lock()
+	defer m.Unlock()
+
+	process, ok := m.processList[id]
+	if !ok {
+		return false
+	}
+
+	m.remove(process)
+	process.Close()
+
+	// Stopping the last member of a group removes the group (scaling to zero does not). A group being replaced is
+	// removed once the replacement finishes instead.
+	if g, ok := m.groups[process.Group()]; ok && len(g.members) == 0 && !g.replacing {
+		delete(m.groups, g.id)
+	}
+	return true
+}
+
+// scale starts or stops members of the group until there are the requested number of replicas, returning the
+// processes that were started (must be called with the lock held).
+//
+// New replicas fill the lowest free indexes, and the highest indexes are stopped first.
+func (m *processManager) scale(g *processGroup, replicas int) ([]Processor, error) {
+	var started []Processor
+	for index := 0; len(g.members) < replicas; index++ {
+		if _, ok := g.members[index]; ok {
+			continue
+		}
+
+		process, err := NewProcess(g.req, g.id, index)
+		if err != nil {
+			return nil, common.Error(err, "failed to create replica %d of %s", index, g.id)
+		}
+
+		g.members[index] = process
+		m.processList[process.ID()] = process
+		started = append(started, process)
+		go m.run(process)
+	}
+
+	if len(g.members) > replicas {
+		list := g.list()
+		for i := len(list) - 1; i >= replicas; i-- {
+			m.remove(list[i])
+			list[i].Close()
+		}
+	}
+
+	return started, nil
+}
+
+// waitStarted gives the started processes a moment to get their PID (must be called without the lock held).
+func waitStarted(started []Processor) {
+	for _, process := range started {
+		for i := 0; i < 10 && process.PID() == -1; i++ {
+			time.Sleep(5 * time.Millisecond)
 		}
 	}
 }
 
-func (p *processManager) set(id string, process Process) {
-	p.Lock()
-	defer p.Unlock()
+// run loops the process until it is closed, or until it finishes (for jobs) and has been kept for the job TTL.
+//
+// Other processes that stop looping (such as a service that gave up on its requirements) are kept until stopped.
+func (m *processManager) run(process Processor) {
+	process.Loop()
+
+	m.RLock()
+	g, ok := m.groups[process.Group()]
+	job := ok && g.req.GetKind() == cynosure.StartRequest_Job
+	m.RUnlock()
 
-	p.processList[id] = process
+	if job && process.State() != cynosure.Process_Running {
+		time.AfterFunc(finishedTTL(), func() {
+			m.Stop(process.ID())
+		})
+	}
+}
+
+// remove forgets the process (and removes it from its group, unless it has already been replaced there).
+func (m *processManager) remove(process Processor) {
+	delete(m.processList, process.ID())
+	if g, ok := m.groups[process.Group()]; ok && g.members[process.Index()] == process {
+		delete(g.members, process.Index())
+	}
+}
+
+func (g *processGroup) list() []Processor {
+	list := make([]Processor, 0, len(g.members))
+	for _, process := range g.members {
+		list = append(list, process)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Index() < list[j].Index()
+	})
+	return list
 }
-*/
@@ -0,0 +1,308 @@
+package process
+
+import (
+	"testing"
+	"time"
+
+	"github.com/norganna/cynosure/proto/cynosure"
+)
+
+// sleeper returns a request for replicas of a long running command, identified by its argument.
+func sleeper(arg string, replicas int32) *cynosure.StartRequest {
+	return &cynosure.StartRequest{
+		Namespace: "test",
+		Replicas:  replicas,
+		Command: &cynosure.Command{
+			Name:  "sleeper",
+			Entry: "/bin/sleep",
+			Args:  []string{arg},
+		},
+	}
+}
+
+// neverReady returns a request for a command that is gated on output it never writes.
+func neverReady() *cynosure.StartRequest {
+	req := sleeper("60", 1)
+	req.Watches = map[string]*cynosure.Watch{
+		"ready": {Match: "never printed", State: cynosure.Watch_MakeReady},
+	}
+	return req
+}
+
+func indexes(list []Processor) []int {
+	out := make([]int, len(list))
+	for i, p := range list {
+		out[i] = p.Index()
+	}
+	return out
+}
+
+func sameInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func argsOf(p Processor) string {
+	args := p.Process().GetCommand().GetArgs()
+	return args[len(args)-1]
+}
+
+func TestScale(t *testing.T) {
+	m := NewProcessManager()
+	defer m.Quit()
+
+	group, list, err := m.Start(sleeper("60", 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sameInts(indexes(list), []int{0, 1, 2}) {
+		t.Fatalf("started indexes %v", indexes(list))
+	}
+
+	tests := []struct {
+		replicas int
+		want     []int
+	}{
+		{1, []int{0}},
+		{2, []int{0, 1}},
+		{0, []int{}},
+		{2, []int{0, 1}},
+	}
+	for _, tt := range tests {
+		list, err := m.Scale(group, tt.replicas)
+		if err != nil {
+			t.Fatalf("scale to %d: %v", tt.replicas, err)
+		}
+		if got := indexes(list); !sameInts(got, tt.want) {
+			t.Errorf("scale to %d: got indexes %v, want %v", tt.replicas, got, tt.want)
+		}
+		if got := len(m.List(nil)); got != len(tt.want) {
+			t.Errorf("scale to %d: %d processes listed", tt.replicas, got)
+		}
+	}
+
+	if _, err := m.Scale(group, -1); err != ErrBadReplicas {
+		t.Errorf("negative scale: got %v", err)
+	}
+	if _, err := m.Scale("unknown", 1); err != ErrUnknownGroup {
+		t.Errorf("unknown group: got %v", err)
+	}
+}
+
+func TestStop(t *testing.T) {
+	m := NewProcessManager()
+	defer m.Quit()
+
+	group, list, err := m.Start(sleeper("60", 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !m.Stop(list[0].ID()) {
+		t.Fatal("failed to stop first replica")
+	}
+	if m.Stop(list[0].ID()) {
+		t.Error("stopped the first replica twice")
+	}
+	// Closing an already stopped process (as a replacement may do) is harmless.
+	list[0].Close()
+	if got := indexes(m.Group(group)); !sameInts(got, []int{1}) {
+		t.Errorf("got indexes %v after stop", got)
+	}
+
+	if !m.Stop(list[1].ID()) {
+		t.Fatal("failed to stop last replica")
+	}
+	if m.Group(group) != nil {
+		t.Error("group kept after its last member was stopped")
+	}
+}
+
+func TestReplace(t *testing.T) {
+	m := NewProcessManager()
+	defer m.Quit()
+
+	group, old, err := m.Start(sleeper("60", 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, list, err := m.Replace(group, sleeper("61", 0), 5*time.Second, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sameInts(indexes(list), []int{0, 1}) {
+		t.Fatalf("replaced indexes %v", indexes(list))
+	}
+	for i, p := range list {
+		if p.ID() == old[i].ID() {
+			t.Errorf("replica %d was not replaced", i)
+		}
+		if m.Get(old[i].ID()) != nil {
+			t.Errorf("old replica %d is still running", i)
+		}
+		if got := argsOf(p); got != "61" {
+			t.Errorf("replica %d runs %s", i, got)
+		}
+	}
+
+	// Later replicas use the replaced specification.
+	list, err = m.Scale(group, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := argsOf(list[2]); got != "61" {
+		t.Errorf("scaled replica runs %s", got)
+	}
+}
+
+func TestReplaceMember(t *testing.T) {
+	m := NewProcessManager()
+	defer m.Quit()
+
+	group, old, err := m.Start(sleeper("60", 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, list, err := m.Replace(old[1].ID(), sleeper("61", 0), 5*time.Second, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list[0].ID() != old[0].ID() {
+		t.Error("replica 0 should not have been replaced")
+	}
+	if got := argsOf(list[1]); got != "61" {
+		t.Errorf("replica 1 runs %s", got)
+	}
+
+	// Replacing a single member leaves the group's specification unchanged.
+	list, err = m.Scale(group, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := argsOf(list[2]); got != "60" {
+		t.Errorf("scaled replica runs %s", got)
+	}
+}
+
+func TestReplaceNotReady(t *testing.T) {
+	m := NewProcessManager()
+	defer m.Quit()
+
+	group, old, err := m.Start(sleeper("60", 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, list, err := m.Replace(group, neverReady(), 300*time.Millisecond, 1)
+	if err != ErrNotReady {
+		t.Fatalf("got %v, want ErrNotReady", err)
+	}
+	if len(list) != 1 || list[0].ID() != old[0].ID() {
+		t.Error("old process was not kept")
+	}
+	if got := len(m.List(nil)); got != 1 {
+		t.Errorf("%d processes listed, the replacement should have been removed", got)
+	}
+}
+
+// replaceInBackground starts a replacement that will not become ready, and waits until it is in progress.
+func replaceInBackground(t *testing.T, m ProcessManager, target string) (done chan error) {
+	done = make(chan error, 1)
+	before := len(m.List(nil))
+	go func() {
+		_, _, err := m.Replace(target, neverReady(), 2*time.Second, 1)
+		done <- err
+	}()
+
+	for i := 0; len(m.List(nil)) == before; i++ {
+		if i > 100 {
+			t.Fatal("replacement did not start")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return done
+}
+
+func TestReplaceInterleaved(t *testing.T) {
+	t.Run("scale", func(t *testing.T) {
+		m := NewProcessManager()
+		defer m.Quit()
+
+		group, _, err := m.Start(sleeper("60", 1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		done := replaceInBackground(t, m, group)
+
+		if _, err := m.Scale(group, 3); err != ErrReplacing {
+			t.Errorf("scale during replace: got %v, want ErrReplacing", err)
+		}
+		if _, _, err := m.Replace(group, sleeper("61", 0), time.Second, 1); err != ErrReplacing {
+			t.Errorf("replace during replace: got %v, want ErrReplacing", err)
+		}
+		if err := <-done; err != ErrNotReady {
+			t.Errorf("got %v, want ErrNotReady", err)
+		}
+	})
+
+	t.Run("stop old", func(t *testing.T) {
+		m := NewProcessManager()
+		defer m.Quit()
+
+		group, old, err := m.Start(sleeper("60", 1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		done := replaceInBackground(t, m, group)
+
+		if !m.Stop(old[0].ID()) {
+			t.Fatal("failed to stop the old process")
+		}
+		if err := <-done; err != ErrNotReady {
+			t.Errorf("got %v, want ErrNotReady", err)
+		}
+		if m.Group(group) != nil {
+			t.Error("group kept after its only member was stopped")
+		}
+		if got := len(m.List(nil)); got != 0 {
+			t.Errorf("%d processes listed", got)
+		}
+	})
+
+	t.Run("stop fresh", func(t *testing.T) {
+		m := NewProcessManager()
+		defer m.Quit()
+
+		group, old, err := m.Start(sleeper("60", 1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		done := replaceInBackground(t, m, group)
+
+		for _, p := range m.List(nil) {
+			if p.ID() != old[0].ID() {
+				m.Stop(p.ID())
+			}
+		}
+		select {
+		case err := <-done:
+			if err != ErrNotReady {
+				t.Errorf("got %v, want ErrNotReady", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("replacement kept waiting for a stopped process")
+		}
+		if got := m.Group(group); len(got) != 1 || got[0].ID() != old[0].ID() {
+			t.Error("old process was removed from the group")
+		}
+	})
+}
@@ -0,0 +1,428 @@
+package process
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/norganna/cynosure/common"
+	psProcess "github.com/shirou/gopsutil/process"
+)
+
+// Event reasons for the notify socket.
+const (
+	EventNotifyReady     = "NotifyReady"
+	EventWatchdogTimeout = "WatchdogTimeout"
+)
+
+// Standard error messages.
+var (
+	ErrNotifyPath = common.ErrorMsg("notify socket path is too long")
+)
+
+// maxSocketPath is the longest path a unix socket can be bound to (`sun_path` is 108 bytes, including the NUL).
+const maxSocketPath = 107
+
+// maxAncestors is how far up the process tree a notifying process is looked for within the command's tree.
+const maxAncestors = 32
+
+// orphanGrace is how long processes left running by a command that exited have to notify a main PID.
+const orphanGrace = 5 * time.Second
+
+var rootLock sync.RWMutex
+var instanceRoot = os.TempDir()
+var imageRoot = os.TempDir()
+
+// SetRoot sets the server root folder, within which each process has an instance folder, images are stored and the
+// revision history is saved.
+func SetRoot(root string) {
+	rootLock.Lock()
+	defer rootLock.Unlock()
+
+	instanceRoot = path.Join(root, "instances")
+	imageRoot = path.Join(root, "images")
+	setHistoryRoot(path.Join(root, "data", "history"))
+}
+
+// instanceDir returns the instance folder of the process (creating it if required).
+func (p *proc) instanceDir() (string, error) {
+	rootLock.RLock()
+	dir := path.Join(instanceRoot, p.identity)
+	rootLock.RUnlock()
+
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return "", common.Error(err, "failed to create instance folder %s", dir)
+	}
+	return dir, nil
+}
+
+// openNotify creates the notify socket for the process and starts reading messages from it.
+//
+// For a process run within an image, the socket is also hard-linked into the image root at the same path, so that
+// `NOTIFY_SOCKET` can be found from within the chroot.
+func (p *proc) openNotify() error {
+	dir, err := p.instanceDir()
+	if err != nil {
+		return err
+	}
+
+	addr := &net.UnixAddr{
+		Name: path.Join(dir, "notify.sock"),
+		Net:  "unixgram",
+	}
+	if len(addr.Name) > maxSocketPath {
+		return common.Error(ErrNotifyPath, "failed to create notify socket %s", addr.Name)
+	}
+	_ = os.Remove(addr.Name)
+
+	conn, err := net.ListenUnixgram("unixgram", addr)
+	if err != nil {
+		return common.Error(err, "failed to create notify socket %s", addr.Name)
+	}
+
+	// Have the kernel supply the credentials of the sender with each message.
+	err = passCredentials(conn)
+	if err != nil {
+		_ = conn.Close()
+		_ = os.Remove(addr.Name)
+		return common.Error(err, "failed to enable credentials on notify socket %s", addr.Name)
+	}
+
+	if image := p.c.GetImage(); image != "" {
+		p.notifyLink, err = linkNotify(image, addr.Name)
+		if err != nil {
+			_ = conn.Close()
+			_ = os.Remove(addr.Name)
+			return err
+		}
+	}
+
+	p.notifyConn = conn
+	p.notifyPath = addr.Name
+	p.watchdogCh = make(chan bool, 1)
+	go p.readNotify(conn)
+	return nil
+}
+
+// closeNotify closes and removes the notify socket.
+func (p *proc) closeNotify() {
+	if p.notifyConn == nil {
+		return
+	}
+
+	_ = p.notifyConn.Close()
+	_ = os.Remove(p.notifyPath)
+	if p.notifyLink != "" {
+		_ = os.Remove(p.notifyLink)
+	}
+}
+
+// linkNotify hard-links the notify socket into the root of the image at the same path, returning the path of the link.
+func linkNotify(image, socket string) (string, error) {
+	root, err := ImageRoot(image)
+	if err != nil {
+		return "", err
+	}
+
+	link := path.Join(root, socket)
+	err = os.MkdirAll(path.Dir(link), 0755)
+	if err != nil {
+		return "", common.Error(err, "failed to create notify socket folder in image %s", image)
+	}
+	_ = os.Remove(link)
+
+	err = os.Link(socket, link)
+	if err != nil {
+		return "", common.Error(err, "failed to link notify socket into image %s", image)
+	}
+	return link, nil
+}
+
+// notifyEnv returns the environment values that tell the process about the notify socket.
+func (p *proc) notifyEnv() []string {
+	if p.notifyConn == nil {
+		return nil
+	}
+
+	env := []string{"NOTIFY_SOCKET=" + p.notifyPath}
+	if ms := p.c.GetWatchdog(); ms > 0 {
+		env = append(env, fmt.Sprintf("WATCHDOG_USEC=%d", ms*1000))
+	}
+	return env
+}
+
+// resetNotify clears the notified state before the command is (re)started.
+func (p *proc) resetNotify() {
+	p.Lock()
+	defer p.Unlock()
+
+	p.notifyReady = false
+	p.notifyStatus = ""
+	p.mainPID = 0
+}
+
+// passCredentials enables `SO_PASSCRED` on the socket.
+func passCredentials(conn *net.UnixConn) error {
+	raw, err := conn.SyscallConn()
+	if err != nil {
+		return err
+	}
+
+	var sErr error
+	err = raw.Control(func(fd uintptr) {
+		sErr = syscall.SetsockoptInt(int(fd), syscall.SOL_SOCKET, syscall.SO_PASSCRED, 1)
+	})
+	if err != nil {
+		return err
+	}
+	return sErr
+}
+
+// readNotify reads messages from the notify socket, ignoring any that weren't sent from within the command's tree.
+func (p *proc) readNotify(conn *net.UnixConn) {
+	buf := make([]byte, 4096)
+	oob := make([]byte, syscall.CmsgSpace(syscall.SizeofUcred))
+	for {
+		n, oobn, _, _, err := conn.ReadMsgUnix(buf, oob)
+		if err != nil {
+			return
+		}
+
+		pid := senderPID(oob[:oobn])
+		if !p.fromCommand(pid) {
+			_, _ = p.Log().Err().Write([]byte(fmt.Sprintf("Ignored notify message from pid %d outside the process\n", pid)))
+			continue
+		}
+
+		for _, line := range strings.Split(string(buf[:n]), "\n") {
+			p.notified(line)
+		}
+	}
+}
+
+// senderPID returns the PID in the credentials of a message (or 0 if there are none).
+func senderPID(oob []byte) int {
+	msgs, err := syscall.ParseSocketControlMessage(oob)
+	if err != nil {
+		return 0
+	}
+
+	for i := range msgs {
+		if cred, err := syscall.ParseUnixCredentials(&msgs[i]); err == nil {
+			return int(cred.Pid)
+		}
+	}
+	return 0
+}
+
+// fromCommand returns whether the PID is within the running command's process tree (or is its notified main PID).
+func (p *proc) fromCommand(pid int) bool {
+	if pid < 1 {
+		return false
+	}
+
+	p.RLock()
+	main := p.mainPID
+	p.RUnlock()
+	if pid == main {
+		return true
+	}
+
+	return p.inTree(pid)
+}
+
+// inTree returns whether the PID is within the command's process tree.
+//
+// This includes processes orphaned within the tree (such as a daemon whose parent exited after forking), which the
+// server adopts as the child subreaper (see SetSubreaper), and which are identified by the notify socket they inherited.
+func (p *proc) inTree(pid int) bool {
+	if descendant(pid, p.commandPID()) {
+		return true
+	}
+
+	top := adopted(pid)
+	return top > 0 && p.notifyPath != "" && procEnv(top, "NOTIFY_SOCKET="+p.notifyPath)
+}
+
+// descendant returns whether the PID is the root PID, or one of its descendants.
+func descendant(pid, root int) bool {
+	if root < 1 || pid <= 1 || pid == os.Getpid() {
+		return false
+	}
+
+	for i := 0; i < maxAncestors && pid > 1; i++ {
+		if pid == root {
+			return true
+		}
+
+		ps, err := psProcess.NewProcess(int32(pid))
+		if err != nil {
+			return false
+		}
+		ppid, err := ps.Ppid()
+		if err != nil {
+			return false
+		}
+		pid = int(ppid)
+	}
+	return false
+}
+
+// adopted returns the ancestor of the PID (or the PID itself) that is a child of the server, or 0 if there is none.
+func adopted(pid int) int {
+	self := os.Getpid()
+	if pid <= 1 || pid == self {
+		return 0
+	}
+
+	for i := 0; i < maxAncestors && pid > 1; i++ {
+		ps, err := psProcess.NewProcess(int32(pid))
+		if err != nil {
+			return 0
+		}
+		ppid, err := ps.Ppid()
+		if err != nil {
+			return 0
+		}
+		if int(ppid) == self {
+			return pid
+		}
+		pid = int(ppid)
+	}
+	return 0
+}
+
+// notified handles a single `KEY=VALUE` message from the notify socket (that came from within the command's tree).
+func (p *proc) notified(line string) {
+	parts := strings.SplitN(line, "=", 2)
+	if len(parts) != 2 {
+		return
+	}
+	key, value := parts[0], parts[1]
+
+	switch key {
+	case "READY":
+		if value == "1" {
+			p.Lock()
+			was := p.notifyReady
+			p.notifyReady = true
+			p.Unlock()
+
+			if !was {
+				p.event(EventNotifyReady, "process notified that it is ready")
+			}
+		}
+	case "STOPPING":
+		if value == "1" {
+			p.Lock()
+			p.notifyReady = false
+			p.Unlock()
+		}
+	case "STATUS":
+		p.Lock()
+		p.notifyStatus = value
+		p.Unlock()
+	case "MAINPID":
+		// The main PID is signalled when stopping, so it must be part of the command.
+		pid, err := strconv.Atoi(value)
+		if err != nil || !p.inTree(pid) {
+			_, _ = p.Log().Err().Write([]byte("Ignored MAINPID=" + value + " that is not part of the process\n"))
+			return
+		}
+
+		p.Lock()
+		p.mainPID = pid
+		p.Unlock()
+	case "WATCHDOG":
+		if value == "1" {
+			select {
+			case p.watchdogCh <- true:
+			default:
+			}
+		}
+	}
+}
+
+// notifiedPID returns the `MAINPID=` that the process notified (if it is still alive).
+func (p *proc) notifiedPID() int {
+	p.RLock()
+	pid := p.mainPID
+	p.RUnlock()
+
+	if pid > 0 && syscall.Kill(pid, 0) == nil {
+		return pid
+	}
+	return 0
+}
+
+// waitMainPID waits for a notified main PID that outlived the command to exit.
+//
+// If the command exited without notifying a main PID, but left processes from its tree running, they are given
+// orphanGrace to notify one (as a daemon whose parent exits after forking does).
+func (p *proc) waitMainPID() {
+	grace := time.Now().Add(orphanGrace)
+	for {
+		p.RLock()
+		main := p.mainPID
+		p.RUnlock()
+
+		if main == 0 && time.Now().Before(grace) && p.orphaned() {
+			time.Sleep(readyPoll)
+			continue
+		}
+		if p.notifiedPID() > 0 {
+			time.Sleep(readyPoll)
+			continue
+		}
+		return
+	}
+}
+
+// orphaned returns whether any processes from the command's tree were adopted by the server and are still running.
+func (p *proc) orphaned() bool {
+	if p.notifyPath == "" {
+		return false
+	}
+	for _, pid := range adoptedPIDs() {
+		if procEnv(pid, "NOTIFY_SOCKET="+p.notifyPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// watchdog restarts the process (through the stop path) if it stops sending `WATCHDOG=1` within the interval.
+func (p *proc) watchdog(done chan bool) {
+	ms := p.c.GetWatchdog()
+	if p.notifyConn == nil || ms <= 0 {
+		return
+	}
+
+	// Discard any ping left over from a previous run.
+	select {
+	case <-p.watchdogCh:
+	default:
+	}
+
+	interval := time.Duration(ms) * time.Millisecond
+	for {
+		select {
+		case <-done:
+			return
+		case <-p.watchdogCh:
+		case <-time.After(interval):
+			msg := fmt.Sprintf("no watchdog notification within %s, restarting", interval)
+			p.event(EventWatchdogTimeout, msg)
+			_, _ = p.Log().Err().Write([]byte("Watchdog timeout: " + msg + "\n"))
+			p.stop()
+			return
+		}
+	}
+}
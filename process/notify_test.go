@@ -0,0 +1,246 @@
+package process
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"path"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/norganna/cynosure/proto/cynosure"
+)
+
+// notifier returns a request for a long running command with a notify socket.
+func notifier() *cynosure.StartRequest {
+	req := sleeper("60", 1)
+	req.Command.Notify = true
+	return req
+}
+
+func TestNotifySender(t *testing.T) {
+	process, err := NewProcess(notifier(), "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer process.Close()
+	go process.Loop()
+
+	p := process.(*proc)
+	time.Sleep(150 * time.Millisecond)
+	child := p.commandPID()
+	if child < 1 {
+		t.Fatal("process did not start")
+	}
+
+	// Messages from outside the process tree are ignored.
+	conn, err := net.Dial("unixgram", p.notifyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = conn.Write([]byte("READY=1\nSTATUS=spoofed"))
+	_ = conn.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	p.RLock()
+	ready, status := p.notifyReady, p.notifyStatus
+	p.RUnlock()
+	if ready || status != "" {
+		t.Errorf("message from outside the process was accepted (ready %v, status %q)", ready, status)
+	}
+
+	tests := []struct {
+		name string
+		pid  int
+		from bool
+	}{
+		{"command", child, true},
+		{"server", os.Getpid(), false},
+		{"init", 1, false},
+		{"invalid", -1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if from := p.fromCommand(tt.pid); from != tt.from {
+				t.Errorf("got from command %v, want %v", from, tt.from)
+			}
+
+			p.Lock()
+			p.mainPID = 0
+			p.Unlock()
+
+			p.notified("MAINPID=" + strconv.Itoa(tt.pid))
+			if accepted := p.notifiedPID() == tt.pid; accepted != tt.from {
+				t.Errorf("got MAINPID accepted %v, want %v", accepted, tt.from)
+			}
+		})
+	}
+}
+
+func TestNotifyPathLength(t *testing.T) {
+	root := "/tmp/" + strings.Repeat("r", 100)
+	defer func() {
+		_ = os.RemoveAll(root)
+		rootLock.Lock()
+		instanceRoot = os.TempDir()
+		rootLock.Unlock()
+	}()
+
+	rootLock.Lock()
+	instanceRoot = root
+	rootLock.Unlock()
+
+	_, err := NewProcess(notifier(), "", 0)
+	if err == nil || !strings.Contains(err.Error(), ErrNotifyPath.Error()) {
+		t.Errorf("got error %v, want %v", err, ErrNotifyPath)
+	}
+}
+
+func TestNotifyImage(t *testing.T) {
+	root, err := ioutil.TempDir("", "images")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = os.RemoveAll(root)
+		rootLock.Lock()
+		imageRoot = os.TempDir()
+		rootLock.Unlock()
+	}()
+
+	rootLock.Lock()
+	imageRoot = root
+	rootLock.Unlock()
+
+	if err := os.MkdirAll(path.Join(root, "base", "latest"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	req := notifier()
+	req.Command.Image = "base"
+	process, err := NewProcess(req, "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := process.(*proc)
+
+	// The socket is found at the same path from within the image.
+	link := path.Join(root, "base", "latest", p.notifyPath)
+	socket, err := os.Stat(p.notifyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	linked, err := os.Stat(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !os.SameFile(socket, linked) || linked.Mode()&os.ModeSocket == 0 {
+		t.Errorf("got %s linked to %v", link, linked.Mode())
+	}
+
+	process.Close()
+	if _, err := os.Stat(link); !os.IsNotExist(err) {
+		t.Errorf("link was not removed: %v", err)
+	}
+
+	// The image must exist.
+	req.Command.Image = "missing"
+	_, err = NewProcess(req, "", 0)
+	if err == nil || !strings.Contains(err.Error(), ErrImageNotFound.Error()) {
+		t.Errorf("got error %v, want %v", err, ErrImageNotFound)
+	}
+}
+
+// TestNotifyDaemon is run as the command of TestNotifyForked, rather than as a test.
+func TestNotifyDaemon(t *testing.T) {
+	switch daemon := os.Getenv("NOTIFY_DAEMON"); daemon {
+	case "":
+		return
+	case "fork":
+		// Start the daemon, and exit without waiting for it.
+		exe, err := os.Executable()
+		if err != nil {
+			os.Exit(1)
+		}
+		cmd := exec.Command(exe, os.Args[1:]...)
+		cmd.Env = append(os.Environ(), "NOTIFY_DAEMON="+strconv.Itoa(os.Getpid()))
+		if err := cmd.Start(); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	default:
+		// Notify once the parent (whose PID is given) has exited.
+		parent, _ := strconv.Atoi(daemon)
+		for i := 0; i < 50 && os.Getppid() == parent; i++ {
+			time.Sleep(100 * time.Millisecond)
+		}
+		conn, err := net.Dial("unixgram", os.Getenv("NOTIFY_SOCKET"))
+		if err != nil {
+			os.Exit(1)
+		}
+		_, _ = conn.Write([]byte("MAINPID=" + strconv.Itoa(os.Getpid()) + "\nREADY=1"))
+		_ = conn.Close()
+		time.Sleep(60 * time.Second)
+		os.Exit(0)
+	}
+}
+
+func TestNotifyForked(t *testing.T) {
+	if err := SetSubreaper(); err != nil {
+		t.Fatal(err)
+	}
+
+	req := notifier()
+	req.Command.Entry = os.Args[0]
+	req.Command.Args = []string{"-test.run=^TestNotifyDaemon$"}
+	req.Command.Env = []string{"NOTIFY_DAEMON=fork"}
+
+	process, err := NewProcess(req, "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer process.Close()
+	go process.Loop()
+
+	p := process.(*proc)
+	var daemon int
+	for i := 0; i < 30 && daemon == 0; i++ {
+		time.Sleep(100 * time.Millisecond)
+		daemon = p.notifiedPID()
+	}
+	if daemon == 0 {
+		t.Fatal("main PID from the forked daemon was not accepted")
+	}
+	defer func() {
+		_ = syscall.Kill(daemon, syscall.SIGKILL)
+	}()
+
+	// The daemon's parent exited, so it was adopted by the server.
+	if state, ppid := procStat(daemon); ppid != os.Getpid() {
+		t.Errorf("daemon %d (%c) has parent %d", daemon, state, ppid)
+	}
+	p.RLock()
+	ready := p.notifyReady
+	p.RUnlock()
+	if !ready || p.PID() != daemon {
+		t.Errorf("got ready %v with PID %d, want %d", ready, p.PID(), daemon)
+	}
+
+	// Once it exits, the daemon is reaped.
+	if err := syscall.Kill(daemon, syscall.SIGTERM); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 30 && syscall.Kill(daemon, 0) == nil; i++ {
+		time.Sleep(100 * time.Millisecond)
+	}
+	if err := syscall.Kill(daemon, 0); err == nil {
+		t.Errorf("daemon %d was not reaped", daemon)
+	}
+}
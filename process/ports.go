@@ -0,0 +1,233 @@
+package process
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/norganna/cynosure/common"
+)
+
+// Standard error messages.
+var (
+	ErrNoPortRange = common.ErrorMsg("no port range has been configured for allocation")
+	ErrNoFreePorts = common.ErrorMsg("no free ports remain in the configured range")
+)
+
+var rePortRef = regexp.MustCompile(`\$\{(PORT_[A-Z0-9_]+)\}|\$(PORT_[A-Z0-9_]+)`)
+
+type portAllocator struct {
+	sync.Mutex
+
+	min  int
+	max  int
+	next int
+
+	// used[port][owner identity] = true
+	used map[int]map[string]bool
+
+	// kept[slot][name] = port, for ports that are handed over to the next process in the slot
+	kept map[string]map[string]int
+}
+
+var allocator = &portAllocator{
+	used: map[int]map[string]bool{},
+	kept: map[string]map[string]int{},
+}
+
+// SetPortRange sets the range of ports (inclusive) that may be allocated to processes.
+func SetPortRange(min, max int) error {
+	if min < 1 || max > 65535 || min > max {
+		return common.ErrorMsg("invalid port range %d-%d", min, max)
+	}
+
+	allocator.Lock()
+	defer allocator.Unlock()
+
+	allocator.min = min
+	allocator.max = max
+	allocator.next = min
+	return nil
+}
+
+// Allocate reserves a free port for each of the names on behalf of the owner.
+//
+// Names that are kept reuse the port already held within the slot (if any), so that a replacement process is handed
+// the same port as the process it replaces.
+func (a *portAllocator) Allocate(owner, slot string, names []string, kept map[string]bool) (map[string]int32, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	a.Lock()
+	defer a.Unlock()
+
+	if a.min == 0 {
+		return nil, ErrNoPortRange
+	}
+
+	allocated := map[string]int32{}
+	for _, name := range names {
+		if _, ok := allocated[name]; ok {
+			continue
+		}
+
+		keep := slot != "" && kept[name]
+		if port, ok := a.kept[slot][name]; keep && ok {
+			a.used[port][owner] = true
+			allocated[name] = int32(port)
+			continue
+		}
+
+		port, err := a.find()
+		if err != nil {
+			a.release(owner)
+			return nil, common.Error(err, "failed to allocate port %s", name)
+		}
+
+		a.used[port] = map[string]bool{owner: true}
+		allocated[name] = int32(port)
+
+		if keep {
+			if a.kept[slot] == nil {
+				a.kept[slot] = map[string]int{}
+			}
+			a.kept[slot][name] = port
+		}
+	}
+
+	return allocated, nil
+}
+
+// Release returns all of the ports held by the owner (and no other owner) to the free range.
+func (a *portAllocator) Release(owner string) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.release(owner)
+}
+
+func (a *portAllocator) release(owner string) {
+	for port, owners := range a.used {
+		if !owners[owner] {
+			continue
+		}
+
+		delete(owners, owner)
+		if len(owners) > 0 {
+			continue
+		}
+
+		delete(a.used, port)
+		for slot, names := range a.kept {
+			for name, p := range names {
+				if p == port {
+					delete(names, name)
+				}
+			}
+			if len(names) == 0 {
+				delete(a.kept, slot)
+			}
+		}
+	}
+}
+
+func (a *portAllocator) find() (int, error) {
+	n := a.max - a.min + 1
+	for i := 0; i < n; i++ {
+		port := a.next
+		a.next++
+		if a.next > a.max {
+			a.next = a.min
+		}
+
+		if _, ok := a.used[port]; ok {
+			continue
+		}
+
+		if portFree(port) {
+			return port, nil
+		}
+	}
+	return 0, ErrNoFreePorts
+}
+
+func portFree(port int) bool {
+	l, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return false
+	}
+	_ = l.Close()
+	return true
+}
+
+// portEnvName returns the environment variable name for a named port.
+func portEnvName(name string) string {
+	return "PORT_" + strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// portEnv returns the environment values for the allocated ports.
+func portEnv(allocated map[string]int32) []string {
+	var env []string
+	for name, port := range allocated {
+		env = append(env, fmt.Sprintf("%s=%d", portEnvName(name), port))
+	}
+	return env
+}
+
+// portRefs returns the names of the ports that are referenced by the values.
+func portRefs(names []string, values []string) map[string]bool {
+	refs := map[string]bool{}
+	for _, value := range values {
+		for _, m := range rePortRef.FindAllStringSubmatch(value, -1) {
+			key := m[1]
+			if key == "" {
+				key = m[2]
+			}
+			for _, name := range names {
+				if portEnvName(name) == key {
+					refs[name] = true
+				}
+			}
+		}
+	}
+	return refs
+}
+
+// expandPorts replaces any `$PORT_NAME` or `${PORT_NAME}` references to allocated ports within the args.
+func expandPorts(args []string, allocated map[string]int32) []string {
+	if len(allocated) == 0 {
+		return args
+	}
+
+	values := map[string]string{}
+	for name, port := range allocated {
+		values[portEnvName(name)] = fmt.Sprintf("%d", port)
+	}
+
+	expanded := make([]string, len(args))
+	for i, arg := range args {
+		expanded[i] = rePortRef.ReplaceAllStringFunc(arg, func(ref string) string {
+			m := rePortRef.FindStringSubmatch(ref)
+			key := m[1]
+			if key == "" {
+				key = m[2]
+			}
+			if v, ok := values[key]; ok {
+				return v
+			}
+			return ref
+		})
+	}
+	return expanded
+}
@@ -0,0 +1,284 @@
+package process
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os/exec"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/norganna/cynosure/common"
+	"github.com/norganna/cynosure/deps"
+	"github.com/norganna/cynosure/pipes"
+	"github.com/norganna/cynosure/proto/cynosure"
+)
+
+// ProbeClass is the log class that probe failures are recorded under.
+const ProbeClass = "probe"
+
+// Probe defaults.
+const (
+	defaultProbeInterval  = 10 * time.Second
+	defaultProbeTimeout   = time.Second
+	defaultProbeThreshold = 3
+)
+
+// Standard error messages.
+var (
+	ErrNoProbeCheck  = common.ErrorMsg("probe has neither a dep nor an exec command")
+	ErrUnknownBroker = common.ErrorMsg("unknown broker identity")
+	ErrProbeTimedOut = common.ErrorMsg("probe timed out")
+)
+
+// probeDep returns a Depender that performs a single check of the probe.
+func (p *proc) probeDep(probe *cynosure.Probe) (deps.Depender, error) {
+	if exec := probe.GetExec(); len(exec) > 0 {
+		return &execDep{p: p, entry: exec[0], args: exec[1:], timeout: probeTimeout(probe)}, nil
+	}
+
+	d := probe.GetDep()
+	if d == nil {
+		return nil, ErrNoProbeCheck
+	}
+
+	b := deps.Instance(d.GetIdentity(), p.namespace)
+	if b == nil {
+		return nil, common.Error(ErrUnknownBroker, "failed to find broker %s", d.GetIdentity())
+	}
+	return b.Dep(expandPorts([]string{d.GetWait()}, p.ports)[0])
+}
+
+// watchProbe checks the probe at its interval until done is closed, keeping the status up to date.
+//
+// Each time the probe starts or stops passing, transition is called, and probing stops if it returns false.
+func (p *proc) watchProbe(name string, probe *cynosure.Probe, status *cynosure.ProbeStatus, done chan bool, transition func(passing bool) bool) {
+	w := pipes.Default.Out()
+	if logging := p.Log(); logging != nil {
+		w = logging.Writer(ProbeClass)
+	}
+
+	dep, err := p.probeDep(probe)
+	if err != nil {
+		_, _ = w.Write([]byte(fmt.Sprintf("%s probe disabled: %s\n", name, err)))
+		return
+	}
+
+	interval := defaultProbeInterval
+	if ms := probe.GetInterval(); ms > 0 {
+		interval = time.Duration(ms) * time.Millisecond
+	}
+
+	check := &probeCheck{
+		dep:     dep,
+		timeout: probeTimeout(probe),
+	}
+
+	threshold := int32(defaultProbeThreshold)
+	if n := probe.GetFailureThreshold(); n > 0 {
+		threshold = n
+	}
+
+	wait := time.Duration(probe.GetInitialDelay()) * time.Millisecond
+	for {
+		select {
+		case <-done:
+			return
+		case <-time.After(wait):
+		}
+		wait = interval
+
+		msg, ok := check.check()
+
+		p.Lock()
+		was := status.Passing
+		status.Checked = time.Now().UnixNano() / int64(time.Millisecond)
+		status.Message = msg
+		if ok {
+			status.Failures = 0
+			status.Passing = true
+		} else {
+			status.Failures++
+			if status.Failures >= threshold {
+				status.Passing = false
+			}
+		}
+		passing, failures := status.Passing, status.Failures
+		p.Unlock()
+
+		if !ok {
+			_, _ = w.Write([]byte(fmt.Sprintf("%s probe failed (%d/%d): %s\n", name, failures, threshold, msg)))
+		}
+
+		if passing != was && !transition(passing) {
+			return
+		}
+	}
+}
+
+// probeTimeout returns how long each check of the probe has to complete.
+func probeTimeout(probe *cynosure.Probe) time.Duration {
+	if ms := probe.GetTimeout(); ms > 0 {
+		return time.Duration(ms) * time.Millisecond
+	}
+	return defaultProbeTimeout
+}
+
+type checkResult struct {
+	msg string
+	ok  bool
+}
+
+// probeCheck checks a dep with a timeout, keeping at most one check running at a time.
+type probeCheck struct {
+	dep     deps.Depender
+	timeout time.Duration
+
+	// running is the check that was still running when it last timed out (deps can't be cancelled).
+	running chan checkResult
+}
+
+// check checks the dep, failing if it doesn't complete within the timeout.
+//
+// If the previous check timed out and is still running, it is waited on again instead of starting another, so a hung
+// dep can't pile up checks.
+func (c *probeCheck) check() (string, bool) {
+	ch := c.running
+	if ch == nil {
+		ch = make(chan checkResult, 1)
+		go func() {
+			msg, ok := c.dep.Check()
+			ch <- checkResult{msg, ok}
+		}()
+	}
+
+	select {
+	case r := <-ch:
+		c.running = nil
+		return r.msg, r.ok
+	case <-time.After(c.timeout):
+		c.running = ch
+		return ErrProbeTimedOut.Error(), false
+	}
+}
+
+// probeLiveness restarts the process (through the stop path) if its liveness probe fails.
+func (p *proc) probeLiveness(done chan bool) {
+	probe := p.c.GetLiveness()
+	if probe == nil {
+		return
+	}
+
+	p.Lock()
+	p.liveness.Passing = true
+	p.liveness.Failures = 0
+	p.Unlock()
+
+	p.watchProbe("Liveness", probe, p.liveness, done, func(passing bool) bool {
+		if passing {
+			return true
+		}
+
+		p.Lock()
+		p.liveness.Restarts++
+		p.Unlock()
+
+		_, _ = p.Log().Writer(ProbeClass).Write([]byte("Liveness probe failed, restarting\n"))
+		p.stop()
+		return false
+	})
+}
+
+// probeReadiness keeps the readiness status of the process up to date with its readiness probe.
+func (p *proc) probeReadiness(done chan bool) {
+	probe := p.c.GetReadiness()
+	if probe == nil {
+		return
+	}
+
+	p.watchProbe("Readiness", probe, p.readiness, done, func(passing bool) bool {
+		p.RLock()
+		msg := p.readiness.Message
+		p.RUnlock()
+
+		if passing {
+			p.event(EventReadinessPassed, msg)
+		} else {
+			p.event(EventReadinessFailed, msg)
+		}
+		return true
+	})
+}
+
+// probeStatus returns a copy of the probe status (or nil if there's no probe).
+func (p *proc) probeStatus(probe *cynosure.Probe, status *cynosure.ProbeStatus) *cynosure.ProbeStatus {
+	if probe == nil {
+		return nil
+	}
+
+	p.RLock()
+	defer p.RUnlock()
+
+	return proto.Clone(status).(*cynosure.ProbeStatus)
+}
+
+// execDep checks by running a command within the process instance.
+type execDep struct {
+	p       *proc
+	entry   string
+	args    []string
+	timeout time.Duration
+}
+
+var _ deps.Depender = (*execDep)(nil)
+
+func (d *execDep) Check() (string, bool) {
+	msg := "exec " + d.entry
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.timeout)
+	defer cancel()
+
+	var out strings.Builder
+	cmd := d.p.commandContext(ctx, d.entry, d.entry, d.args, d.p.c.GetEnv(), &out, ioutil.Discard)
+	err := RunGroup(ctx, cmd)
+	if ctx.Err() == context.DeadlineExceeded {
+		return msg + " " + ErrProbeTimedOut.Error(), false
+	}
+	if err != nil {
+		return msg + " " + err.Error(), false
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if last := lines[len(lines)-1]; last != "" {
+		return msg + " " + last, true
+	}
+	return msg + " passed", true
+}
+
+// RunGroup runs the command in its own process group, killing the whole group if the context is done first (so
+// children that keep the output open can't keep it running).
+func RunGroup(ctx context.Context, cmd *exec.Cmd) error {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+	err := startCmd(cmd)
+	if err != nil {
+		return err
+	}
+
+	exited := make(chan bool)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		case <-exited:
+		}
+	}()
+
+	err = waitCmd(cmd)
+	close(exited)
+	return err
+}
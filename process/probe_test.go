@@ -0,0 +1,189 @@
+package process
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/norganna/cynosure/proto/cynosure"
+)
+
+func TestExecProbe(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		ok     bool
+		msg    string
+	}{
+		{"passes", "echo starting; echo healthy", true, "exec /bin/sh healthy"},
+		{"passes silently", "true", true, "exec /bin/sh passed"},
+		{"fails", "exit 2", false, "exec /bin/sh exit status 2"},
+		{"hangs", "sleep 5", false, "exec /bin/sh probe timed out"},
+		{"hangs in a child", "sleep 5 & sleep 5", false, "exec /bin/sh probe timed out"},
+	}
+
+	p, err := NewProcess(sleeper("60", 1), "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dep, err := p.(*proc).probeDep(&cynosure.Probe{
+				Exec:    []string{"/bin/sh", "-c", tt.script},
+				Timeout: 200,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			start := time.Now()
+			msg, ok := dep.Check()
+			if elapsed := time.Now().Sub(start); elapsed > time.Second {
+				t.Errorf("check took %s", elapsed)
+			}
+			if ok != tt.ok || msg != tt.msg {
+				t.Errorf("got %v %q, want %v %q", ok, msg, tt.ok, tt.msg)
+			}
+		})
+	}
+}
+
+// hungDep never completes a check until it is released.
+type hungDep struct {
+	checks  int32
+	release chan bool
+}
+
+func (d *hungDep) Check() (string, bool) {
+	atomic.AddInt32(&d.checks, 1)
+	<-d.release
+	return "released", true
+}
+
+func TestProbeCheckHung(t *testing.T) {
+	dep := &hungDep{release: make(chan bool)}
+	check := &probeCheck{dep: dep, timeout: 20 * time.Millisecond}
+
+	for i := 0; i < 3; i++ {
+		if msg, ok := check.check(); ok || msg != ErrProbeTimedOut.Error() {
+			t.Errorf("check %d: got %v %q", i, ok, msg)
+		}
+	}
+	if n := atomic.LoadInt32(&dep.checks); n != 1 {
+		t.Errorf("%d checks were started while the first was hung", n)
+	}
+
+	// Once the hung check completes, its result is used and the next check starts afresh.
+	close(dep.release)
+	if msg, ok := check.check(); !ok || msg != "released" {
+		t.Errorf("got %v %q after release", ok, msg)
+	}
+	if _, ok := check.check(); !ok {
+		t.Error("next check failed")
+	}
+	if n := atomic.LoadInt32(&dep.checks); n != 2 {
+		t.Errorf("got %d checks, want 2", n)
+	}
+}
+
+func TestReadinessEvents(t *testing.T) {
+	dir, err := ioutil.TempDir("", "readiness")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	flag := path.Join(dir, "ready")
+
+	req := sleeper("60", 1)
+	req.Command.Readiness = &cynosure.Probe{
+		Exec:             []string{"/bin/test", "-e", flag},
+		Interval:         50,
+		Timeout:          500,
+		FailureThreshold: 1,
+	}
+	process, err := NewProcess(req, "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	looped := make(chan bool)
+	go func() {
+		process.Loop()
+		close(looped)
+	}()
+
+	// waitReady waits for the process to reach the ready state (and for it to be recorded).
+	waitReady := func(want bool) {
+		for i := 0; i < 40; i++ {
+			time.Sleep(50 * time.Millisecond)
+			if process.Ready() == want && process.Process().GetReadiness().GetPassing() == want {
+				time.Sleep(2 * readyPoll)
+				return
+			}
+		}
+		t.Fatalf("process did not become ready %v", want)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	if process.Ready() {
+		t.Fatal("process was ready before its probe passed")
+	}
+
+	for _, ready := range []bool{true, false, true} {
+		if ready {
+			err = ioutil.WriteFile(flag, nil, 0644)
+		} else {
+			err = os.Remove(flag)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		waitReady(ready)
+	}
+
+	process.Close()
+	select {
+	case <-looped:
+	case <-time.After(3 * time.Second):
+		t.Fatal("process did not stop")
+	}
+
+	// The exit is recorded once the ready state is next watched.
+	events := process.(*proc).Events()
+	for i := 0; i < 10 && events[len(events)-1].Reason != EventNotReady; i++ {
+		time.Sleep(readyPoll)
+		events = process.(*proc).Events()
+	}
+
+	// The probe and the ready state are recorded separately, as the probe changes ready state.
+	var probe, state []string
+	for _, e := range events {
+		switch e.Reason {
+		case EventReadinessPassed, EventReadinessFailed:
+			probe = append(probe, e.Reason)
+		case EventReady, EventNotReady:
+			state = append(state, e.Reason+": "+e.Message)
+		}
+	}
+
+	wantProbe := []string{EventReadinessPassed, EventReadinessFailed, EventReadinessPassed}
+	wantState := []string{
+		EventReady + ": process is ready",
+		EventNotReady + ": process is no longer ready",
+		EventReady + ": process is ready",
+		EventNotReady + ": process exited",
+	}
+	if strings.Join(probe, ",") != strings.Join(wantProbe, ",") {
+		t.Errorf("got probe events %v, want %v", probe, wantProbe)
+	}
+	if strings.Join(state, ",") != strings.Join(wantState, ",") {
+		t.Errorf("got ready events %v, want %v", state, wantState)
+	}
+}
@@ -1,11 +1,15 @@
 package process
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"net"
 	"os"
 	"os/exec"
 	"sort"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -21,27 +25,48 @@ import (
 // Processor allows lifecycle management of a process instance.
 type Processor interface {
 	Close()
+	Group() string
 	ID() string
+	Index() int
+	Labels() []*cynosure.KV
 	Loop()
+	Name() string
+	Namespace() string
 
 	Process() *cynosure.Process
 	Log() pipes.Logger
 	PID() int
+	Ready() bool
+	State() cynosure.Process_State
 }
 
 type proc struct {
+	sync.RWMutex
+
 	identity     string
 	namespace    string
+	group        string
+	index        int
+	labels       []*cynosure.KV
 	environments []string
 
-	ch chan bool
-	c  *cynosure.Command
+	ch        chan bool
+	closeOnce sync.Once
+	c         *cynosure.Command
+
+	job      *cynosure.Job
+	expired  chan bool
+	deadline *time.Timer
 
-	cmd   *exec.Cmd
-	deps  deps.DepList
-	pipes pipes.Piper
+	cmd     *exec.Cmd
+	cmdPID  int
+	initCmd *exec.Cmd
+	deps    deps.DepList
+	pipes   pipes.Piper
 
 	prevMsg string
+	ports   map[string]int32
+	gated   bool
 
 	inc        time.Duration
 	delay      time.Duration
@@ -50,30 +75,160 @@ type proc struct {
 	resetAfter time.Duration
 
 	started int64
+
+	state    cynosure.Process_State
+	attempts int32
+	exitCode int32
+	exitMsg  string
+	finished int64
+
+	hooks        []*cynosure.HookResult
+	hookPending  bool
+	hookNotReady bool
+	generation   int
+
+	liveness  *cynosure.ProbeStatus
+	readiness *cynosure.ProbeStatus
+	events    []*cynosure.Event
+
+	notifyConn   *net.UnixConn
+	notifyPath   string
+	notifyLink   string
+	notifyReady  bool
+	notifyStatus string
+	mainPID      int
+	watchdogCh   chan bool
+
+	sockets []*os.File
+
+	// lost[requirement] = true
+	lost map[string]bool
+
+	// waiting[requirement] = when it started failing
+	waiting      map[string]time.Time
+	waitingSince time.Time
+
+	// reqStatus[requirement] = last check
+	reqStatus map[string]*cynosure.RequirementStatus
 }
 
 var _ Processor = (*proc)(nil)
 
-// NewProcess creates a new Processor.
-func NewProcess(ns string, envs []string, c *cynosure.Command) Processor {
-	return &proc{
+// NewProcess creates a new Processor for the replica at index within the group.
+func NewProcess(req *cynosure.StartRequest, group string, index int) (Processor, error) {
+	c := req.GetCommand()
+	p := &proc{
 		identity:     c.GetName() + "-" + uuid.New("p"),
-		namespace:    ns,
-		environments: envs,
-
-		ch: make(chan bool),
-		c:  c,
+		namespace:    req.GetNamespace(),
+		group:        group,
+		index:        index,
+		labels:       req.GetLabels(),
+		environments: req.GetEnvironments(),
+
+		ch:    make(chan bool),
+		c:     c,
+		pipes: pipes.NewLogging(),
+
+		liveness:  &cynosure.ProbeStatus{},
+		readiness: &cynosure.ProbeStatus{},
+		lost:      map[string]bool{},
+		waiting:   map[string]time.Time{},
+		reqStatus: map[string]*cynosure.RequirementStatus{},
 
 		inc:        500 * time.Millisecond,
 		minDelay:   1 * time.Second,
 		maxDelay:   30 * time.Second,
 		resetAfter: 60 * time.Second,
 	}
+
+	if req.GetKind() == cynosure.StartRequest_Job {
+		p.job = req.GetJob()
+		if p.job == nil {
+			p.job = &cynosure.Job{}
+		}
+		p.expired = make(chan bool)
+	}
+
+	for name, watch := range req.GetWatches() {
+		p.pipes.AddWatch(name, watch)
+		if watch.GetState() == cynosure.Watch_MakeReady {
+			p.gated = true
+		}
+	}
+
+	// Build the requirements up front, so a badly formed requirement or expression is rejected when starting.
+	_, err := p.Deps()
+	if err != nil {
+		return nil, err
+	}
+
+	// Ports and sockets held by the server are handed over to processes that replace the one at the same index.
+	scope, slot := p.identity, ""
+	if group != "" {
+		scope, slot = group, fmt.Sprintf("%s/%d", group, index)
+	}
+
+	var addresses []string
+	for _, spec := range c.GetSockets() {
+		addresses = append(addresses, spec.GetAddress())
+	}
+
+	p.ports, err = allocator.Allocate(p.identity, slot, c.GetPorts(), portRefs(c.GetPorts(), addresses))
+	if err != nil {
+		return nil, err
+	}
+
+	p.sockets, err = sockets.Acquire(p.identity, scope, c.GetSockets(), p.ports)
+	if err != nil {
+		allocator.Release(p.identity)
+		return nil, err
+	}
+
+	if c.GetNotify() {
+		err = p.openNotify()
+		if err != nil {
+			allocator.Release(p.identity)
+			sockets.Release(p.identity)
+			return nil, err
+		}
+	}
+
+	return p, nil
 }
 
+// Close stops the process and releases its resources (it is safe to call more than once).
 func (p *proc) Close() {
-	close(p.ch)
+	p.closeOnce.Do(func() {
+		close(p.ch)
+		p.stopDeadline()
+		allocator.Release(p.identity)
+		p.stop()
+		p.closeNotify()
+		sockets.Release(p.identity)
+	})
+}
+
+// stop runs the pre-stop hook and then signals the running command to terminate.
+func (p *proc) stop() {
+	p.stopInit()
+
+	if p.PID() < 1 {
+		return
+	}
+
+	// The pre-stop hook may take a while, so don't hold up the caller.
+	if p.c.GetHooks().GetPreStop() != nil {
+		go func() {
+			p.preStop()
+			p.signal()
+		}()
+		return
+	}
+	p.signal()
+}
 
+// signal asks the running command to terminate (forcefully if it doesn't exit within 10 seconds).
+func (p *proc) signal() {
 	if pid := p.PID(); pid > 0 {
 		// Send it a soft kill notification.
 		err := syscall.Kill(pid, syscall.SIGINT)
@@ -101,11 +256,23 @@ func (p *proc) Cmd() *exec.Cmd {
 	}
 
 	c := p.c
+	env := append(append([]string(nil), c.GetEnv()...), p.notifyEnv()...)
+	cmd := p.command(c.GetName(), c.GetEntry(), c.GetArgs(), env, piper.Out(), piper.Err())
+	p.activate(cmd)
+	return cmd
+}
 
-	cmd := exec.Command(c.GetEntry(), c.GetArgs()...)
-	cmd.Args[0] = c.Name
-	cmd.Stdout = piper.Out()
-	cmd.Stderr = piper.Err()
+// command builds a command to run within the instance, with its environment and allocated ports.
+func (p *proc) command(name, entry string, args, extra []string, stdout, stderr io.Writer) *exec.Cmd {
+	return p.commandContext(context.Background(), name, entry, args, extra, stdout, stderr)
+}
+
+// commandContext builds a command like command, which is killed if the context is done before it completes.
+func (p *proc) commandContext(ctx context.Context, name, entry string, args, extra []string, stdout, stderr io.Writer) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, entry, expandPorts(args, p.ports)...)
+	cmd.Args[0] = name
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
 
 	var envs [][]string
 	for _, name := range p.environments {
@@ -113,7 +280,9 @@ func (p *proc) Cmd() *exec.Cmd {
 			envs = append(envs, e)
 		}
 	}
-	envs = append(envs, c.Env)
+	envs = append(envs, extra, portEnv(p.ports), []string{
+		fmt.Sprintf("CYNO_INSTANCE_INDEX=%d", p.index),
+	})
 
 	cmd.Env = buildEnv(envs)
 	return cmd
@@ -126,8 +295,25 @@ func (p *proc) Deps() (deps.DepList, error) {
 
 	depMap := deps.DepList{}
 	for n, dd := range p.c.Requirements {
+		if e := dd.GetExpr(); e != nil {
+			expr, err := p.requirementExpr(e)
+			if err == nil {
+				err = expr.Validate()
+			}
+			if err != nil {
+				return nil, common.Error(err, "failed to build requirements %s", n)
+			}
+
+			depMap[n] = []deps.Depender{expr}
+			continue
+		}
+
 		for _, d := range dd.Deps {
 			b := deps.Instance(d.Identity, p.namespace)
+			if b == nil {
+				return nil, common.Error(ErrUnknownBroker, "failed to build requirements %s/%s", n, d.Identity)
+			}
+
 			dep, err := b.Dep(d.Wait)
 			if err != nil {
 				return nil, common.Error(err, "failed to build requirements %s/%s", n, d.Identity)
@@ -141,50 +327,117 @@ func (p *proc) Deps() (deps.DepList, error) {
 	return depMap, nil
 }
 
+func (p *proc) Group() string {
+	return p.group
+}
+
 func (p *proc) ID() string {
 	return p.identity
 }
 
+func (p *proc) Index() int {
+	return p.index
+}
+
+func (p *proc) Labels() []*cynosure.KV {
+	return p.labels
+}
+
+// Loop runs the command until the process is closed (or, for jobs, until the job finishes).
 func (p *proc) Loop() {
 	p.delay = p.minDelay
+	p.startDeadline()
 
 	for {
 		select {
 		case <-p.ch:
 			return
+		case <-p.expired:
+			p.finish(cynosure.Process_Failed, "deadline exceeded")
+			return
 		default:
-			p.tryRun()
+			ran, err := p.tryRun()
+			if err == ErrRequirementsDeadline {
+				return
+			}
+			if p.job != nil && p.jobDone(ran, err) {
+				return
+			}
+			p.backoff()
 		}
 	}
 }
 
+func (p *proc) Name() string {
+	return p.c.GetName()
+}
+
+func (p *proc) Namespace() string {
+	return p.namespace
+}
+
 func (p *proc) Process() *cynosure.Process {
 	started := p.Started()
 	var lines int64
 	if logging := p.Log(); logging != nil {
 		lines = logging.Count()
 	}
+	entry, args, env := p.c.GetEntry(), p.c.GetArgs(), p.c.GetEnv()
+	p.RLock()
+	cmd := p.cmd
+	p.RUnlock()
+	if cmd != nil {
+		entry, args, env = cmd.Path, cmd.Args, cmd.Env
+	}
 	now := time.Now().UnixNano() / int64(time.Millisecond)
 	process := &cynosure.Process{
 		Identifier: p.ID(),
 		Namespace:  p.namespace,
+		Group:      p.group,
+		Index:      int32(p.index),
+		Labels:     p.labels,
 		Pid:        int32(p.PID()),
 		Started:    started,
 		Running:    now - started,
-		Ready:      false,
+		Ready:      p.Ready(),
+		State:      p.State(),
 		Command: &cynosure.Command{
 			Name:         p.c.GetName(),
 			Image:        p.c.GetImage(),
-			Entry:        p.cmd.Path,
-			Args:         p.cmd.Args,
-			Env:          p.cmd.Env,
+			Entry:        entry,
+			Args:         args,
+			Env:          env,
 			Requirements: p.c.GetRequirements(),
+			Ports:        p.c.GetPorts(),
+			Init:         p.c.GetInit(),
+			Hooks:        p.c.GetHooks(),
+			Liveness:     p.c.GetLiveness(),
+			Readiness:    p.c.GetReadiness(),
+			Notify:       p.c.GetNotify(),
+			Watchdog:     p.c.GetWatchdog(),
+			Sockets:      p.c.GetSockets(),
+			Recheck:      p.c.GetRecheck(),
+			Deadline:     p.c.GetDeadline(),
 			Lines:        lines,
 		},
 		Ports:        p.Ports(),
 		Observations: p.pipes.Observed(),
+		Allocations:  p.ports,
+		Liveness:     p.probeStatus(p.c.GetLiveness(), p.liveness),
+		Readiness:    p.probeStatus(p.c.GetReadiness(), p.readiness),
+		Events:       p.Events(),
+		Requirements: p.requirementStatus(),
 	}
 
+	p.RLock()
+	process.Attempts = p.attempts
+	process.ExitCode = p.exitCode
+	process.ExitMessage = p.exitMsg
+	process.Finished = p.finished
+	process.Status = p.notifyStatus
+	process.Hooks = append([]*cynosure.HookResult(nil), p.hooks...)
+	p.RUnlock()
+
 	return process
 }
 
@@ -196,16 +449,19 @@ func (p *proc) Log() pipes.Logger {
 }
 
 func (p *proc) PID() int {
-	if cmd := p.cmd; cmd != nil {
-		if proc := cmd.Process; proc != nil {
-			if pid := proc.Pid; pid > 0 {
-				if pp, err := os.FindProcess(pid); err == nil && pp != nil {
-					return pid
-				}
-			} else {
-				return pid
-			}
-		}
+	if pid := p.notifiedPID(); pid > 0 {
+		return pid
+	}
+	return p.commandPID()
+}
+
+// commandPID returns the PID of the running command (ignoring any notified main PID), or -1 if it is not running.
+func (p *proc) commandPID() int {
+	p.RLock()
+	defer p.RUnlock()
+
+	if p.cmdPID > 0 {
+		return p.cmdPID
 	}
 	return -1
 }
@@ -230,60 +486,176 @@ func (p *proc) Ports() []string {
 	return list
 }
 
+// Ready returns whether the process is running and, if it has any `MakeReady` watches, whether they have made it ready.
+//
+// A process with a post-start hook is not ready until the hook has finished (and, for a `not_ready` hook, succeeded).
+func (p *proc) Ready() bool {
+	return p.ready(true)
+}
+
+// ready returns whether the process is ready, ignoring the post-start hook unless hooked.
+func (p *proc) ready(hooked bool) bool {
+	p.RLock()
+	if p.started == 0 {
+		p.RUnlock()
+		return false
+	}
+	notReady := hooked && (p.hookPending || p.hookNotReady)
+	if p.c.GetReadiness() != nil && !p.readiness.Passing {
+		notReady = true
+	}
+	if p.notifyConn != nil && !p.notifyReady {
+		notReady = true
+	}
+	if len(p.lost) > 0 {
+		notReady = true
+	}
+	p.RUnlock()
+	if notReady {
+		return false
+	}
+
+	if p.gated {
+		return p.pipes.Ready()
+	}
+	return true
+}
+
 func (p *proc) Started() int64 {
+	p.RLock()
+	defer p.RUnlock()
+
 	return p.started
 }
 
-func (p *proc) tryRun() error {
+// tryRun runs the command once if its requirements are met, reporting whether it ran and how it exited.
+func (p *proc) tryRun() (ran bool, err error) {
 	d, err := p.Deps()
 	if err != nil {
-		return common.Error(err, "failed checking deps")
+		return false, common.Error(err, "failed checking deps")
 	}
 
-	mm, ok := d.Check()
+	mm, failed := p.checkRequirements(d)
 	checkMsg := strings.Join(mm, "\n - ")
 	if checkMsg != p.prevMsg {
 		p.prevMsg = checkMsg
-		_, _ = p.Log().Out().Write([]byte("Requirements:\n - " + checkMsg))
+		_, _ = p.Log().Out().Write([]byte("Requirements:\n - " + checkMsg + "\n"))
 	}
 
-	if ok {
-		startTime := time.Now()
+	if unmet, expired := p.waitExpired(failed); expired {
+		p.giveUp(unmet)
+		return false, ErrRequirementsDeadline
+	}
 
-		fmt.Printf("Executing: %s\n", strings.Join(p.cmd.Args, " "))
-		p.started = startTime.UnixNano() / int64(time.Millisecond)
+	if len(failed) > 0 {
+		return false, nil
+	}
 
-		p.pipes.Clear()
-		err := p.cmd.Run()
+	p.Lock()
+	p.attempts++
+	p.Unlock()
 
-		p.started = 0
-		if err != nil {
-			if exit, ok := err.(*exec.ExitError); ok {
-				if exit.Success() {
-					p.delay = p.minDelay
-				} else {
-					fmt.Printf("Process exited with error\n")
-				}
+	// Init commands must all succeed before the main entry is run.
+	if code, err := p.runInit(); err != nil {
+		p.exited(code, err.Error())
+		return true, err
+	}
 
-				if time.Now().Sub(startTime) > p.resetAfter {
-					p.delay = p.minDelay
-				}
-			} else {
-				fmt.Printf("Error running command: %#v\n", err.Error())
-				os.Exit(1)
-			}
-		} else if elapsed := time.Now().Sub(startTime); elapsed > p.resetAfter {
-			p.delay = p.minDelay
+	select {
+	case <-p.ch:
+		return false, nil
+	default:
+	}
+
+	cmd := p.Cmd()
+	startTime := time.Now()
+
+	fmt.Printf("Executing: %s\n", strings.Join(cmd.Args, " "))
+
+	p.Lock()
+	p.cmd = cmd
+	p.started = startTime.UnixNano() / int64(time.Millisecond)
+	p.generation++
+	generation := p.generation
+	p.hookPending = p.c.GetHooks().GetPostStart() != nil
+	p.hookNotReady = false
+	p.readiness.Passing = false
+	p.readiness.Failures = 0
+	p.Unlock()
+
+	p.resetNotify()
+	p.pipes.Clear()
+	done := make(chan bool)
+	go p.postStart(done, generation)
+	go p.probeLiveness(done)
+	go p.probeReadiness(done)
+	go p.watchReady(done)
+	go p.watchdog(done)
+	go p.watchRequirements(done)
+	err = startCmd(cmd)
+	if err == nil {
+		p.Lock()
+		p.cmdPID = cmd.Process.Pid
+		p.Unlock()
+
+		err = waitCmd(cmd)
+
+		p.Lock()
+		p.cmdPID = 0
+		p.Unlock()
+	}
+	p.waitMainPID()
+	close(done)
+
+	p.Lock()
+	p.started = 0
+	p.Unlock()
+	if time.Now().Sub(startTime) > p.resetAfter {
+		p.delay = p.minDelay
+	}
+
+	var code int32
+	msg := "exited successfully"
+	if err != nil {
+		if exit, ok := err.(*exec.ExitError); ok {
+			code = int32(exit.ExitCode())
+			msg = exit.Error()
+			fmt.Printf("Process exited with error\n")
+		} else {
+			// Failing to run at all (e.g. a missing entry-point) counts as a failed attempt rather than a crash.
+			code = -1
+			msg = err.Error()
+			fmt.Printf("Error running command: %#v\n", err.Error())
+			_, _ = p.Log().Err().Write([]byte("Failed to run command: " + err.Error() + "\n"))
 		}
 	}
 
-	time.Sleep(p.delay)
+	p.exited(code, msg)
+	p.postExit(code)
+	return true, err
+}
+
+// exited records how the last run of the command exited.
+func (p *proc) exited(code int32, msg string) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.exitCode = code
+	p.exitMsg = msg
+}
+
+// backoff waits before the next attempt, increasing the delay each time up to the maximum.
+func (p *proc) backoff() {
+	select {
+	case <-p.ch:
+	case <-p.expired:
+	case <-time.After(p.delay):
+	}
+
 	p.delay += p.inc
 	if p.delay > p.maxDelay {
 		p.delay = p.maxDelay
 	}
-
-	return nil
 }
 
 func buildEnv(envs [][]string) []string {
@@ -0,0 +1,179 @@
+package process
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/norganna/cynosure/common"
+)
+
+// prSetChildSubreaper is the `prctl` option that makes the caller a child subreaper.
+const prSetChildSubreaper = 36
+
+// reapInterval is how often orphans are looked for, in case a `SIGCHLD` was missed.
+const reapInterval = time.Second
+
+var reaper = struct {
+	sync.Mutex
+
+	enabled bool
+	// started contains the PIDs of the commands started by the server, which are waited on by their `exec.Cmd`.
+	started map[int]bool
+}{
+	started: map[int]bool{},
+}
+
+// SetSubreaper makes the server the child subreaper (`PR_SET_CHILD_SUBREAPER`) of the commands it runs.
+//
+// A process orphaned within a command's tree (such as a daemon whose parent exits after forking) is then re-parented
+// to the server instead of init, so it remains part of the tree, and the server reaps it when it exits.
+func SetSubreaper() error {
+	reaper.Lock()
+	defer reaper.Unlock()
+
+	if reaper.enabled {
+		return nil
+	}
+
+	_, _, errno := syscall.RawSyscall(syscall.SYS_PRCTL, prSetChildSubreaper, 1, 0)
+	if errno != 0 {
+		return common.Error(errno, "failed to become the child subreaper")
+	}
+	reaper.enabled = true
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGCHLD)
+	go func() {
+		for {
+			select {
+			case <-ch:
+			case <-time.After(reapInterval):
+			}
+			reapOrphans()
+		}
+	}()
+	return nil
+}
+
+// startCmd starts the command, recording it as started by the server so that it is left for its `exec.Cmd` to wait on.
+func startCmd(cmd *exec.Cmd) error {
+	reaper.Lock()
+	defer reaper.Unlock()
+
+	err := cmd.Start()
+	if err != nil {
+		return err
+	}
+	reaper.started[cmd.Process.Pid] = true
+	return nil
+}
+
+// waitCmd waits for a command started with startCmd to exit.
+func waitCmd(cmd *exec.Cmd) error {
+	err := cmd.Wait()
+
+	reaper.Lock()
+	delete(reaper.started, cmd.Process.Pid)
+	reaper.Unlock()
+
+	return err
+}
+
+// runCmd starts the command and waits for it to exit.
+func runCmd(cmd *exec.Cmd) error {
+	err := startCmd(cmd)
+	if err != nil {
+		return err
+	}
+	return waitCmd(cmd)
+}
+
+// reapOrphans waits on the exited children of the server that it didn't start (those re-parented to it).
+func reapOrphans() {
+	reaper.Lock()
+	defer reaper.Unlock()
+
+	self := os.Getpid()
+	stats, _ := filepath.Glob("/proc/[0-9]*/stat")
+	for _, stat := range stats {
+		pid, _ := strconv.Atoi(filepath.Base(filepath.Dir(stat)))
+		if reaper.started[pid] {
+			continue
+		}
+
+		state, ppid := procStat(pid)
+		if state != 'Z' || ppid != self {
+			continue
+		}
+
+		var ws syscall.WaitStatus
+		_, _ = syscall.Wait4(pid, &ws, syscall.WNOHANG, nil)
+	}
+}
+
+// adoptedPIDs returns the running children of the server that it didn't start (those re-parented to it).
+func adoptedPIDs() []int {
+	reaper.Lock()
+	defer reaper.Unlock()
+
+	var pids []int
+	self := os.Getpid()
+	stats, _ := filepath.Glob("/proc/[0-9]*/stat")
+	for _, stat := range stats {
+		pid, _ := strconv.Atoi(filepath.Base(filepath.Dir(stat)))
+		if reaper.started[pid] {
+			continue
+		}
+
+		state, ppid := procStat(pid)
+		if state != 0 && state != 'Z' && ppid == self {
+			pids = append(pids, pid)
+		}
+	}
+	return pids
+}
+
+// procStat returns the state and parent PID of the process (or 0 for both if it can't be read).
+func procStat(pid int) (state byte, ppid int) {
+	data, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return 0, 0
+	}
+
+	// The command name is in parentheses and may itself contain them, so the fields follow the last one.
+	i := bytes.LastIndexByte(data, ')')
+	if i < 0 {
+		return 0, 0
+	}
+	fields := bytes.Fields(data[i+1:])
+	if len(fields) < 2 || len(fields[0]) != 1 {
+		return 0, 0
+	}
+
+	ppid, _ = strconv.Atoi(string(fields[1]))
+	return fields[0][0], ppid
+}
+
+// procEnv returns whether the environment the process was started with contains the `KEY=VALUE` entry.
+func procEnv(pid int, entry string) bool {
+	data, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/environ", pid))
+	if err != nil {
+		return false
+	}
+
+	for _, e := range bytes.Split(data, []byte{0}) {
+		if string(e) == entry {
+			return true
+		}
+	}
+	return false
+}
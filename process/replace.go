@@ -0,0 +1,199 @@
+package process
+
+import (
+	"time"
+
+	"github.com/norganna/cynosure/common"
+	"github.com/norganna/cynosure/proto/cynosure"
+)
+
+// Standard error messages.
+var (
+	ErrUnknownTarget = common.ErrorMsg("unknown process or group to replace")
+	ErrReplacing     = common.ErrorMsg("a replacement is already in progress for the group")
+	ErrNotReady      = common.ErrorMsg("replacement did not become ready before the deadline")
+)
+
+// readyPoll is how often replacements are checked for readiness.
+const readyPoll = 100 * time.Millisecond
+
+// Replace starts the new specification alongside the target (a process or an entire group), only stopping the old
+// processes once their replacements become ready.
+//
+// Groups are replaced in batches of surge replicas. If a batch does not become ready before the deadline, its
+// replacements are removed, the old processes are kept and the replacement stops (leaving earlier batches replaced).
+func (m *processManager) Replace(target string, req *cynosure.StartRequest, deadline time.Duration, surge int) (group string, list []Processor, err error) {
+	if req.GetCommand() == nil {
+		return "", nil, ErrNoCommand
+	}
+	// Reject badly formed requirements before any of the target is scaled down.
+	err = validateRequirements(req)
+	if err != nil {
+		return "", nil, err
+	}
+	if surge < 1 {
+		surge = 1
+	}
+
+	m.Lock()
+	g, old := m.replaceTarget(target)
+	if g == nil {
+		m.Unlock()
+		return "", nil, ErrUnknownTarget
+	}
+	if g.replacing {
+		m.Unlock()
+		return g.id, nil, ErrReplacing
+	}
+	g.replacing = true
+
+	// When the whole group is being replaced, scale down before (and up after) replacing to save on churn.
+	whole := target == g.id || len(g.members) == 1
+	replicas := len(old)
+	if whole && req.GetReplicas() > 0 {
+		replicas = int(req.GetReplicas())
+		if replicas < len(old) {
+			for _, process := range old[replicas:] {
+				m.remove(process)
+				process.Close()
+			}
+			old = old[:replicas]
+		}
+	}
+	m.Unlock()
+
+	defer func() {
+		m.Lock()
+		defer m.Unlock()
+
+		g.replacing = false
+		if len(old) > 0 && len(g.members) == 0 && m.groups[g.id] == g {
+			// Every member was stopped during the replacement.
+			delete(m.groups, g.id)
+		}
+		list = g.list()
+	}()
+
+	for start := 0; start < len(old); start += surge {
+		end := start + surge
+		if end > len(old) {
+			end = len(old)
+		}
+
+		err = m.replaceBatch(g, req, old[start:end], deadline)
+		if err != nil {
+			return g.id, nil, err
+		}
+	}
+
+	// Once the whole group is replaced, further replicas (from a later scale) are started with the new specification.
+	m.Lock()
+	var started []Processor
+	if whole && m.groups[g.id] == g {
+		g.req = req
+		started, err = m.scale(g, replicas)
+	}
+	m.Unlock()
+	if err != nil {
+		return g.id, nil, err
+	}
+
+	waitStarted(started)
+	return g.id, nil, nil
+}
+
+// replaceTarget finds the group and the processes within it to be replaced (must be called with the lock held).
+func (m *processManager) replaceTarget(target string) (*processGroup, []Processor) {
+	if g, ok := m.groups[target]; ok {
+		return g, g.list()
+	}
+
+	if process, ok := m.processList[target]; ok {
+		if g, ok := m.groups[process.Group()]; ok {
+			return g, []Processor{process}
+		}
+	}
+
+	return nil, nil
+}
+
+func (m *processManager) replaceBatch(g *processGroup, req *cynosure.StartRequest, old []Processor, deadline time.Duration) error {
+	var fresh []Processor
+	discard := func() {
+		for _, process := range fresh {
+			m.remove(process)
+			process.Close()
+		}
+	}
+
+	m.Lock()
+	for _, o := range old {
+		process, err := NewProcess(req, g.id, o.Index())
+		if err != nil {
+			discard()
+			m.Unlock()
+			return common.Error(err, "failed to create replacement for %s", o.ID())
+		}
+
+		m.processList[process.ID()] = process
+		fresh = append(fresh, process)
+		go m.run(process)
+	}
+	m.Unlock()
+
+	ready := m.waitReady(fresh, deadline)
+
+	m.Lock()
+	defer m.Unlock()
+
+	if !ready {
+		discard()
+		return ErrNotReady
+	}
+
+	if m.groups[g.id] != g {
+		// The group was stopped while we were waiting.
+		discard()
+		return ErrUnknownTarget
+	}
+
+	for i, o := range old {
+		if _, ok := m.processList[o.ID()]; !ok {
+			// The old process was stopped while we were waiting, so it isn't replaced.
+			m.remove(fresh[i])
+			fresh[i].Close()
+			continue
+		}
+
+		m.remove(o)
+		o.Close()
+		g.members[o.Index()] = fresh[i]
+	}
+	return nil
+}
+
+// waitReady waits until all of the processes are ready, returning false if the deadline passes (or any of them is
+// stopped) first.
+func (m *processManager) waitReady(list []Processor, deadline time.Duration) bool {
+	expires := time.Now().Add(deadline)
+	for {
+		ready := true
+		for _, process := range list {
+			if m.Get(process.ID()) == nil {
+				return false
+			}
+			if !process.Ready() {
+				ready = false
+				break
+			}
+		}
+
+		if ready {
+			return true
+		}
+		if time.Now().After(expires) {
+			return false
+		}
+		time.Sleep(readyPoll)
+	}
+}
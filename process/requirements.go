@@ -0,0 +1,342 @@
+package process
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/norganna/cynosure/common"
+	"github.com/norganna/cynosure/deps"
+	"github.com/norganna/cynosure/proto/cynosure"
+)
+
+// Standard error messages.
+var (
+	ErrRequirementsDeadline = common.ErrorMsg("requirements were not met before the deadline")
+)
+
+// Event reasons for requirements.
+const (
+	EventRequirementLost     = "RequirementLost"
+	EventRequirementRestored = "RequirementRestored"
+	EventRequirementsFailed  = "RequirementsFailed"
+)
+
+// defaultRecheck is how often requirements are rechecked while running if the command does not specify.
+const defaultRecheck = 10 * time.Second
+
+// watchRequirements rechecks the requirements that have a lost policy on an interval until done is closed.
+func (p *proc) watchRequirements(done chan bool) {
+	var names []string
+	for name, dd := range p.c.GetRequirements() {
+		if dd.GetLost() != cynosure.Deps_Ignore {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return
+	}
+	sort.Strings(names)
+
+	d, err := p.Deps()
+	if err != nil {
+		return
+	}
+
+	interval := defaultRecheck
+	if ms := p.c.GetRecheck(); ms > 0 {
+		interval = time.Duration(ms) * time.Millisecond
+	}
+
+	defer p.clearLost()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-time.After(interval):
+		}
+
+		for _, name := range names {
+			msg, ok := p.checkRequirement(d, name)
+
+			p.Lock()
+			was := p.lost[name]
+			if ok {
+				delete(p.lost, name)
+			} else {
+				p.lost[name] = true
+			}
+			p.Unlock()
+
+			if ok {
+				if was {
+					p.event(EventRequirementRestored, msg)
+				}
+				continue
+			}
+			if was {
+				continue
+			}
+
+			p.event(EventRequirementLost, msg)
+			_, _ = p.Log().Out().Write([]byte("Requirement lost:\n - " + msg + "\n"))
+
+			if p.c.GetRequirements()[name].GetLost() == cynosure.Deps_Stop {
+				p.stop()
+				return
+			}
+		}
+	}
+}
+
+// clearLost forgets any lost requirements (they will be checked again before the next start).
+func (p *proc) clearLost() {
+	p.Lock()
+	defer p.Unlock()
+
+	p.lost = map[string]bool{}
+}
+
+// checkRequirements checks each of the requirements, returning the messages for all and those that failed.
+func (p *proc) checkRequirements(d deps.DepList) (messages []string, failed map[string]string) {
+	failed = map[string]string{}
+	for _, name := range d.Names() {
+		msg, ok := p.checkRequirement(d, name)
+		if !ok {
+			failed[name] = msg
+		}
+		messages = append(messages, msg)
+	}
+	return messages, failed
+}
+
+// checkRequirement checks the named requirement and records its status.
+func (p *proc) checkRequirement(d deps.DepList, name string) (string, bool) {
+	r := d.Result(name)
+
+	status := &cynosure.RequirementStatus{
+		Name:    name,
+		Passed:  r.OK,
+		Message: r.Message,
+		Checked: time.Now().UnixNano() / int64(time.Millisecond),
+	}
+
+	if r.Expr != nil {
+		status.Expr = exprStatus(p.c.GetRequirements()[name].GetExpr(), r.Expr)
+
+		p.Lock()
+		p.reqStatus[name] = status
+		p.Unlock()
+
+		return r.Message, r.OK
+	}
+
+	status.Deps = make([]*cynosure.DepStatus, len(r.Deps))
+	specs := p.c.GetRequirements()[name].GetDeps()
+	for i, dr := range r.Deps {
+		status.Deps[i] = &cynosure.DepStatus{
+			Checked: dr.Checked,
+			Passed:  dr.OK,
+			Message: dr.Message,
+		}
+		if i < len(specs) {
+			status.Deps[i].Identity = specs[i].GetIdentity()
+			status.Deps[i].Wait = specs[i].GetWait()
+		}
+	}
+
+	p.Lock()
+	p.reqStatus[name] = status
+	p.Unlock()
+
+	return r.Message, r.OK
+}
+
+// requirementStatus returns the last status of each requirement (ordered by name).
+//
+// Requirements that have not been checked yet are included as not passed.
+func (p *proc) requirementStatus() []*cynosure.RequirementStatus {
+	reqs := p.c.GetRequirements()
+	if len(reqs) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(reqs))
+	for name := range reqs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	p.RLock()
+	defer p.RUnlock()
+
+	list := make([]*cynosure.RequirementStatus, len(names))
+	for i, name := range names {
+		if status, ok := p.reqStatus[name]; ok {
+			list[i] = status
+			continue
+		}
+
+		status := &cynosure.RequirementStatus{
+			Name:    name,
+			Message: "not checked",
+		}
+		if e := reqs[name].GetExpr(); e != nil {
+			status.Expr = exprStatus(e, nil)
+		}
+		for _, dep := range reqs[name].GetDeps() {
+			status.Deps = append(status.Deps, &cynosure.DepStatus{
+				Identity: dep.GetIdentity(),
+				Wait:     dep.GetWait(),
+			})
+		}
+		list[i] = status
+	}
+	return list
+}
+
+// validateRequirements returns an error if the requirements of the request can't be built.
+func validateRequirements(req *cynosure.StartRequest) error {
+	p := &proc{
+		namespace: req.GetNamespace(),
+		c:         req.GetCommand(),
+	}
+	_, err := p.Deps()
+	return err
+}
+
+// exprOps maps the expression operators to their deps equivalent.
+var exprOps = map[cynosure.Expr_Op]deps.Op{
+	cynosure.Expr_Leaf:   deps.OpLeaf,
+	cynosure.Expr_And:    deps.OpAnd,
+	cynosure.Expr_Or:     deps.OpOr,
+	cynosure.Expr_Not:    deps.OpNot,
+	cynosure.Expr_Quorum: deps.OpQuorum,
+}
+
+// requirementExpr builds the requirement expression, getting the leaf dependencies from their brokers.
+func (p *proc) requirementExpr(e *cynosure.Expr) (*deps.Expr, error) {
+	op, ok := exprOps[e.GetOp()]
+	if !ok {
+		return nil, common.Error(deps.ErrExprUnknownOp, "failed to build operator %s", e.GetOp())
+	}
+
+	expr := &deps.Expr{
+		Op:     op,
+		Quorum: int(e.GetQuorum()),
+	}
+
+	if d := e.GetDep(); d != nil && op == deps.OpLeaf {
+		b := deps.Instance(d.GetIdentity(), p.namespace)
+		if b == nil {
+			return nil, common.Error(ErrUnknownBroker, "failed to find broker %s", d.GetIdentity())
+		}
+
+		dep, err := b.Dep(d.GetWait())
+		if err != nil {
+			return nil, common.Error(err, "failed to build dependency %s", d.GetIdentity())
+		}
+		expr.Dep = dep
+	}
+
+	for _, arg := range e.GetArgs() {
+		ae, err := p.requirementExpr(arg)
+		if err != nil {
+			return nil, err
+		}
+		expr.Args = append(expr.Args, ae)
+	}
+	return expr, nil
+}
+
+// exprStatus returns the status of the expression from its result (which is nil if it hasn't been checked).
+func exprStatus(e *cynosure.Expr, r *deps.ExprResult) *cynosure.ExprStatus {
+	status := &cynosure.ExprStatus{
+		Op:     e.GetOp(),
+		Quorum: e.GetQuorum(),
+	}
+	if status.Op == cynosure.Expr_Quorum && status.Quorum < 1 {
+		status.Quorum = int32(len(e.GetArgs())/2 + 1)
+	}
+
+	if r != nil {
+		status.Quorum = int32(r.Quorum)
+		status.Checked = r.Checked
+		status.Passed = r.OK
+		status.Message = r.Message
+	}
+
+	if d := e.GetDep(); d != nil && e.GetOp() == cynosure.Expr_Leaf {
+		status.Dep = &cynosure.DepStatus{
+			Identity: d.GetIdentity(),
+			Wait:     d.GetWait(),
+			Checked:  status.Checked,
+			Passed:   status.Passed,
+			Message:  status.Message,
+		}
+	}
+
+	for i, arg := range e.GetArgs() {
+		var ar *deps.ExprResult
+		if r != nil && i < len(r.Args) {
+			ar = r.Args[i]
+		}
+		status.Args = append(status.Args, exprStatus(arg, ar))
+	}
+	return status
+}
+
+// waitExpired returns the messages of the failed requirements if any (or all) have been waited on past their deadline.
+//
+// Each requirement is timed from when it started failing, and the command deadline from when any started failing.
+func (p *proc) waitExpired(failed map[string]string) ([]string, bool) {
+	now := time.Now()
+	for name := range p.waiting {
+		if _, ok := failed[name]; !ok {
+			delete(p.waiting, name)
+		}
+	}
+
+	if len(failed) == 0 {
+		p.waitingSince = time.Time{}
+		return nil, false
+	}
+
+	if p.waitingSince.IsZero() {
+		p.waitingSince = now
+	}
+
+	expired := false
+	if ms := p.c.GetDeadline(); ms > 0 && now.Sub(p.waitingSince) > time.Duration(ms)*time.Millisecond {
+		expired = true
+	}
+
+	var messages []string
+	for name, msg := range failed {
+		messages = append(messages, msg)
+
+		since, ok := p.waiting[name]
+		if !ok {
+			since = now
+			p.waiting[name] = since
+		}
+		if ms := p.c.GetRequirements()[name].GetDeadline(); ms > 0 && now.Sub(since) > time.Duration(ms)*time.Millisecond {
+			expired = true
+		}
+	}
+	sort.Strings(messages)
+	return messages, expired
+}
+
+// giveUp fails the process because its requirements were not met in time.
+func (p *proc) giveUp(messages []string) {
+	msg := ErrRequirementsDeadline.Error() + ": " + strings.Join(messages, "; ")
+
+	p.event(EventRequirementsFailed, msg)
+	p.finish(cynosure.Process_Failed, msg)
+
+	if hook := p.c.GetHooks().GetFailed(); hook != nil {
+		p.runHook(cynosure.HookResult_Failed, hook, nil)
+	}
+}
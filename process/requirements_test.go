@@ -0,0 +1,349 @@
+package process
+
+import (
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/norganna/cynosure/deps"
+	_ "github.com/norganna/cynosure/deps/always"
+	"github.com/norganna/cynosure/proto/cynosure"
+)
+
+func TestWaitExpired(t *testing.T) {
+	req := sleeper("60", 1)
+	req.Command.Requirements = map[string]*cynosure.Deps{
+		"a": {Deadline: 150},
+		"b": {Deadline: 150},
+	}
+
+	process, err := NewProcess(req, "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer process.Close()
+	p := process.(*proc)
+
+	// Each step is 60ms after the previous one.
+	steps := []struct {
+		failed  []string
+		expired bool
+	}{
+		{[]string{"a"}, false},
+		{[]string{"b"}, false},
+		{[]string{"a", "b"}, false},
+		// The requirements have failed for 180ms in total, but a has only been failing again for 60ms.
+		{[]string{"a", "b"}, false},
+		// Now b has been failing for 180ms.
+		{[]string{"a", "b"}, true},
+		{nil, false},
+		{[]string{"b"}, false},
+	}
+	for i, step := range steps {
+		failed := map[string]string{}
+		for _, name := range step.failed {
+			failed[name] = name + " failed"
+		}
+
+		messages, expired := p.waitExpired(failed)
+		if expired != step.expired {
+			t.Errorf("step %d: got expired %v, want %v", i, expired, step.expired)
+		}
+		if len(messages) != len(step.failed) {
+			t.Errorf("step %d: got messages %v", i, messages)
+		}
+		time.Sleep(60 * time.Millisecond)
+	}
+}
+
+func TestWaitExpiredCommand(t *testing.T) {
+	req := sleeper("60", 1)
+	req.Command.Deadline = 150
+
+	process, err := NewProcess(req, "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer process.Close()
+	p := process.(*proc)
+
+	// The command deadline applies while any requirement is failing.
+	for i, name := range []string{"a", "b", "a"} {
+		if _, expired := p.waitExpired(map[string]string{name: "failed"}); expired {
+			t.Errorf("step %d: expired early", i)
+		}
+		time.Sleep(60 * time.Millisecond)
+	}
+	if _, expired := p.waitExpired(map[string]string{"b": "failed"}); !expired {
+		t.Error("command deadline did not expire")
+	}
+}
+
+func TestInvalidRequirements(t *testing.T) {
+	err := deps.NewInstance("up", "", "always", map[string]string{"state": "true"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	up := &cynosure.Expr{Dep: &cynosure.Dep{Identity: "up"}}
+	tests := []struct {
+		name string
+		deps *cynosure.Deps
+		err  error
+	}{
+		{"unknown broker", &cynosure.Deps{Deps: []*cynosure.Dep{{Identity: "missing"}}}, ErrUnknownBroker},
+		{"unknown expression broker", &cynosure.Deps{Expr: &cynosure.Expr{Dep: &cynosure.Dep{Identity: "missing"}}}, ErrUnknownBroker},
+		{"quorum too large", &cynosure.Deps{Expr: &cynosure.Expr{Op: cynosure.Expr_Quorum, Quorum: 3, Args: []*cynosure.Expr{up, up}}}, deps.ErrExprQuorum},
+		{"not with two args", &cynosure.Deps{Expr: &cynosure.Expr{Op: cynosure.Expr_Not, Args: []*cynosure.Expr{up, up}}}, deps.ErrExprNotArgs},
+		{"unknown operator", &cynosure.Deps{Expr: &cynosure.Expr{Op: 99, Args: []*cynosure.Expr{up}}}, deps.ErrExprUnknownOp},
+	}
+
+	m := NewProcessManager()
+	defer m.Quit()
+
+	group, old, err := m.Start(sleeper("60", 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := sleeper("61", 1)
+			req.Command.Requirements = map[string]*cynosure.Deps{"ready": tt.deps}
+
+			_, _, err := m.Start(req)
+			if err == nil || !strings.Contains(err.Error(), tt.err.Error()) {
+				t.Errorf("start got error %v, want %v", err, tt.err)
+			}
+
+			// Nothing is scaled down when the replacement is rejected.
+			_, _, err = m.Replace(group, req, time.Second, 1)
+			if err == nil || !strings.Contains(err.Error(), tt.err.Error()) {
+				t.Errorf("replace got error %v, want %v", err, tt.err)
+			}
+			for i, p := range old {
+				if m.Get(p.ID()) == nil {
+					t.Errorf("replica %d was stopped", i)
+				}
+			}
+		})
+	}
+}
+
+// toggleDep is a requirement that can be switched between met and failing.
+type toggleDep struct {
+	ok int32
+}
+
+func (d *toggleDep) Check() (string, bool) {
+	if atomic.LoadInt32(&d.ok) == 1 {
+		return "toggle on", true
+	}
+	return "toggle off", false
+}
+
+func (d *toggleDep) set(ok bool) {
+	var v int32
+	if ok {
+		v = 1
+	}
+	atomic.StoreInt32(&d.ok, v)
+}
+
+func TestLostPolicy(t *testing.T) {
+	err := deps.NewInstance("up", "", "always", map[string]string{"state": "true"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		lost  cynosure.Deps_Lost
+		ready bool
+		// stopped is whether the command is stopped while the requirement is lost.
+		stopped bool
+		events  []string
+	}{
+		{"ignore", cynosure.Deps_Ignore, true, false, nil},
+		{"not ready", cynosure.Deps_NotReady, false, false, []string{EventRequirementLost, EventRequirementRestored}},
+		{"stop", cynosure.Deps_Stop, false, true, []string{EventRequirementLost}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := sleeper("60", 1)
+			req.Command.Recheck = 50
+			req.Command.Requirements = map[string]*cynosure.Deps{
+				"net": {Deps: []*cynosure.Dep{{Identity: "up"}}, Lost: tt.lost},
+			}
+
+			process, err := NewProcess(req, "", 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer process.Close()
+			p := process.(*proc)
+
+			toggle := &toggleDep{ok: 1}
+			p.deps = deps.DepList{"net": {toggle}}
+			go process.Loop()
+
+			// waitFor waits for the process to be ready (or not), returning the PID of its command.
+			waitFor := func(ready bool) int {
+				for i := 0; i < 50; i++ {
+					time.Sleep(100 * time.Millisecond)
+					if process.Ready() == ready {
+						return p.commandPID()
+					}
+				}
+				t.Fatalf("process did not become ready %v", ready)
+				return 0
+			}
+
+			pid := waitFor(true)
+			if pid < 1 {
+				t.Fatal("process did not start")
+			}
+
+			toggle.set(false)
+			time.Sleep(300 * time.Millisecond)
+			if got := process.Ready(); got != tt.ready {
+				t.Errorf("got ready %v while lost, want %v", got, tt.ready)
+			}
+			if running := p.commandPID() == pid; running == tt.stopped {
+				t.Errorf("got command running %v while lost (PID %d, was %d)", running, p.commandPID(), pid)
+			}
+
+			// Once the requirement is met again, the process is ready (with a new command if it was stopped).
+			toggle.set(true)
+			now := waitFor(true)
+			if restarted := now != pid; restarted != tt.stopped {
+				t.Errorf("got restarted %v (PID %d, was %d)", restarted, now, pid)
+			}
+
+			var events []string
+			for _, e := range p.Events() {
+				if e.Reason == EventRequirementLost || e.Reason == EventRequirementRestored {
+					events = append(events, e.Reason)
+				}
+			}
+			if strings.Join(events, ",") != strings.Join(tt.events, ",") {
+				t.Errorf("got events %v, want %v", events, tt.events)
+			}
+		})
+	}
+}
+
+func TestRequirementStatus(t *testing.T) {
+	for identity, state := range map[string]string{"up": "true", "never": "false"} {
+		err := deps.NewInstance(identity, "", "always", map[string]string{"state": state})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	leaf := func(identity string) *cynosure.Expr {
+		return &cynosure.Expr{Dep: &cynosure.Dep{Identity: identity, Wait: identity}}
+	}
+	req := sleeper("60", 1)
+	req.Command.Requirements = map[string]*cynosure.Deps{
+		"zeta": {Deps: []*cynosure.Dep{
+			{Identity: "never", Wait: "a"},
+			{Identity: "up", Wait: "b"},
+			{Identity: "up", Wait: "c"},
+		}},
+		"alpha": {Expr: &cynosure.Expr{Op: cynosure.Expr_And, Args: []*cynosure.Expr{
+			leaf("up"),
+			{Op: cynosure.Expr_Not, Args: []*cynosure.Expr{leaf("never")}},
+		}}},
+		"mid": {Expr: &cynosure.Expr{Op: cynosure.Expr_Quorum, Args: []*cynosure.Expr{
+			leaf("up"), leaf("never"), leaf("never"),
+		}}},
+	}
+
+	process, err := NewProcess(req, "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer process.Close()
+	p := process.(*proc)
+
+	names := func(list []*cynosure.RequirementStatus) string {
+		var out []string
+		for _, status := range list {
+			out = append(out, status.Name)
+		}
+		return strings.Join(out, ",")
+	}
+
+	// Before they are checked, the requirements are listed (by name) with their structure.
+	list := p.requirementStatus()
+	if got := names(list); got != "alpha,mid,zeta" {
+		t.Fatalf("got requirements %s", got)
+	}
+	for _, status := range list {
+		if status.Passed || status.Checked != 0 || status.Message != "not checked" {
+			t.Errorf("%s: got unchecked status %v", status.Name, status)
+		}
+	}
+	if e := list[0].Expr; e.GetOp() != cynosure.Expr_And || len(e.GetArgs()) != 2 ||
+		e.GetArgs()[0].GetDep().GetIdentity() != "up" || e.GetArgs()[1].GetArgs()[0].GetDep().GetIdentity() != "never" {
+		t.Errorf("got unchecked expression %v", e)
+	}
+	if q := list[1].Expr.GetQuorum(); q != 2 {
+		t.Errorf("got default quorum %d, want 2", q)
+	}
+	if d := list[2].Deps; len(d) != 3 || d[0].Identity != "never" || d[0].Wait != "a" || d[2].Wait != "c" {
+		t.Errorf("got unchecked deps %v", d)
+	}
+
+	d, err := p.Deps()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.checkRequirements(d)
+
+	list = p.requirementStatus()
+	if got := names(list); got != "alpha,mid,zeta" {
+		t.Fatalf("got requirements %s", got)
+	}
+	alpha, mid, zeta := list[0], list[1], list[2]
+
+	if !alpha.Passed || alpha.Checked == 0 {
+		t.Errorf("alpha: got %v", alpha)
+	}
+	up, negated := alpha.Expr.GetArgs()[0], alpha.Expr.GetArgs()[1]
+	if !alpha.Expr.Checked || !alpha.Expr.Passed || !up.Checked || !up.GetDep().GetPassed() ||
+		up.GetDep().GetMessage() != "always true" || !negated.Passed {
+		t.Errorf("alpha: got expression %v", alpha.Expr)
+	}
+	if never := negated.GetArgs()[0].GetDep(); never.GetIdentity() != "never" || never.GetPassed() || !never.GetChecked() {
+		t.Errorf("alpha: got negated dep %v", never)
+	}
+
+	if mid.Passed || mid.Message == "" || mid.Expr.GetQuorum() != 2 || mid.Expr.Passed {
+		t.Errorf("mid: got %v", mid)
+	}
+
+	// The deps after the first that passes are not checked.
+	want := []struct {
+		wait    string
+		checked bool
+		passed  bool
+		message string
+	}{
+		{"a", true, false, "always false"},
+		{"b", true, true, "always true"},
+		{"c", false, false, ""},
+	}
+	if !zeta.Passed || len(zeta.Deps) != len(want) {
+		t.Fatalf("zeta: got %v", zeta)
+	}
+	for i, w := range want {
+		got := zeta.Deps[i]
+		if got.Wait != w.wait || got.Checked != w.checked || got.Passed != w.passed || got.Message != w.message {
+			t.Errorf("zeta dep %d: got %v", i, got)
+		}
+	}
+}
@@ -0,0 +1,366 @@
+package process
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/norganna/cynosure/common"
+	"github.com/norganna/cynosure/proto/cynosure"
+	"github.com/robfig/cron/v3"
+)
+
+// Standard error messages.
+var (
+	ErrNoScheduleName  = common.ErrorMsg("a schedule name is required")
+	ErrUnknownSchedule = common.ErrorMsg("unknown schedule")
+)
+
+// ScheduleLabel is the label given to jobs started by a schedule (set to the schedule name).
+const ScheduleLabel = "cyno.schedule"
+
+// maxRuns is the number of runs kept in the history of each schedule.
+const maxRuns = 20
+
+// jobPoll is how often a scheduled job is checked for completion.
+const jobPoll = time.Second
+
+// Scheduler starts jobs from templates according to cron expressions.
+type Scheduler interface {
+	Delete(namespace, name string) bool
+	Get(namespace, name string) *cynosure.Schedule
+	List(namespace string) []*cynosure.Schedule
+	Resume(namespace, name string) (*cynosure.Schedule, error)
+	Schedule(req *cynosure.ScheduleRequest) (*cynosure.Schedule, error)
+	Suspend(namespace, name string) (*cynosure.Schedule, error)
+}
+
+// DefaultScheduler is the scheduler used by the server (which starts its jobs in the Default process manager).
+var DefaultScheduler = NewScheduler(Default)
+
+type schedule struct {
+	spec  *cynosure.Schedule
+	cron  cron.Schedule
+	loc   *time.Location
+	timer *time.Timer
+}
+
+type scheduler struct {
+	sync.RWMutex
+
+	m ProcessManager
+
+	// schedules[namespace][name]
+	schedules map[string]map[string]*schedule
+}
+
+var _ Scheduler = (*scheduler)(nil)
+
+// NewScheduler creates a new Scheduler that starts its jobs in the process manager.
+func NewScheduler(m ProcessManager) Scheduler {
+	return &scheduler{
+		m:         m,
+		schedules: map[string]map[string]*schedule{},
+	}
+}
+
+func (s *scheduler) Delete(namespace, name string) bool {
+	s.Lock()
+	defer s.Unlock()
+
+	sc, ok := s.schedules[namespace][name]
+	if !ok {
+		return false
+	}
+
+	sc.disarm()
+	delete(s.schedules[namespace], name)
+	return true
+}
+
+func (s *scheduler) Get(namespace, name string) *cynosure.Schedule {
+	s.RLock()
+	defer s.RUnlock()
+
+	sc, ok := s.schedules[namespace][name]
+	if !ok {
+		return nil
+	}
+	return sc.copy()
+}
+
+func (s *scheduler) List(namespace string) []*cynosure.Schedule {
+	s.RLock()
+	defer s.RUnlock()
+
+	var list []*cynosure.Schedule
+	for _, sc := range s.schedules[namespace] {
+		list = append(list, sc.copy())
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+func (s *scheduler) Resume(namespace, name string) (*cynosure.Schedule, error) {
+	return s.suspend(namespace, name, false)
+}
+
+func (s *scheduler) Schedule(req *cynosure.ScheduleRequest) (*cynosure.Schedule, error) {
+	if req.GetName() == "" {
+		return nil, ErrNoScheduleName
+	}
+	if req.GetStart().GetCommand() == nil {
+		return nil, ErrNoCommand
+	}
+
+	expr, err := cron.ParseStandard(req.GetCron())
+	if err != nil {
+		return nil, common.Error(err, "failed to parse cron expression %s", req.GetCron())
+	}
+
+	loc := time.Local
+	if tz := req.GetTimezone(); tz != "" {
+		loc, err = time.LoadLocation(tz)
+		if err != nil {
+			return nil, common.Error(err, "failed to load timezone %s", tz)
+		}
+	}
+
+	sc := &schedule{
+		spec: &cynosure.Schedule{
+			Namespace:   req.GetNamespace(),
+			Name:        req.GetName(),
+			Cron:        req.GetCron(),
+			Timezone:    req.GetTimezone(),
+			Start:       req.GetStart(),
+			Concurrency: req.GetConcurrency(),
+			Suspended:   req.GetSuspended(),
+			Created:     time.Now().UnixNano() / int64(time.Millisecond),
+		},
+		cron: expr,
+		loc:  loc,
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	names, ok := s.schedules[sc.spec.Namespace]
+	if !ok {
+		names = map[string]*schedule{}
+		s.schedules[sc.spec.Namespace] = names
+	}
+
+	// Updating a schedule keeps its creation time and run history.
+	if prev, ok := names[sc.spec.Name]; ok {
+		prev.disarm()
+		sc.spec.Created = prev.spec.Created
+		sc.spec.Runs = prev.spec.Runs
+	}
+	names[sc.spec.Name] = sc
+
+	s.arm(sc)
+	return sc.copy(), nil
+}
+
+func (s *scheduler) Suspend(namespace, name string) (*cynosure.Schedule, error) {
+	return s.suspend(namespace, name, true)
+}
+
+func (s *scheduler) suspend(namespace, name string, suspended bool) (*cynosure.Schedule, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	sc, ok := s.schedules[namespace][name]
+	if !ok {
+		return nil, ErrUnknownSchedule
+	}
+
+	sc.spec.Suspended = suspended
+	sc.disarm()
+	s.arm(sc)
+	return sc.copy(), nil
+}
+
+// arm sets the timer for the next tick of the schedule (unless it is suspended).
+func (s *scheduler) arm(sc *schedule) {
+	sc.spec.Next = 0
+	if sc.spec.Suspended {
+		return
+	}
+
+	now := time.Now()
+	next := sc.cron.Next(now.In(sc.loc))
+	if next.IsZero() {
+		return
+	}
+
+	sc.spec.Next = next.UnixNano() / int64(time.Millisecond)
+	sc.timer = time.AfterFunc(next.Sub(now), func() {
+		s.tick(sc)
+	})
+}
+
+// tick starts a job for the schedule according to its concurrency policy and arms the next tick.
+//
+// The policy is decided under the lock, but the process manager is called without it.
+func (s *scheduler) tick(sc *schedule) {
+	s.Lock()
+
+	// The schedule may have been deleted, replaced or suspended while the timer was firing.
+	if s.schedules[sc.spec.Namespace][sc.spec.Name] != sc || sc.spec.Suspended {
+		s.Unlock()
+		return
+	}
+	sc.timer = nil
+
+	var running []*cynosure.ScheduleRun
+	for _, run := range sc.spec.Runs {
+		if run.Group != "" && run.State == cynosure.Process_Running {
+			running = append(running, run)
+		}
+	}
+	concurrency := sc.spec.Concurrency
+	req := sc.template()
+	s.Unlock()
+
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+	run := &cynosure.ScheduleRun{
+		Time: now,
+	}
+
+	active := s.active(running)
+	switch {
+	case len(active) > 0 && concurrency == cynosure.Schedule_Forbid:
+		run.Skipped = true
+		run.Message = "previous run is still running"
+		run.Finished = now
+	default:
+		if concurrency == cynosure.Schedule_Replace && len(active) > 0 {
+			s.Lock()
+			for _, prev := range active {
+				if prev.State == cynosure.Process_Running {
+					prev.State = cynosure.Process_Failed
+					prev.Message = "replaced by a later run"
+					prev.Finished = now
+				}
+			}
+			s.Unlock()
+
+			for _, prev := range active {
+				for _, process := range s.m.Group(prev.Group) {
+					s.m.Stop(process.ID())
+				}
+			}
+		}
+
+		group, _, err := s.m.Start(req)
+		if err != nil {
+			run.State = cynosure.Process_Failed
+			run.Message = err.Error()
+			run.Finished = now
+		} else {
+			run.Group = group
+		}
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	sc.spec.Runs = append(sc.spec.Runs, run)
+	if len(sc.spec.Runs) > maxRuns {
+		sc.spec.Runs = sc.spec.Runs[len(sc.spec.Runs)-maxRuns:]
+	}
+	if run.Group != "" {
+		go s.watch(run)
+	}
+
+	// The schedule may have been changed (or already re-armed by a resume) while the job was starting.
+	if s.schedules[sc.spec.Namespace][sc.spec.Name] == sc && !sc.spec.Suspended && sc.timer == nil {
+		s.arm(sc)
+	}
+}
+
+// watch waits for the job started by the run to finish and records its outcome.
+func (s *scheduler) watch(run *cynosure.ScheduleRun) {
+	for {
+		time.Sleep(jobPoll)
+
+		list := s.m.Group(run.Group)
+		state, msg := jobState(list)
+		if len(list) == 0 {
+			state, msg = cynosure.Process_Failed, "stopped before finishing"
+		}
+		if state == cynosure.Process_Running {
+			continue
+		}
+
+		s.Lock()
+		if run.State == cynosure.Process_Running {
+			run.State = state
+			run.Message = msg
+			run.Finished = time.Now().UnixNano() / int64(time.Millisecond)
+		}
+		s.Unlock()
+		return
+	}
+}
+
+// jobState returns the combined state of the processes of a job (failed if any of them failed).
+func jobState(list []Processor) (cynosure.Process_State, string) {
+	state := cynosure.Process_Succeeded
+	msg := ""
+	for _, process := range list {
+		switch process.State() {
+		case cynosure.Process_Running:
+			return cynosure.Process_Running, ""
+		case cynosure.Process_Failed:
+			if state != cynosure.Process_Failed {
+				state = cynosure.Process_Failed
+				msg = process.Process().GetExitMessage()
+			}
+		}
+	}
+	return state, msg
+}
+
+// active returns the runs whose jobs are still running (must be called without the lock held).
+func (s *scheduler) active(runs []*cynosure.ScheduleRun) (list []*cynosure.ScheduleRun) {
+	for _, run := range runs {
+		// The run may not have been updated yet, so check the job itself.
+		if members := s.m.Group(run.Group); len(members) > 0 {
+			if state, _ := jobState(members); state == cynosure.Process_Running {
+				list = append(list, run)
+			}
+		}
+	}
+	return list
+}
+
+// template returns the start request for a new job of the schedule.
+func (sc *schedule) template() *cynosure.StartRequest {
+	req := proto.Clone(sc.spec.Start).(*cynosure.StartRequest)
+	req.Kind = cynosure.StartRequest_Job
+	if req.Namespace == "" {
+		req.Namespace = sc.spec.Namespace
+	}
+	req.Labels = append(req.Labels, &cynosure.KV{
+		Key:   ScheduleLabel,
+		Value: sc.spec.Name,
+	})
+	return req
+}
+
+func (sc *schedule) disarm() {
+	if sc.timer != nil {
+		sc.timer.Stop()
+		sc.timer = nil
+	}
+}
+
+func (sc *schedule) copy() *cynosure.Schedule {
+	return proto.Clone(sc.spec).(*cynosure.Schedule)
+}
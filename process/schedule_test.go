@@ -0,0 +1,421 @@
+package process
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/norganna/cynosure/proto/cynosure"
+)
+
+// fakeManager is a process manager that starts single process jobs which run until they are finished or stopped.
+type fakeManager struct {
+	ProcessManager
+	sync.Mutex
+
+	started []*cynosure.StartRequest
+	stopped []string
+	// groups[group] = state of the job
+	groups map[string]cynosure.Process_State
+	fail   error
+	// onStart is called (without the lock held) when a job is started.
+	onStart func()
+}
+
+func newFakeManager() *fakeManager {
+	return &fakeManager{
+		groups: map[string]cynosure.Process_State{},
+	}
+}
+
+func (m *fakeManager) Start(req *cynosure.StartRequest) (string, []Processor, error) {
+	m.Lock()
+	if m.fail != nil {
+		m.Unlock()
+		return "", nil, m.fail
+	}
+	group := fmt.Sprintf("job-%d", len(m.started))
+	m.started = append(m.started, req)
+	m.groups[group] = cynosure.Process_Running
+	onStart := m.onStart
+	m.Unlock()
+
+	if onStart != nil {
+		onStart()
+	}
+	return group, nil, nil
+}
+
+func (m *fakeManager) Group(group string) []Processor {
+	m.Lock()
+	defer m.Unlock()
+
+	state, ok := m.groups[group]
+	if !ok {
+		return nil
+	}
+	return []Processor{&fakeJob{id: group + "/0", state: state}}
+}
+
+func (m *fakeManager) Stop(id string) bool {
+	m.Lock()
+	defer m.Unlock()
+
+	group := strings.TrimSuffix(id, "/0")
+	if _, ok := m.groups[group]; !ok {
+		return false
+	}
+	m.groups[group] = cynosure.Process_Failed
+	m.stopped = append(m.stopped, group)
+	return true
+}
+
+// finish sets the state of the job.
+func (m *fakeManager) finish(group string, state cynosure.Process_State) {
+	m.Lock()
+	m.groups[group] = state
+	m.Unlock()
+}
+
+func (m *fakeManager) counts() (started, stopped int) {
+	m.Lock()
+	defer m.Unlock()
+
+	return len(m.started), len(m.stopped)
+}
+
+type fakeJob struct {
+	Processor
+
+	id    string
+	state cynosure.Process_State
+}
+
+func (j *fakeJob) ID() string {
+	return j.id
+}
+
+func (j *fakeJob) State() cynosure.Process_State {
+	return j.state
+}
+
+func (j *fakeJob) Process() *cynosure.Process {
+	return &cynosure.Process{Identifier: j.id, State: j.state, ExitMessage: "exit status 1"}
+}
+
+// soon is a cron schedule that is always due after a delay.
+type soon time.Duration
+
+func (d soon) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(d))
+}
+
+// scheduled returns a request for a schedule that won't tick by itself within a test.
+func scheduled(name string, concurrency cynosure.Schedule_Concurrency) *cynosure.ScheduleRequest {
+	return &cynosure.ScheduleRequest{
+		Namespace:   "test",
+		Name:        name,
+		Cron:        "0 0 1 1 *",
+		Start:       sleeper("60", 1),
+		Concurrency: concurrency,
+	}
+}
+
+// nextAt returns the next time after now that it is hour:minute in the location.
+func nextAt(hour, minute int, loc *time.Location) int64 {
+	now := time.Now().In(loc)
+	next := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, loc)
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next.UnixNano() / int64(time.Millisecond)
+}
+
+func TestScheduleArm(t *testing.T) {
+	m := newFakeManager()
+	s := NewScheduler(m).(*scheduler)
+
+	invalid := []*cynosure.ScheduleRequest{
+		{Namespace: "test", Cron: "* * * * *", Start: sleeper("60", 1)},
+		{Namespace: "test", Name: "nightly", Cron: "* * * * *", Start: &cynosure.StartRequest{}},
+		{Namespace: "test", Name: "nightly", Cron: "not a cron", Start: sleeper("60", 1)},
+		{Namespace: "test", Name: "nightly", Cron: "* * * * *", Timezone: "Not/AZone", Start: sleeper("60", 1)},
+	}
+	for i, req := range invalid {
+		if _, err := s.Schedule(req); err == nil {
+			t.Errorf("invalid request %d was accepted", i)
+		}
+	}
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		timezone string
+		loc      *time.Location
+	}{
+		{"local", "", time.Local},
+		{"timezone", "Asia/Tokyo", tokyo},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := scheduled(tt.name, cynosure.Schedule_Allow)
+			req.Cron = "30 9 * * *"
+			req.Timezone = tt.timezone
+
+			spec, err := s.Schedule(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := nextAt(9, 30, tt.loc); spec.Next != want {
+				t.Errorf("got next %d, want %d", spec.Next, want)
+			}
+		})
+	}
+
+	// A suspended schedule is not armed.
+	req := scheduled("suspended", cynosure.Schedule_Allow)
+	req.Suspended = true
+	spec, err := s.Schedule(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec.Next != 0 || s.schedules["test"]["suspended"].timer != nil {
+		t.Errorf("suspended schedule was armed for %d", spec.Next)
+	}
+
+	// The timer starts a job from the template when the schedule is due.
+	s.Lock()
+	sc := s.schedules["test"]["local"]
+	sc.disarm()
+	sc.cron = soon(50 * time.Millisecond)
+	s.arm(sc)
+	s.Unlock()
+
+	time.Sleep(200 * time.Millisecond)
+	if _, err := s.Suspend("test", "local"); err != nil {
+		t.Fatal(err)
+	}
+	// Let any tick that was already firing finish.
+	time.Sleep(100 * time.Millisecond)
+
+	started, _ := m.counts()
+	if started < 2 {
+		t.Fatalf("got %d jobs started", started)
+	}
+	job := m.started[0]
+	if job.Kind != cynosure.StartRequest_Job || job.Namespace != "test" || len(job.Labels) != 1 ||
+		job.Labels[0].Key != ScheduleLabel || job.Labels[0].Value != "local" {
+		t.Errorf("got job %v", job)
+	}
+	if runs := s.Get("test", "local").Runs; len(runs) != started || runs[0].Group != "job-0" {
+		t.Errorf("got runs %v for %d jobs", runs, started)
+	}
+
+	// Updating a schedule keeps its creation time and run history.
+	prev := s.Get("test", "local")
+	spec, err = s.Schedule(scheduled("local", cynosure.Schedule_Forbid))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec.Created != prev.Created || len(spec.Runs) != len(prev.Runs) || spec.Concurrency != cynosure.Schedule_Forbid {
+		t.Errorf("got %v after updating %v", spec, prev)
+	}
+}
+
+func TestScheduleConcurrency(t *testing.T) {
+	tests := []struct {
+		name        string
+		concurrency cynosure.Schedule_Concurrency
+		started     int
+		stopped     int
+	}{
+		{"allow", cynosure.Schedule_Allow, 2, 0},
+		{"forbid", cynosure.Schedule_Forbid, 1, 0},
+		{"replace", cynosure.Schedule_Replace, 2, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newFakeManager()
+			s := NewScheduler(m).(*scheduler)
+			if _, err := s.Schedule(scheduled("nightly", tt.concurrency)); err != nil {
+				t.Fatal(err)
+			}
+			defer s.Delete("test", "nightly")
+			sc := s.schedules["test"]["nightly"]
+
+			s.tick(sc)
+			s.tick(sc)
+
+			started, stopped := m.counts()
+			if started != tt.started || stopped != tt.stopped {
+				t.Fatalf("got %d started and %d stopped, want %d and %d", started, stopped, tt.started, tt.stopped)
+			}
+
+			runs := s.Get("test", "nightly").Runs
+			if len(runs) != 2 || runs[0].Group != "job-0" {
+				t.Fatalf("got runs %v", runs)
+			}
+			first, second := runs[0], runs[1]
+
+			switch tt.concurrency {
+			case cynosure.Schedule_Allow:
+				if first.State != cynosure.Process_Running || second.Group != "job-1" || second.Skipped {
+					t.Errorf("got runs %v", runs)
+				}
+			case cynosure.Schedule_Forbid:
+				// A skipped run did not fail.
+				if !second.Skipped || second.Group != "" || second.State == cynosure.Process_Failed || second.Finished == 0 {
+					t.Errorf("got skipped run %v", second)
+				}
+
+				// Once the previous job has finished, the next run is started.
+				m.finish("job-0", cynosure.Process_Succeeded)
+				s.tick(sc)
+				runs = s.Get("test", "nightly").Runs
+				if len(runs) != 3 || runs[2].Group != "job-1" || runs[2].Skipped {
+					t.Errorf("got runs %v", runs)
+				}
+			case cynosure.Schedule_Replace:
+				if first.State != cynosure.Process_Failed || first.Message != "replaced by a later run" ||
+					m.stopped[0] != "job-0" || second.Group != "job-1" {
+					t.Errorf("got runs %v with %v stopped", runs, m.stopped)
+				}
+			}
+		})
+	}
+}
+
+func TestScheduleTick(t *testing.T) {
+	m := newFakeManager()
+	s := NewScheduler(m).(*scheduler)
+	if _, err := s.Schedule(scheduled("nightly", cynosure.Schedule_Allow)); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Delete("test", "nightly")
+	sc := s.schedules["test"]["nightly"]
+
+	// The process manager is called without the scheduler locked.
+	m.onStart = func() {
+		got := make(chan bool)
+		go func() {
+			s.Get("test", "nightly")
+			close(got)
+		}()
+		select {
+		case <-got:
+		case <-time.After(time.Second):
+			t.Error("scheduler was locked while starting the job")
+		}
+	}
+	s.tick(sc)
+	m.onStart = nil
+
+	s.RLock()
+	armed := sc.timer != nil && sc.spec.Next > 0
+	s.RUnlock()
+	if !armed {
+		t.Error("next tick was not armed")
+	}
+
+	// The outcome of the job is recorded.
+	m.finish("job-0", cynosure.Process_Failed)
+	time.Sleep(jobPoll + 200*time.Millisecond)
+	run := s.Get("test", "nightly").Runs[0]
+	if run.State != cynosure.Process_Failed || run.Message != "exit status 1" || run.Finished == 0 {
+		t.Errorf("got run %v", run)
+	}
+
+	// A job that fails to start is recorded as a failed run.
+	m.fail = errors.New("no such command")
+	s.tick(sc)
+	run = s.Get("test", "nightly").Runs[1]
+	if run.State != cynosure.Process_Failed || run.Message != "no such command" || run.Group != "" {
+		t.Errorf("got run %v", run)
+	}
+}
+
+func TestScheduleSuspend(t *testing.T) {
+	m := newFakeManager()
+	s := NewScheduler(m).(*scheduler)
+	if _, err := s.Schedule(scheduled("nightly", cynosure.Schedule_Allow)); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Delete("test", "nightly")
+	sc := s.schedules["test"]["nightly"]
+
+	spec, err := s.Suspend("test", "nightly")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !spec.Suspended || spec.Next != 0 || sc.timer != nil {
+		t.Errorf("got suspended %v next %d", spec.Suspended, spec.Next)
+	}
+
+	// A tick that was already firing does nothing.
+	s.tick(sc)
+	if started, _ := m.counts(); started != 0 || len(s.Get("test", "nightly").Runs) != 0 {
+		t.Errorf("suspended schedule started %d jobs", started)
+	}
+
+	spec, err = s.Resume("test", "nightly")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec.Suspended || spec.Next == 0 || sc.timer == nil {
+		t.Errorf("got suspended %v next %d", spec.Suspended, spec.Next)
+	}
+
+	if _, err := s.Suspend("test", "missing"); err != ErrUnknownSchedule {
+		t.Errorf("got error %v, want %v", err, ErrUnknownSchedule)
+	}
+	if _, err := s.Resume("other", "nightly"); err != ErrUnknownSchedule {
+		t.Errorf("got error %v, want %v", err, ErrUnknownSchedule)
+	}
+}
+
+func TestScheduleDelete(t *testing.T) {
+	m := newFakeManager()
+	s := NewScheduler(m).(*scheduler)
+	for _, name := range []string{"weekly", "daily", "hourly"} {
+		if _, err := s.Schedule(scheduled(name, cynosure.Schedule_Allow)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	list := s.List("test")
+	if len(list) != 3 || list[0].Name != "daily" || list[1].Name != "hourly" || list[2].Name != "weekly" {
+		t.Errorf("got list %v", list)
+	}
+
+	sc := s.schedules["test"]["daily"]
+	if !s.Delete("test", "daily") {
+		t.Fatal("schedule was not deleted")
+	}
+	if sc.timer != nil || s.Get("test", "daily") != nil || len(s.List("test")) != 2 {
+		t.Error("deleted schedule was kept")
+	}
+	if s.Delete("test", "daily") {
+		t.Error("deleted schedule was deleted again")
+	}
+
+	// A tick that was already firing does nothing.
+	s.tick(sc)
+	if started, _ := m.counts(); started != 0 {
+		t.Errorf("deleted schedule started %d jobs", started)
+	}
+	if sc.timer != nil {
+		t.Error("deleted schedule was armed")
+	}
+
+	for _, name := range []string{"weekly", "hourly"} {
+		s.Delete("test", name)
+	}
+}
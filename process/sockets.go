@@ -0,0 +1,208 @@
+package process
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/norganna/cynosure/common"
+	"github.com/norganna/cynosure/proto/cynosure"
+)
+
+// ActivateCommand is the hidden command used to start processes with listen sockets.
+//
+// The process is started as `cynosure ActivateCommand ENTRY NAME ARGS...` so that `LISTEN_PID` can be set to its own
+// PID before it replaces itself with the entry-point.
+const ActivateCommand = "__activate"
+
+// listenFDStart is the first file descriptor used for passed sockets.
+const listenFDStart = 3
+
+// socketKey identifies a socket, they are only shared within a group (or by a single process outside of one).
+type socketKey struct {
+	scope   string
+	name    string
+	network string
+	address string
+}
+
+type listenSocket struct {
+	closer io.Closer
+	file   *os.File
+
+	// owners[identity] = true
+	owners map[string]bool
+}
+
+type socketRegistry struct {
+	sync.Mutex
+
+	sockets map[socketKey]*listenSocket
+}
+
+var sockets = &socketRegistry{
+	sockets: map[socketKey]*listenSocket{},
+}
+
+// Acquire binds (or reuses already bound) sockets on behalf of the owner, returning their files in order.
+//
+// Sockets are reused when another owner within the scope holds the same named socket on the same address.
+func (r *socketRegistry) Acquire(owner, scope string, specs []*cynosure.Socket, allocated map[string]int32) ([]*os.File, error) {
+	if len(specs) == 0 {
+		return nil, nil
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	files := make([]*os.File, len(specs))
+	for i, spec := range specs {
+		key := socketKey{
+			scope:   scope,
+			name:    spec.GetName(),
+			network: spec.GetNetwork(),
+			address: expandPorts([]string{spec.GetAddress()}, allocated)[0],
+		}
+		if key.network == "" {
+			key.network = "tcp"
+		}
+
+		s, ok := r.sockets[key]
+		if !ok {
+			var err error
+			s, err = bind(key)
+			if err != nil {
+				r.release(owner)
+				return nil, common.Error(err, "failed to bind socket %s", spec.GetName())
+			}
+			r.sockets[key] = s
+		}
+
+		s.owners[owner] = true
+		files[i] = s.file
+	}
+	return files, nil
+}
+
+// Release closes any sockets that are no longer used by any owner.
+func (r *socketRegistry) Release(owner string) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.release(owner)
+}
+
+func (r *socketRegistry) release(owner string) {
+	for key, s := range r.sockets {
+		if !s.owners[owner] {
+			continue
+		}
+
+		delete(s.owners, owner)
+		if len(s.owners) == 0 {
+			_ = s.file.Close()
+			_ = s.closer.Close()
+			delete(r.sockets, key)
+		}
+	}
+}
+
+type filer interface {
+	io.Closer
+	File() (*os.File, error)
+}
+
+func bind(key socketKey) (*listenSocket, error) {
+	var f filer
+	switch key.network {
+	case "tcp", "tcp4", "tcp6", "unix":
+		l, err := net.Listen(key.network, key.address)
+		if err != nil {
+			return nil, err
+		}
+		f = l.(filer)
+	case "udp", "udp4", "udp6":
+		c, err := net.ListenPacket(key.network, key.address)
+		if err != nil {
+			return nil, err
+		}
+		f = c.(filer)
+	default:
+		return nil, common.ErrorMsg("unsupported socket network %s", key.network)
+	}
+
+	file, err := f.File()
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	return &listenSocket{
+		closer: f,
+		file:   file,
+		owners: map[string]bool{},
+	}, nil
+}
+
+// activate changes the command to pass the listen sockets, starting it through ActivateCommand.
+func (p *proc) activate(cmd *exec.Cmd) {
+	if len(p.sockets) == 0 {
+		return
+	}
+
+	names := make([]string, len(p.sockets))
+	for i, spec := range p.c.GetSockets() {
+		names[i] = spec.GetName()
+		if names[i] == "" {
+			names[i] = "unknown"
+		}
+	}
+
+	cmd.ExtraFiles = p.sockets
+	cmd.Env = append(cmd.Env,
+		fmt.Sprintf("LISTEN_FDS=%d", len(p.sockets)),
+		"LISTEN_FDNAMES="+strings.Join(names, ":"),
+	)
+
+	// Without the shim the process won't have `LISTEN_PID`, but most implementations will still accept the sockets.
+	self, err := os.Executable()
+	if err != nil {
+		_, _ = p.Log().Err().Write([]byte("Failed to find server executable for socket activation: " + err.Error() + "\n"))
+		return
+	}
+
+	cmd.Args = append([]string{self, ActivateCommand, cmd.Path}, cmd.Args...)
+	cmd.Path = self
+}
+
+// Activate is run in place of the server for ActivateCommand, it sets `LISTEN_PID` and executes the entry-point.
+//
+// The args are the entry-point, the name to run it as and its args.
+func Activate(args []string) {
+	if len(args) < 2 {
+		_, _ = fmt.Fprintln(os.Stderr, "Usage: cynosure "+ActivateCommand+" ENTRY NAME ARGS...")
+		os.Exit(127)
+	}
+
+	// The passed sockets must not be closed when the entry-point is executed.
+	n, _ := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	for fd := listenFDStart; fd < listenFDStart+n; fd++ {
+		_, _, _ = syscall.Syscall(syscall.SYS_FCNTL, uintptr(fd), syscall.F_SETFD, 0)
+	}
+
+	_ = os.Setenv("LISTEN_PID", strconv.Itoa(os.Getpid()))
+
+	entry, err := exec.LookPath(args[0])
+	if err == nil {
+		err = syscall.Exec(entry, args[1:], os.Environ())
+	}
+
+	_, _ = fmt.Fprintf(os.Stderr, "Failed to execute %s: %s\n", args[0], err)
+	os.Exit(127)
+}
@@ -0,0 +1,116 @@
+package process
+
+import (
+	"testing"
+
+	"github.com/norganna/cynosure/proto/cynosure"
+)
+
+func TestAllocateKept(t *testing.T) {
+	if err := SetPortRange(42100, 42199); err != nil {
+		t.Fatal(err)
+	}
+
+	names := []string{"http", "admin"}
+	kept := map[string]bool{"http": true}
+
+	old, err := allocator.Allocate("old", "web/0", names, kept)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer allocator.Release("old")
+
+	tests := []struct {
+		name  string
+		owner string
+		slot  string
+		same  map[string]bool
+	}{
+		{"replacement", "new", "web/0", map[string]bool{"http": true}},
+		{"other replica", "replica", "web/1", nil},
+		{"other group", "other", "api/0", nil},
+		{"no group", "single", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ports, err := allocator.Allocate(tt.owner, tt.slot, names, kept)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer allocator.Release(tt.owner)
+
+			for _, name := range names {
+				if same := ports[name] == old[name]; same != tt.same[name] {
+					t.Errorf("port %s: got %d (old %d), want same %v", name, ports[name], old[name], tt.same[name])
+				}
+			}
+		})
+	}
+
+	// Once the last owner releases the port, the slot no longer hands it over.
+	allocator.Release("old")
+	ports, err := allocator.Allocate("later", "web/0", names, kept)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer allocator.Release("later")
+	if ports["http"] == old["http"] {
+		t.Error("released port was handed over")
+	}
+}
+
+func TestAcquireScope(t *testing.T) {
+	specs := []*cynosure.Socket{{Name: "http", Address: "127.0.0.1:0"}}
+	unix := []*cynosure.Socket{{Name: "http", Address: "@cynosure-test-scope", Network: "unix"}}
+
+	first, err := sockets.Acquire("first", "web", unix, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sockets.Release("first")
+
+	tests := []struct {
+		name  string
+		owner string
+		scope string
+		specs []*cynosure.Socket
+		same  bool
+		fails bool
+	}{
+		{"same group", "second", "web", unix, true, false},
+		{"other name", "renamed", "web", []*cynosure.Socket{{Name: "admin", Address: "@cynosure-test-scope", Network: "unix"}}, false, true},
+		{"other group", "other", "api", unix, false, true},
+		{"other address", "tcp", "web", specs, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := sockets.Acquire(tt.owner, tt.scope, tt.specs, nil)
+			if (err != nil) != tt.fails {
+				t.Fatalf("got error %v, want failure %v", err, tt.fails)
+			}
+			if err != nil {
+				return
+			}
+			defer sockets.Release(tt.owner)
+
+			if same := files[0] == first[0]; same != tt.same {
+				t.Errorf("got same socket %v, want %v", same, tt.same)
+			}
+		})
+	}
+}
+
+func TestPortRefs(t *testing.T) {
+	names := []string{"http", "admin-api", "unused"}
+	refs := portRefs(names, []string{":${PORT_HTTP}", "127.0.0.1:$PORT_ADMIN_API", ":8080"})
+
+	want := map[string]bool{"http": true, "admin-api": true}
+	if len(refs) != len(want) {
+		t.Fatalf("got %v, want %v", refs, want)
+	}
+	for name := range want {
+		if !refs[name] {
+			t.Errorf("%s is not referenced", name)
+		}
+	}
+}
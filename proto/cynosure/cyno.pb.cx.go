@@ -17,6 +17,55 @@ const (
     "application/json"
   ],
   "paths": {
+    "/v1/diff/{name}": {
+      "get": {
+        "summary": "Diff shows the changes between two revisions of a named process.",
+        "operationId": "Diff",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/cynosureDiffResponse"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "name",
+            "description": "Name of the process (the ` + "`Command.Name`" + `).",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          },
+          {
+            "name": "namespace",
+            "description": "Namespace the process runs in.",
+            "in": "query",
+            "required": false,
+            "type": "string"
+          },
+          {
+            "name": "from",
+            "description": "From is the older revision to compare (default = the revision before ` + "`To`" + `).",
+            "in": "query",
+            "required": false,
+            "type": "string",
+            "format": "int64"
+          },
+          {
+            "name": "to",
+            "description": "To is the newer revision to compare (default = the current revision).",
+            "in": "query",
+            "required": false,
+            "type": "string",
+            "format": "int64"
+          }
+        ],
+        "tags": [
+          "API"
+        ]
+      }
+    },
     "/v1/environment/{name}": {
       "post": {
         "summary": "Environment allows setting default environment values for all processes started in the specified namespace.",
@@ -51,6 +100,40 @@ const (
         ]
       }
     },
+    "/v1/history/{name}": {
+      "get": {
+        "summary": "History lists the revisions of the ` + "`StartRequest`" + ` that have been deployed for a named process in a namespace.",
+        "description": "The history is saved within the server root, so it is kept across restarts of the server.",
+        "operationId": "History",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/cynosureHistoryResponse"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "name",
+            "description": "Name of the process (the ` + "`Command.Name`" + `).",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          },
+          {
+            "name": "namespace",
+            "description": "Namespace the process runs in.",
+            "in": "query",
+            "required": false,
+            "type": "string"
+          }
+        ],
+        "tags": [
+          "API"
+        ]
+      }
+    },
     "/v1/image/{identity}": {
       "get": {
         "operationId": "Image",
@@ -192,6 +275,74 @@ const (
         ]
       }
     },
+    "/v1/replace/{identifier}": {
+      "post": {
+        "summary": "Replace starts a new specification alongside a running process (or group), only stopping the old process once the new one is ready.",
+        "operationId": "Replace",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/cynosureReplaceResponse"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "identifier",
+            "description": "Identifier of the process to replace, or of the group to replace all of its replicas.",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          },
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/cynosureReplaceRequest"
+            }
+          }
+        ],
+        "tags": [
+          "API"
+        ]
+      }
+    },
+    "/v1/rollback/{name}": {
+      "post": {
+        "summary": "Rollback redeploys a previous revision of a named process.",
+        "operationId": "Rollback",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/cynosureRollbackResponse"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "name",
+            "description": "Name of the process (the ` + "`Command.Name`" + `).",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          },
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/cynosureRollbackRequest"
+            }
+          }
+        ],
+        "tags": [
+          "API"
+        ]
+      }
+    },
     "/v1/running": {
       "get": {
         "summary": "Running will ` + "```return```" + ` a list of running processes that match the requested filter (or all).",
@@ -234,26 +385,32 @@ const (
         ]
       }
     },
-    "/v1/start": {
+    "/v1/scale/{group}": {
       "post": {
-        "summary": "Start creates a new process from the given request.",
-        "operationId": "Start",
+        "summary": "Scale changes the number of replicas running within a process group.",
+        "operationId": "Scale",
         "responses": {
           "200": {
             "description": "A successful response.",
             "schema": {
-              "$ref": "#/definitions/cynosureStartResponse"
+              "$ref": "#/definitions/cynosureScaleResponse"
             }
           }
         },
         "parameters": [
+          {
+            "name": "group",
+            "description": "Group of processes to scale.",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          },
           {
             "name": "body",
-            "description": "Command to run.",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/cynosureCommand"
+              "$ref": "#/definitions/cynosureScaleRequest"
             }
           }
         ],
@@ -262,89 +419,368 @@ const (
         ]
       }
     },
-    "/v1/stop/{identifier}": {
+    "/v1/schedule/{name}": {
       "delete": {
-        "summary": "Stop terminates a specified process.",
-        "operationId": "Stop",
+        "summary": "Unschedule deletes a schedule (jobs that it has already started are left to finish).",
+        "operationId": "Unschedule",
         "responses": {
           "200": {
             "description": "A successful response.",
             "schema": {
-              "$ref": "#/definitions/cynosureStopResponse"
+              "$ref": "#/definitions/cynosureUnscheduleResponse"
             }
           }
         },
         "parameters": [
           {
-            "name": "identifier",
-            "description": "Identifier of the process to terminate.",
+            "name": "name",
+            "description": "Name of the schedule.",
             "in": "path",
             "required": true,
             "type": "string"
+          },
+          {
+            "name": "namespace",
+            "description": "Namespace that the schedule belongs to.",
+            "in": "query",
+            "required": false,
+            "type": "string"
           }
         ],
         "tags": [
           "API"
         ]
-      }
-    }
-  },
-  "definitions": {
-    "FilterOp": {
-      "type": "string",
-      "enum": [
-        "In",
-        "NotIn"
-      ],
-      "default": "In",
-      "description": " - In: In requires that at least one of the values match.\n - NotIn: NotIn requires that none of the values are found."
-    },
-    "WatchState": {
-      "type": "string",
-      "enum": [
-        "Unchanged",
-        "MakeReady",
-        "NotReady"
-      ],
-      "default": "Unchanged",
-      "description": "State changes.\n\n - Unchanged: Unchanged does not change the state of the process (default).\n - MakeReady: MakeReady changes the process state to ready, if not currently not-ready.\n - NotReady: NotReady changes the process state to not-ready, if currently ready."
-    },
-    "cynosureCommand": {
-      "type": "object",
-      "properties": {
-        "name": {
-          "type": "string",
-          "description": "Name will be used as the prefix for the identifier."
-        },
-        "image": {
-          "type": "string",
-          "description": "Image is the uploaded image file to use as the filesystem."
-        },
-        "entry": {
-          "type": "string",
-          "description": "Entry is the command to execute as the entry-point."
+      },
+      "post": {
+        "summary": "Schedule creates (or updates) a schedule that starts a job from a ` + "`StartRequest`" + ` template at each tick of a cron expression.",
+        "operationId": "Schedule",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/cynosureScheduleResponse"
+            }
+          }
         },
-        "args": {
-          "type": "array",
-          "items": {
+        "parameters": [
+          {
+            "name": "name",
+            "description": "Name of the schedule (unique within the namespace).",
+            "in": "path",
+            "required": true,
             "type": "string"
           },
-          "description": "Args are supplied to the executable."
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/cynosureScheduleRequest"
+            }
+          }
+        ],
+        "tags": [
+          "API"
+        ]
+      }
+    },
+    "/v1/schedule/{name}/resume": {
+      "post": {
+        "summary": "Resume continues starting jobs for a suspended schedule (from the next tick).",
+        "operationId": "Resume",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/cynosureScheduleResponse"
+            }
+          }
         },
-        "env": {
-          "type": "array",
-          "items": {
+        "parameters": [
+          {
+            "name": "name",
+            "description": "Name of the schedule.",
+            "in": "path",
+            "required": true,
             "type": "string"
           },
-          "description": "Env provides extra environment variables."
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/cynosureSuspendRequest"
+            }
+          }
+        ],
+        "tags": [
+          "API"
+        ]
+      }
+    },
+    "/v1/schedule/{name}/suspend": {
+      "post": {
+        "summary": "Suspend stops a schedule from starting any more jobs until it is resumed.",
+        "operationId": "Suspend",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/cynosureScheduleResponse"
+            }
+          }
         },
-        "requirements": {
-          "type": "object",
-          "additionalProperties": {
-            "$ref": "#/definitions/cynosureDeps"
+        "parameters": [
+          {
+            "name": "name",
+            "description": "Name of the schedule.",
+            "in": "path",
+            "required": true,
+            "type": "string"
           },
-          "description": "Requirements is a set of dependencies to be met before the command will be run."
-        },
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/cynosureSuspendRequest"
+            }
+          }
+        ],
+        "tags": [
+          "API"
+        ]
+      }
+    },
+    "/v1/schedules": {
+      "get": {
+        "summary": "Schedules lists the schedules (and their run history) in a namespace.",
+        "operationId": "Schedules",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/cynosureSchedulesResponse"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "namespace",
+            "description": "Namespace to list the schedules of.",
+            "in": "query",
+            "required": false,
+            "type": "string"
+          }
+        ],
+        "tags": [
+          "API"
+        ]
+      }
+    },
+    "/v1/start": {
+      "post": {
+        "summary": "Start creates a new process from the given request.",
+        "operationId": "Start",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/cynosureStartResponse"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "body",
+            "description": "Command to run.",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/cynosureCommand"
+            }
+          }
+        ],
+        "tags": [
+          "API"
+        ]
+      }
+    },
+    "/v1/stop/{identifier}": {
+      "delete": {
+        "summary": "Stop terminates a specified process.",
+        "operationId": "Stop",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/cynosureStopResponse"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "identifier",
+            "description": "Identifier of the process to terminate.",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          }
+        ],
+        "tags": [
+          "API"
+        ]
+      }
+    }
+  },
+  "definitions": {
+    "DepsLost": {
+      "type": "string",
+      "enum": [
+        "Ignore",
+        "NotReady",
+        "Stop"
+      ],
+      "default": "Ignore",
+      "description": "Lost policies.\n\n - Ignore: Ignore does nothing if the requirement fails while the process is running (default).\n - NotReady: NotReady marks the process as not-ready until the requirement is met again.\n - Stop: Stop stops the process, which then waits for its requirements to be met before starting again."
+    },
+    "HookResultPoint": {
+      "type": "string",
+      "enum": [
+        "PostStart",
+        "PreStop",
+        "PostExit",
+        "Failed"
+      ],
+      "default": "PostStart",
+      "description": "Point in the life of the process at which hooks are run.\n\n - PostStart: PostStart hooks run once the process becomes ready.\n - PreStop: PreStop hooks run before the process is asked to stop.\n - PostExit: PostExit hooks run after the process exits.\n - Failed: Failed hooks run when the process gives up."
+    },
+    "ScheduleConcurrency": {
+      "type": "string",
+      "enum": [
+        "Allow",
+        "Forbid",
+        "Replace"
+      ],
+      "default": "Allow",
+      "description": "Concurrency policies.\n\n - Allow: Allow starts a new job even if a previous one is still running (default).\n - Forbid: Forbid skips the tick if a previous job is still running.\n - Replace: Replace stops any running jobs before starting the new one."
+    },
+    "StartRequestKind": {
+      "type": "string",
+      "enum": [
+        "Service",
+        "Job"
+      ],
+      "default": "Service",
+      "description": "Kind of process to run.\n\n - Service: Service processes are restarted whenever they exit (default).\n - Job: Job processes run until they complete successfully (or fail too many times)."
+    },
+    "cynosureChange": {
+      "type": "object",
+      "properties": {
+        "path": {
+          "type": "string",
+          "description": "Path to the value that changed, as a JSON pointer (e.g. ` + "`/command/args/1`" + `)."
+        },
+        "from": {
+          "type": "string",
+          "description": "From is the JSON encoded old value (empty if it was added)."
+        },
+        "to": {
+          "type": "string",
+          "description": "To is the JSON encoded new value (empty if it was removed)."
+        }
+      },
+      "description": "Change is a difference in a single value between two revisions."
+    },
+    "cynosureCommand": {
+      "type": "object",
+      "properties": {
+        "name": {
+          "type": "string",
+          "description": "Name will be used as the prefix for the identifier."
+        },
+        "image": {
+          "type": "string",
+          "description": "Image is the uploaded image file to use as the filesystem."
+        },
+        "entry": {
+          "type": "string",
+          "description": "Entry is the command to execute as the entry-point."
+        },
+        "args": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "description": "Args are supplied to the executable."
+        },
+        "env": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "description": "Env provides extra environment variables."
+        },
+        "requirements": {
+          "type": "object",
+          "additionalProperties": {
+            "$ref": "#/definitions/cynosureDeps"
+          },
+          "description": "Requirements is a set of dependencies to be met before the command will be run."
+        },
+        "ports": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "description": "Ports are names of ports to allocate from the server's free range (supplied as ` + "`PORT_{NAME}`" + ` environment values).\n\nAllocated ports may also be referenced within ` + "`Args` as `${PORT_{NAME}}`" + `."
+        },
+        "init": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/cynosureInit"
+          },
+          "description": "Init commands are run in order before the entry-point (each must succeed before the next, or the entry-point, is run)."
+        },
+        "hooks": {
+          "$ref": "#/definitions/cynosureHooks",
+          "description": "Hooks are run at defined points in the life of the process."
+        },
+        "liveness": {
+          "$ref": "#/definitions/cynosureProbe",
+          "description": "Liveness probe restarts the process (through the normal stop path) when it fails too many times in a row."
+        },
+        "readiness": {
+          "$ref": "#/definitions/cynosureProbe",
+          "description": "Readiness probe must be passing (along with any ` + "`MakeReady`" + ` watches) for the process to be ready."
+        },
+        "notify": {
+          "type": "boolean",
+          "format": "boolean",
+          "description": "Notify creates a systemd style notify socket for the process (supplied as ` + "`NOTIFY_SOCKET`" + `).\n\nThe process is not ready until it sends ` + "`READY=1`, `STATUS=` is reported as `Process.Status`" + `,\nand ` + "`MAINPID=`" + ` changes the PID that is reported and signalled.\nMessages (and ` + "`MAINPID=`" + ` values) are only accepted from within the process tree of the command, which includes\nprocesses left running after their parent exited (such as a forked daemon), as the server adopts them. If the\ncommand exits without notifying a ` + "`MAINPID=`" + `, any such processes have 5 seconds to notify one before the process\nis treated as exited. For a command run within an ` + "`image`" + `, the socket is hard-linked into the image at the same\npath."
+        },
+        "watchdog": {
+          "type": "string",
+          "format": "int64",
+          "description": "Watchdog is the number of milliseconds within which a notifying process must send ` + "`WATCHDOG=1`" + ` before it is\nrestarted (supplied as ` + "`WATCHDOG_USEC`" + `, default = none)."
+        },
+        "sockets": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/cynosureSocket"
+          },
+          "description": "Sockets are bound by the server and passed to the process using the ` + "`LISTEN_FDS`" + ` convention (starting at fd 3).\n\nThe sockets stay open while any process uses them, so they are kept across restarts and replacements.\nSockets are only shared within the group, and allocated ports referenced by their address are handed over\nto the replacement of each replica."
+        },
+        "recheck": {
+          "type": "string",
+          "format": "int64",
+          "description": "Recheck is the number of milliseconds between checks of the requirements while the process is running (default = 10000).\n\nOnly requirements with a ` + "`Deps.Lost` policy other than `Ignore`" + ` are rechecked."
+        },
+        "deadline": {
+          "type": "string",
+          "format": "int64",
+          "description": "Deadline is the number of milliseconds to wait for all of the requirements to be met before the process fails\n(default = forever)."
+        },
         "lines": {
           "type": "string",
           "format": "int64",
@@ -358,281 +794,1216 @@ const (
       "properties": {
         "identity": {
           "type": "string",
-          "description": "Identity of the broker to use, defined within the server configuration."
+          "description": "Identity of the broker to use, defined within the server configuration."
+        },
+        "wait": {
+          "type": "string",
+          "description": "Wait is the thing to wait for, how it is interpreted/found is up to the provider."
+        }
+      },
+      "description": "Dep contains dependency requirements."
+    },
+    "cynosureDepStatus": {
+      "type": "object",
+      "properties": {
+        "identity": {
+          "type": "string",
+          "description": "Identity of the broker that was used."
+        },
+        "wait": {
+          "type": "string",
+          "description": "Wait is the thing that was waited for."
+        },
+        "checked": {
+          "type": "boolean",
+          "format": "boolean",
+          "description": "Checked is whether the dependency was checked (those after the first that passes are not)."
+        },
+        "passed": {
+          "type": "boolean",
+          "format": "boolean",
+          "description": "Passed is whether the dependency passed."
+        },
+        "message": {
+          "type": "string",
+          "description": "Message from the check."
+        }
+      },
+      "description": "DepStatus is the result of the last check of a single ` + "`Dep`" + `."
+    },
+    "cynosureDeps": {
+      "type": "object",
+      "properties": {
+        "deps": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/cynosureDep"
+          },
+          "description": "Deps is the list of ` + "`Dep`" + ` items."
+        },
+        "lost": {
+          "$ref": "#/definitions/DepsLost",
+          "description": "Lost determines what happens if the requirement fails after the process has started."
+        },
+        "deadline": {
+          "type": "string",
+          "format": "int64",
+          "description": "Deadline is the number of milliseconds to wait for the requirement to be met before the process fails (default = forever)."
+        },
+        "expr": {
+          "$ref": "#/definitions/cynosureExpr",
+          "description": "Expr is an expression over dependencies that must be met, used instead of the ` + "`deps`" + ` list if supplied."
+        }
+      },
+      "description": "Deps is a list of Dep entries, any of which can fulfil the requirement (or an ` + "`Expr`" + ` over them that must pass)."
+    },
+    "cynosureDiffResponse": {
+      "type": "object",
+      "properties": {
+        "from": {
+          "type": "string",
+          "format": "int64",
+          "description": "From is the older revision that was compared."
+        },
+        "to": {
+          "type": "string",
+          "format": "int64",
+          "description": "To is the newer revision that was compared."
+        },
+        "changes": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/cynosureChange"
+          },
+          "description": "Changes between the ` + "`StartRequest`" + ` of the two revisions."
+        }
+      },
+      "description": "DiffResponse is the output supplied by the ` + "`Diff`" + ` API endpoint."
+    },
+    "cynosureEnvironmentRequest": {
+      "type": "object",
+      "properties": {
+        "name": {
+          "type": "string",
+          "description": "Name of the environment."
+        },
+        "values": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "description": "Values to provide for the namespace (` + "`key=value`" + `)."
+        }
+      },
+      "description": "EnvironmentRequest is the input supplied to the ` + "`Environment`" + ` API endpoint."
+    },
+    "cynosureEnvironmentResponse": {
+      "type": "object",
+      "properties": {
+        "success": {
+          "type": "boolean",
+          "format": "boolean",
+          "description": "Success of the environment request."
+        }
+      },
+      "description": "EnvironmentResponse is the output supplied by the ` + "`Environment`" + ` API endpoint."
+    },
+    "cynosureEvent": {
+      "type": "object",
+      "properties": {
+        "time": {
+          "type": "string",
+          "format": "int64",
+          "description": "Time in milliseconds since epoch of the event."
+        },
+        "reason": {
+          "type": "string",
+          "description": "Reason is a short machine readable name for the event (e.g. ` + "`Ready`, `NotReady`" + `)."
+        },
+        "message": {
+          "type": "string",
+          "description": "Message describes the event."
+        }
+      },
+      "description": "Event records a notable change to a process."
+    },
+    "cynosureExpr": {
+      "type": "object",
+      "properties": {
+        "op": {
+          "$ref": "#/definitions/cynosureExprOp",
+          "description": "Op is the operator of the expression."
+        },
+        "dep": {
+          "$ref": "#/definitions/cynosureDep",
+          "description": "Dep is the dependency to check (for a ` + "`Leaf`" + `)."
+        },
+        "args": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/cynosureExpr"
+          },
+          "description": "Args are the operands of the expression."
+        },
+        "quorum": {
+          "type": "integer",
+          "format": "int32",
+          "description": "Quorum is the number of ` + "`args` that must pass (for a `Quorum`" + `, default = a majority)."
+        }
+      },
+      "description": "Expr is a boolean expression over ` + "`Dep`" + ` items."
+    },
+    "cynosureExprOp": {
+      "type": "string",
+      "enum": [
+        "Leaf",
+        "And",
+        "Or",
+        "Not",
+        "Quorum"
+      ],
+      "default": "Leaf",
+      "description": "Operators.\n\n - Leaf: Leaf passes if the ` + "`dep`" + ` passes (default).\n - And: And passes if all of the ` + "`args`" + ` pass.\n - Or: Or passes if any of the ` + "`args`" + ` pass.\n - Not: Not passes if its single ` + "`args`" + ` item fails.\n - Quorum: Quorum passes if at least ` + "`quorum` of the `args`" + ` pass."
+    },
+    "cynosureExprStatus": {
+      "type": "object",
+      "properties": {
+        "op": {
+          "$ref": "#/definitions/cynosureExprOp",
+          "description": "Op is the operator of the expression."
+        },
+        "dep": {
+          "$ref": "#/definitions/cynosureDepStatus",
+          "description": "Dep is the result of the dependency (for a ` + "`Leaf`" + `)."
+        },
+        "args": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/cynosureExprStatus"
+          },
+          "description": "Args are the results of each of the operands (in order)."
+        },
+        "quorum": {
+          "type": "integer",
+          "format": "int32",
+          "description": "Quorum is the number of operands that had to pass (for a ` + "`Quorum`" + `)."
+        },
+        "checked": {
+          "type": "boolean",
+          "format": "boolean",
+          "description": "Checked is whether the expression was evaluated (operands after the outcome is known are not)."
+        },
+        "passed": {
+          "type": "boolean",
+          "format": "boolean",
+          "description": "Passed is whether the expression passed."
+        },
+        "message": {
+          "type": "string",
+          "description": "Message describing the outcome (for a failure, the branch that is blocking)."
+        }
+      },
+      "description": "ExprStatus is the result of the last evaluation of an ` + "`Expr`" + `."
+    },
+    "cynosureFilter": {
+      "type": "object",
+      "properties": {
+        "type": {
+          "$ref": "#/definitions/cynosureFilterType",
+          "description": "Type of value to filter on."
+        },
+        "op": {
+          "$ref": "#/definitions/cynosureFilterOp",
+          "description": "Op is the matching type."
+        },
+        "values": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "description": "Values to match."
+        },
+        "key": {
+          "type": "string",
+          "description": "Key to match on (type dependant)."
+        }
+      },
+      "description": "Filter expresses how to match a ` + "`Process`" + `."
+    },
+    "cynosureFilterOp": {
+      "type": "string",
+      "enum": [
+        "In",
+        "NotIn"
+      ],
+      "default": "In",
+      "description": " - In: In requires that at least one of the values match.\n - NotIn: NotIn requires that none of the values are found."
+    },
+    "cynosureFilterType": {
+      "type": "string",
+      "enum": [
+        "Namespace",
+        "Label",
+        "Group",
+        "Name",
+        "Identifier"
+      ],
+      "default": "Namespace",
+      "description": "Type is the kind of thing to match on.\n\n - Namespace: Namespace matches on the namespace of the process.\n - Label: Label matches on a label used to start a process (requires a ` + "`Filter.Key`" + `).\n - Group: Group matches on the group identifier of the process.\n - Name: Name matches on the ` + "`Command.Name`" + ` of the process.\n - Identifier: Identifier matches on the identifier of the process."
+    },
+    "cynosureHistoryResponse": {
+      "type": "object",
+      "properties": {
+        "revisions": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/cynosureRevision"
+          },
+          "description": "Revisions that have been deployed, oldest first."
+        }
+      },
+      "description": "HistoryResponse is the output supplied by the ` + "`History`" + ` API endpoint."
+    },
+    "cynosureHook": {
+      "type": "object",
+      "properties": {
+        "entry": {
+          "type": "string",
+          "description": "Entry is the command to execute (for an exec hook)."
+        },
+        "args": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "description": "Args are supplied to the executable."
+        },
+        "env": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "description": "Env provides extra environment variables (in addition to those of the ` + "`Command`" + `)."
+        },
+        "url": {
+          "type": "string",
+          "description": "URL to call (for an HTTP hook), allocated ports may be referenced as ` + "`${PORT_{NAME}}`" + `."
+        },
+        "method": {
+          "type": "string",
+          "description": "Method of the HTTP call (default = GET)."
+        },
+        "timeout": {
+          "type": "string",
+          "format": "int64",
+          "description": "Timeout is the number of milliseconds the hook has to complete (default = 10000)."
+        },
+        "not_ready": {
+          "type": "boolean",
+          "format": "boolean",
+          "description": "NotReady marks the process as not-ready if a ` + "`PostStart`" + ` hook fails (until the next start)."
+        }
+      },
+      "description": "Hook is either a command executed within the instance or an HTTP call to the process."
+    },
+    "cynosureHookResult": {
+      "type": "object",
+      "properties": {
+        "point": {
+          "$ref": "#/definitions/HookResultPoint",
+          "description": "Point at which the hook was run."
+        },
+        "time": {
+          "type": "string",
+          "format": "int64",
+          "description": "Time in milliseconds since epoch that the hook was run."
+        },
+        "duration": {
+          "type": "string",
+          "format": "int64",
+          "description": "Duration in milliseconds that the hook took to complete."
+        },
+        "success": {
+          "type": "boolean",
+          "format": "boolean",
+          "description": "Success is whether the hook completed successfully."
+        },
+        "message": {
+          "type": "string",
+          "description": "Message describes the outcome of the hook."
+        }
+      },
+      "description": "HookResult records the outcome of a hook being run."
+    },
+    "cynosureHooks": {
+      "type": "object",
+      "properties": {
+        "post_start": {
+          "$ref": "#/definitions/cynosureHook",
+          "description": "PostStart is run once the process becomes ready after each start.\nThe process is not reported as ready (e.g. to ` + "`Replace`" + `) until the hook finishes."
+        },
+        "pre_stop": {
+          "$ref": "#/definitions/cynosureHook",
+          "description": "PreStop is run before the process is asked to stop."
+        },
+        "post_exit": {
+          "$ref": "#/definitions/cynosureHook",
+          "description": "PostExit is run after each exit of the process (with its exit code as ` + "`CYNO_EXIT_CODE`" + `)."
+        },
+        "failed": {
+          "$ref": "#/definitions/cynosureHook",
+          "description": "Failed is run when the process gives up (e.g. its requirements were not met before their deadline)."
+        }
+      },
+      "description": "Hooks are run at defined points in the life of a process."
+    },
+    "cynosureImageResponse": {
+      "type": "object",
+      "properties": {
+        "exists": {
+          "type": "boolean",
+          "format": "boolean",
+          "description": "Exists if the image exists in the system."
+        },
+        "success": {
+          "type": "boolean",
+          "format": "boolean",
+          "description": "Success if the image was created."
+        }
+      },
+      "description": "ImageResponse is the output supplied by the ` + "`Image`" + ` API endpoint."
+    },
+    "cynosureInfoResponse": {
+      "type": "object",
+      "properties": {
+        "process": {
+          "$ref": "#/definitions/cynosureProcess",
+          "description": "Process contains the running process that matched the identifier."
+        }
+      },
+      "description": "InfoResponse is the output supplied by the ` + "`Info`" + ` API endpoint."
+    },
+    "cynosureInit": {
+      "type": "object",
+      "properties": {
+        "name": {
+          "type": "string",
+          "description": "Name of the init command (used in log output, default = its position starting at 1)."
+        },
+        "entry": {
+          "type": "string",
+          "description": "Entry is the command to execute."
+        },
+        "args": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "description": "Args are supplied to the executable."
+        },
+        "env": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "description": "Env provides extra environment variables (in addition to those of the ` + "`Command`" + `)."
+        }
+      },
+      "description": "Init is a setup command that is run before the entry-point of a ` + "`Command`" + ` (with the same environment)."
+    },
+    "cynosureJob": {
+      "type": "object",
+      "properties": {
+        "retries": {
+          "type": "integer",
+          "format": "int32",
+          "description": "Retries is the number of times a failed run will be retried before the job fails (default = 0)."
+        },
+        "deadline": {
+          "type": "string",
+          "format": "int64",
+          "description": "Deadline is the number of milliseconds the job has to complete before it is stopped and fails (default = none)."
+        },
+        "parallelism": {
+          "type": "integer",
+          "format": "int32",
+          "description": "Parallelism is the number of instances of the job to run at once (default = 1)."
+        }
+      },
+      "description": "Job contains the completion settings for a ` + "`Job`" + ` kind of process."
+    },
+    "cynosureKV": {
+      "type": "object",
+      "properties": {
+        "key": {
+          "type": "string",
+          "description": "Key of the value."
+        },
+        "value": {
+          "type": "string",
+          "description": "Value of the key."
+        }
+      },
+      "description": "KV is a simple key/value pair."
+    },
+    "cynosureLogEntry": {
+      "type": "object",
+      "properties": {
+        "pos": {
+          "type": "string",
+          "format": "int64",
+          "description": "Pos provides the line number for the log entry (starts at 1)."
+        },
+        "time": {
+          "type": "string",
+          "description": "Time that the log entry was processed."
+        },
+        "source": {
+          "type": "string",
+          "description": "Source of the log entry (` + "`out`, `err`, `debug`, `info`, `warning`, `error`, `fatal`" + `, etc)."
+        },
+        "raw": {
+          "type": "string",
+          "description": "Raw contents of the log line."
+        },
+        "message": {
+          "type": "string",
+          "description": "Message that was parsed out of the raw log line."
+        },
+        "fields": {
+          "type": "string",
+          "description": "Fields that were parsed out of the raw log line."
+        }
+      },
+      "description": "LogEntry of a log line from a process."
+    },
+    "cynosureLogsResponse": {
+      "type": "object",
+      "properties": {
+        "entries": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/cynosureLogEntry"
+          },
+          "title": "Entries of the log as per the request"
+        },
+        "count": {
+          "type": "string",
+          "format": "int64",
+          "description": "Count is the total number of log entries over the life of the process."
+        },
+        "continue": {
+          "type": "string",
+          "description": "Continue can be provided as ` + "`LogRequest.Since`" + ` value to subsequent requests for log tailing."
+        }
+      },
+      "description": "LogsResponse is the output supplied by the ` + "`Logs`" + ` API endpoint."
+    },
+    "cynosureProbe": {
+      "type": "object",
+      "properties": {
+        "dep": {
+          "$ref": "#/definitions/cynosureDep",
+          "description": "Dep is checked using a broker defined within the server configuration."
+        },
+        "exec": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "description": "Exec is a command (entry followed by args) run within the instance, which passes if it exits successfully."
+        },
+        "initial_delay": {
+          "type": "string",
+          "format": "int64",
+          "description": "InitialDelay is the number of milliseconds after start before the first check (default = 0)."
+        },
+        "interval": {
+          "type": "string",
+          "format": "int64",
+          "description": "Interval is the number of milliseconds between checks (default = 10000)."
+        },
+        "timeout": {
+          "type": "string",
+          "format": "int64",
+          "description": "Timeout is the number of milliseconds a check has to pass (default = 1000)."
+        },
+        "failure_threshold": {
+          "type": "integer",
+          "format": "int32",
+          "description": "FailureThreshold is the number of consecutive failed checks before the probe fails (default = 3)."
+        }
+      },
+      "description": "Probe periodically checks the health of a running process.\n\nEither ` + "`Dep` (checked by a broker such as `http` or `port`) or `Exec`" + ` (run within the instance) should be supplied.\nAllocated ports may be referenced within either as ` + "`${PORT_{NAME}}`" + `."
+    },
+    "cynosureProbeStatus": {
+      "type": "object",
+      "properties": {
+        "passing": {
+          "type": "boolean",
+          "format": "boolean",
+          "description": "Passing is whether the probe is currently passing (it fails after ` + "`FailureThreshold`" + ` consecutive failures)."
+        },
+        "failures": {
+          "type": "integer",
+          "format": "int32",
+          "description": "Failures is the number of consecutive failed checks."
+        },
+        "checked": {
+          "type": "string",
+          "format": "int64",
+          "description": "Checked time in milliseconds since epoch of the last check."
+        },
+        "message": {
+          "type": "string",
+          "description": "Message from the last check."
+        },
+        "restarts": {
+          "type": "integer",
+          "format": "int32",
+          "description": "Restarts is the number of times the process has been restarted by the probe."
+        }
+      },
+      "description": "ProbeStatus is the current state of a ` + "`Probe`" + `."
+    },
+    "cynosureProcess": {
+      "type": "object",
+      "properties": {
+        "identifier": {
+          "type": "string",
+          "description": "Identifier is the unique ID that is assigned to this instance of the command."
+        },
+        "namespace": {
+          "type": "string",
+          "description": "Namespace that the process is running in."
+        },
+        "group": {
+          "type": "string",
+          "description": "Group is the identifier shared by all of the replicas started from the same request."
+        },
+        "index": {
+          "type": "integer",
+          "format": "int32",
+          "description": "Index of this replica within its group (starts at 0)."
+        },
+        "labels": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/cynosureKV"
+          },
+          "description": "Labels that were assigned to the process at start."
+        },
+        "pid": {
+          "type": "integer",
+          "format": "int32",
+          "description": "Pid is the process ID of the command."
+        },
+        "started": {
+          "type": "string",
+          "format": "int64",
+          "description": "Started time in milliseconds since epoch that the current PID started."
+        },
+        "running": {
+          "type": "string",
+          "format": "int64",
+          "description": "Running duration in milliseconds that the process has been running."
+        },
+        "ready": {
+          "type": "boolean",
+          "format": "boolean",
+          "description": "Ready is whether the process thinks it's ready (running, with any ` + "`MakeReady`" + ` watches matched and any readiness probe passing)."
+        },
+        "state": {
+          "$ref": "#/definitions/cynosureProcessState",
+          "description": "State of the process."
+        },
+        "attempts": {
+          "type": "integer",
+          "format": "int32",
+          "description": "Attempts is the number of times the command has been run."
+        },
+        "exit_code": {
+          "type": "integer",
+          "format": "int32",
+          "description": "ExitCode of the last run of the command."
+        },
+        "exit_message": {
+          "type": "string",
+          "description": "ExitMessage describes how the last run of the command exited (or why the process failed)."
+        },
+        "finished": {
+          "type": "string",
+          "format": "int64",
+          "description": "Finished time in milliseconds since epoch that the process reached a finished state."
+        },
+        "command": {
+          "$ref": "#/definitions/cynosureCommand",
+          "title": "Command to run (or that is running)"
+        },
+        "ports": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "description": "Ports that are open (TCP/UDP for listening) by the process."
+        },
+        "observations": {
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          },
+          "description": "Observations that have been made by the ` + "`StartRequest.Watches`" + ` (which are supplied at start-up)."
+        },
+        "allocations": {
+          "type": "object",
+          "additionalProperties": {
+            "type": "integer",
+            "format": "int32"
+          },
+          "description": "Allocations are the ports allocated to the ` + "`Command.Ports`" + ` names (released when the process is stopped)."
+        },
+        "hooks": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/cynosureHookResult"
+          },
+          "description": "Hooks are the results of the most recent hooks run for the process (oldest first)."
+        },
+        "liveness": {
+          "$ref": "#/definitions/cynosureProbeStatus",
+          "description": "Liveness is the status of the ` + "`Command.Liveness`" + ` probe."
+        },
+        "readiness": {
+          "$ref": "#/definitions/cynosureProbeStatus",
+          "description": "Readiness is the status of the ` + "`Command.Readiness`" + ` probe."
+        },
+        "events": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/cynosureEvent"
+          },
+          "description": "Events are the most recent notable changes to the process (oldest first)."
+        },
+        "status": {
+          "type": "string",
+          "description": "Status is the last ` + "`STATUS=`" + ` message sent to the notify socket."
+        },
+        "requirements": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/cynosureRequirementStatus"
+          },
+          "description": "Requirements are the results of the last check of each of the ` + "`Command.Requirements`" + ` (ordered by name)."
+        }
+      },
+      "description": "Process information to create a new process or return from a running process."
+    },
+    "cynosureProcessState": {
+      "type": "string",
+      "enum": [
+        "Running",
+        "Succeeded",
+        "Failed"
+      ],
+      "default": "Running",
+      "description": "State of the process.\n\n - Running: Running processes are active, waiting for requirements or waiting to be restarted (default).\n - Succeeded: Succeeded jobs have completed successfully.\n - Failed: Failed processes have given up (e.g. jobs that ran out of retries or passed their deadline)."
+    },
+    "cynosureReplaceRequest": {
+      "type": "object",
+      "properties": {
+        "identifier": {
+          "type": "string",
+          "description": "Identifier of the process to replace, or of the group to replace all of its replicas."
+        },
+        "start": {
+          "$ref": "#/definitions/cynosureStartRequest",
+          "description": "Start is the new specification to run in place of the existing one.\n\nWhen replacing a group, ` + "`StartRequest.Replicas`" + ` (if set) changes the number of replicas."
+        },
+        "deadline": {
+          "type": "string",
+          "format": "int64",
+          "description": "Deadline is the number of milliseconds to wait for a replacement to become ready (default = 60000)."
+        },
+        "surge": {
+          "type": "integer",
+          "format": "int32",
+          "description": "Surge is the number of replicas within a group to replace at a time (default = 1)."
+        }
+      },
+      "description": "ReplaceRequest is the input supplied to the ` + "`Replace`" + ` API endpoint."
+    },
+    "cynosureReplaceResponse": {
+      "type": "object",
+      "properties": {
+        "success": {
+          "type": "boolean",
+          "format": "boolean",
+          "description": "Success is whether all of the replacements became ready (and the old processes were stopped)."
+        },
+        "message": {
+          "type": "string",
+          "description": "Message describes why the replacement failed."
+        },
+        "group": {
+          "type": "string",
+          "description": "Group is the identifier of the group that the processes belong to."
+        },
+        "processes": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/cynosureProcess"
+          },
+          "description": "Processes contains the replicas running within the group after the replacement."
+        }
+      },
+      "description": "ReplaceResponse is the output supplied by the ` + "`Replace`" + ` API endpoint."
+    },
+    "cynosureRequirementStatus": {
+      "type": "object",
+      "properties": {
+        "name": {
+          "type": "string",
+          "description": "Name of the requirement."
+        },
+        "passed": {
+          "type": "boolean",
+          "format": "boolean",
+          "description": "Passed is whether any of the requirement's dependencies passed."
+        },
+        "message": {
+          "type": "string",
+          "description": "Message summarising the check."
+        },
+        "checked": {
+          "type": "string",
+          "format": "int64",
+          "description": "Checked time in milliseconds since epoch of the last check."
+        },
+        "deps": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/cynosureDepStatus"
+          },
+          "description": "Deps are the results of each of the requirement's dependencies (in order)."
+        },
+        "expr": {
+          "$ref": "#/definitions/cynosureExprStatus",
+          "description": "Expr is the evaluated ` + "`Deps.expr`" + ` tree (if the requirement has one)."
+        }
+      },
+      "description": "RequirementStatus is the result of the last check of a requirement."
+    },
+    "cynosureRevision": {
+      "type": "object",
+      "properties": {
+        "revision": {
+          "type": "string",
+          "format": "int64",
+          "description": "Revision number (starts at 1 and increases with each change)."
+        },
+        "time": {
+          "type": "string",
+          "format": "int64",
+          "description": "Time in milliseconds since epoch that the revision was deployed."
+        },
+        "identity": {
+          "type": "string",
+          "description": "Identity is the common name of the client certificate that made the change."
+        },
+        "action": {
+          "type": "string",
+          "description": "Action that created the revision (` + "`start`, `replace` or `rollback`" + `)."
+        },
+        "source": {
+          "type": "string",
+          "format": "int64",
+          "description": "Source is the revision that was redeployed (for a ` + "`rollback`" + ` action)."
+        },
+        "start": {
+          "$ref": "#/definitions/cynosureStartRequest",
+          "description": "Start is the request that was deployed."
+        }
+      },
+      "description": "Revision is a ` + "`StartRequest`" + ` that was deployed for a named process."
+    },
+    "cynosureRollbackRequest": {
+      "type": "object",
+      "properties": {
+        "namespace": {
+          "type": "string",
+          "description": "Namespace the process runs in."
+        },
+        "name": {
+          "type": "string",
+          "description": "Name of the process (the ` + "`Command.Name`" + `)."
+        },
+        "revision": {
+          "type": "string",
+          "format": "int64",
+          "description": "Revision to redeploy (default = the revision before the current one)."
+        },
+        "deadline": {
+          "type": "string",
+          "format": "int64",
+          "description": "Deadline is the number of milliseconds to wait for a replacement to become ready (default = 60000)."
+        },
+        "surge": {
+          "type": "integer",
+          "format": "int32",
+          "description": "Surge is the number of replicas within a group to replace at a time (default = 1)."
+        }
+      },
+      "description": "RollbackRequest is the input supplied to the ` + "`Rollback`" + ` API endpoint."
+    },
+    "cynosureRollbackResponse": {
+      "type": "object",
+      "properties": {
+        "success": {
+          "type": "boolean",
+          "format": "boolean",
+          "description": "Success is whether the revision was redeployed and became ready."
+        },
+        "message": {
+          "type": "string",
+          "description": "Message describes why the rollback failed."
         },
-        "wait": {
+        "revision": {
+          "$ref": "#/definitions/cynosureRevision",
+          "description": "Revision is the new revision that was recorded for the rollback."
+        },
+        "group": {
           "type": "string",
-          "description": "Wait is the thing to wait for, how it is interpreted/found is up to the provider."
+          "description": "Group is the identifier of the group that the processes belong to."
+        },
+        "processes": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/cynosureProcess"
+          },
+          "description": "Processes contains the replicas running within the group after the rollback."
         }
       },
-      "description": "Dep contains dependency requirements."
+      "description": "RollbackResponse is the output supplied by the ` + "`Rollback`" + ` API endpoint."
     },
-    "cynosureDeps": {
+    "cynosureRunningRequest": {
       "type": "object",
       "properties": {
-        "deps": {
+        "filters": {
           "type": "array",
           "items": {
-            "$ref": "#/definitions/cynosureDep"
+            "$ref": "#/definitions/cynosureFilter"
           },
-          "description": "Deps is the list of ` + "`Dep`" + ` items."
+          "description": "Filters allow limiting the processes returned by this call (default = all)."
         }
       },
-      "description": "Deps is a list of Dep entries, any of which can fulfil the requirement."
+      "description": "RunningRequest is the input supplied to the ` + "`Running`" + ` API endpoint."
     },
-    "cynosureEnvironmentRequest": {
+    "cynosureRunningResponse": {
       "type": "object",
       "properties": {
-        "name": {
-          "type": "string",
-          "description": "Name of the environment."
-        },
-        "values": {
+        "processes": {
           "type": "array",
           "items": {
-            "type": "string"
+            "$ref": "#/definitions/cynosureProcess"
           },
-          "description": "Values to provide for the namespace (` + "`key=value`" + `)."
+          "description": "Processes contains the running processes that matched the request ` + "`Filter`" + `."
         }
       },
-      "description": "EnvironmentRequest is the input supplied to the ` + "`Environment`" + ` API endpoint."
+      "description": "RunningResponse is the output supplied by the ` + "`Running`" + ` API endpoint."
     },
-    "cynosureEnvironmentResponse": {
+    "cynosureScaleRequest": {
       "type": "object",
       "properties": {
-        "success": {
-          "type": "boolean",
-          "format": "boolean",
-          "description": "Success of the environment request."
+        "group": {
+          "type": "string",
+          "description": "Group of processes to scale."
+        },
+        "replicas": {
+          "type": "integer",
+          "format": "int32",
+          "description": "Replicas is the number of instances that should be running.\n\nWhen scaling down, the instances with the highest indexes are stopped first."
         }
       },
-      "description": "EnvironmentResponse is the output supplied by the ` + "`Environment`" + ` API endpoint."
+      "description": "ScaleRequest is the input supplied to the ` + "`Scale`" + ` API endpoint."
     },
-    "cynosureFilter": {
+    "cynosureScaleResponse": {
       "type": "object",
       "properties": {
-        "type": {
-          "$ref": "#/definitions/cynosureFilterType",
-          "description": "Type of value to filter on."
-        },
-        "op": {
-          "$ref": "#/definitions/FilterOp",
-          "description": "Op is the matching type."
-        },
-        "values": {
+        "processes": {
           "type": "array",
           "items": {
-            "type": "string"
+            "$ref": "#/definitions/cynosureProcess"
           },
-          "description": "Values to match."
-        },
-        "key": {
-          "type": "string",
-          "description": "Key to match on (type dependant)."
+          "description": "Processes contains the replicas within the group after scaling."
         }
       },
-      "description": "Filter expresses how to match a ` + "`Process`" + `."
-    },
-    "cynosureFilterType": {
-      "type": "string",
-      "enum": [
-        "Namespace",
-        "Label"
-      ],
-      "default": "Namespace",
-      "description": "Type is the kind of thing to match on.\n\n - Namespace: Namespace matches on the namespace of the process.\n - Label: Label matches on a label used to start a process (requires a ` + "`Filter.Key`" + `)."
+      "description": "ScaleResponse is the output supplied by the ` + "`Scale`" + ` API endpoint."
     },
-    "cynosureImageResponse": {
+    "cynosureSchedule": {
       "type": "object",
       "properties": {
-        "exists": {
-          "type": "boolean",
-          "format": "boolean",
-          "description": "Exists if the image exists in the system."
+        "namespace": {
+          "type": "string",
+          "description": "Namespace that the schedule belongs to."
         },
-        "success": {
+        "name": {
+          "type": "string",
+          "description": "Name of the schedule."
+        },
+        "cron": {
+          "type": "string",
+          "description": "Cron expression that determines when jobs are started."
+        },
+        "timezone": {
+          "type": "string",
+          "description": "Timezone the cron expression is evaluated in."
+        },
+        "start": {
+          "$ref": "#/definitions/cynosureStartRequest",
+          "description": "Start is the template used to start each job."
+        },
+        "concurrency": {
+          "$ref": "#/definitions/ScheduleConcurrency",
+          "description": "Concurrency determines what happens when a tick occurs while a previous job is still running."
+        },
+        "suspended": {
           "type": "boolean",
           "format": "boolean",
-          "description": "Success if the image was created."
+          "description": "Suspended schedules do not start jobs until they are resumed."
+        },
+        "created": {
+          "type": "string",
+          "format": "int64",
+          "description": "Created time in milliseconds since epoch that the schedule was created."
+        },
+        "next": {
+          "type": "string",
+          "format": "int64",
+          "description": "Next time in milliseconds since epoch that a job will be started (0 if suspended)."
+        },
+        "runs": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/cynosureScheduleRun"
+          },
+          "description": "Runs are the most recent ticks of the schedule (oldest first)."
         }
       },
-      "description": "ImageResponse is the output supplied by the ` + "`Image`" + ` API endpoint."
+      "description": "Schedule is a cron expression that starts jobs from a ` + "`StartRequest`" + ` template."
     },
-    "cynosureInfoResponse": {
+    "cynosureScheduleRequest": {
       "type": "object",
       "properties": {
-        "process": {
-          "$ref": "#/definitions/cynosureProcess",
-          "description": "Process contains the running process that matched the identifier."
+        "namespace": {
+          "type": "string",
+          "description": "Namespace that the schedule belongs to."
+        },
+        "name": {
+          "type": "string",
+          "description": "Name of the schedule (unique within the namespace)."
+        },
+        "cron": {
+          "type": "string",
+          "description": "Cron expression (` + "`minute hour day-of-month month day-of-week`, or a descriptor such as `@hourly`" + `)."
+        },
+        "timezone": {
+          "type": "string",
+          "description": "Timezone the cron expression is evaluated in (e.g. ` + "`Australia/Brisbane`" + `, default = server local time)."
+        },
+        "start": {
+          "$ref": "#/definitions/cynosureStartRequest",
+          "description": "Start is the template used to start a job at each tick (it is always started as a ` + "`Job`" + ` kind)."
+        },
+        "concurrency": {
+          "$ref": "#/definitions/ScheduleConcurrency",
+          "description": "Concurrency determines what happens when a tick occurs while a previous job is still running."
+        },
+        "suspended": {
+          "type": "boolean",
+          "format": "boolean",
+          "description": "Suspended schedules do not start jobs until they are resumed."
         }
       },
-      "description": "InfoResponse is the output supplied by the ` + "`Info`" + ` API endpoint."
+      "description": "ScheduleRequest is the input supplied to the ` + "`Schedule`" + ` API endpoint."
     },
-    "cynosureKV": {
+    "cynosureScheduleResponse": {
       "type": "object",
       "properties": {
-        "key": {
-          "type": "string",
-          "description": "Key of the value."
-        },
-        "value": {
-          "type": "string",
-          "description": "Value of the key."
+        "schedule": {
+          "$ref": "#/definitions/cynosureSchedule",
+          "description": "Schedule as it now stands."
         }
       },
-      "description": "KV is a simple key/value pair."
+      "description": "ScheduleResponse is the output supplied by the ` + "`Schedule`, `Suspend` and `Resume`" + ` API endpoints."
     },
-    "cynosureLogEntry": {
+    "cynosureScheduleRun": {
       "type": "object",
       "properties": {
-        "pos": {
+        "time": {
           "type": "string",
           "format": "int64",
-          "description": "Pos provides the line number for the log entry (starts at 1)."
+          "description": "Time in milliseconds since epoch of the tick."
         },
-        "time": {
+        "group": {
           "type": "string",
-          "description": "Time that the log entry was processed."
+          "description": "Group is the identifier of the job group that was started (empty if the run was skipped or failed to start)."
         },
-        "source": {
-          "type": "string",
-          "description": "Source of the log entry (` + "`out`, `err`, `debug`, `info`, `warning`, `error`, `fatal`" + `, etc)."
+        "state": {
+          "$ref": "#/definitions/cynosureProcessState",
+          "description": "State of the job (` + "`Running`" + ` until all of its processes have finished, and left unset for a skipped run)."
         },
-        "raw": {
-          "type": "string",
-          "description": "Raw contents of the log line."
+        "skipped": {
+          "type": "boolean",
+          "format": "boolean",
+          "description": "Skipped is whether the tick was skipped because a previous job was still running."
         },
         "message": {
           "type": "string",
-          "description": "Message that was parsed out of the raw log line."
+          "description": "Message describes why the run was skipped, failed to start or failed."
         },
-        "fields": {
+        "finished": {
           "type": "string",
-          "description": "Fields that were parsed out of the raw log line."
+          "format": "int64",
+          "description": "Finished time in milliseconds since epoch that the job finished."
         }
       },
-      "description": "LogEntry of a log line from a process."
+      "description": "ScheduleRun records what happened at a tick of a schedule."
     },
-    "cynosureLogsResponse": {
+    "cynosureSchedulesResponse": {
       "type": "object",
       "properties": {
-        "entries": {
+        "schedules": {
           "type": "array",
           "items": {
-            "$ref": "#/definitions/cynosureLogEntry"
+            "$ref": "#/definitions/cynosureSchedule"
           },
-          "title": "Entries of the log as per the request"
-        },
-        "count": {
-          "type": "string",
-          "format": "int64",
-          "description": "Count is the total number of log entries over the life of the process."
-        },
-        "continue": {
-          "type": "string",
-          "description": "Continue can be provided as ` + "`LogRequest.Since`" + ` value to subsequent requests for log tailing."
+          "description": "Schedules in the namespace (ordered by name)."
         }
       },
-      "description": "LogsResponse is the output supplied by the ` + "`Logs`" + ` API endpoint."
+      "description": "SchedulesResponse is the output supplied by the ` + "`Schedules`" + ` API endpoint."
     },
-    "cynosureProcess": {
+    "cynosureSocket": {
       "type": "object",
       "properties": {
-        "identifier": {
+        "name": {
           "type": "string",
-          "description": "Identifier is the unique ID that is assigned to this instance of the command."
+          "description": "Name of the socket (supplied in ` + "`LISTEN_FDNAMES`" + `)."
         },
-        "namespace": {
+        "address": {
           "type": "string",
-          "description": "Namespace that the process is running in."
-        },
-        "pid": {
-          "type": "integer",
-          "format": "int32",
-          "description": "Pid is the process ID of the command."
+          "description": "Address to bind (e.g. ` + "`:8080`, or a path for a unix socket), allocated ports may be referenced as `${PORT_{NAME}}`" + `."
         },
-        "started": {
+        "network": {
           "type": "string",
-          "format": "int64",
-          "description": "Started time in milliseconds since epoch that the current PID started."
+          "description": "Network is one of ` + "`tcp`, `tcp4`, `tcp6`, `udp`, `udp4`, `udp6` or `unix`" + ` (default = tcp)."
+        }
+      },
+      "description": "Socket is a listen socket that is bound by the server and passed to a process."
+    },
+    "cynosureStartRequest": {
+      "type": "object",
+      "properties": {
+        "command": {
+          "$ref": "#/definitions/cynosureCommand",
+          "description": "Command to run."
         },
-        "running": {
+        "namespace": {
           "type": "string",
-          "format": "int64",
-          "description": "Running duration in milliseconds that the process has been running."
-        },
-        "ready": {
-          "type": "boolean",
-          "format": "boolean",
-          "description": "Ready is whether the process thinks it's ready (determined by watches on start)."
+          "description": "Namespace to run the command in."
         },
-        "command": {
-          "$ref": "#/definitions/cynosureCommand",
-          "title": "Command to run (or that is running)"
+        "labels": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/cynosureKV"
+          },
+          "description": "Labels to assign to the process (allows filtering of processes)."
         },
-        "ports": {
+        "environments": {
           "type": "array",
           "items": {
             "type": "string"
           },
-          "description": "Ports that are open (TCP/UDP for listening) by the process."
+          "description": "Environments to run the command in (stacks environment variables)."
         },
-        "observations": {
+        "replicas": {
+          "type": "integer",
+          "format": "int32",
+          "description": "Replicas is the number of instances of the command to run as a group (default = 1).\n\nEach instance is supplied its index within the group as the ` + "`CYNO_INSTANCE_INDEX`" + ` environment value."
+        },
+        "kind": {
+          "$ref": "#/definitions/StartRequestKind",
+          "description": "Kind of process to run."
+        },
+        "job": {
+          "$ref": "#/definitions/cynosureJob",
+          "description": "Job contains the completion settings for a ` + "`Job`" + ` kind of process.\n\nJobs run ` + "`Job.Parallelism` instances at once (instead of `Replicas`" + `), each of which must complete successfully."
+        },
+        "watches": {
           "type": "object",
           "additionalProperties": {
-            "type": "string"
+            "$ref": "#/definitions/cynosureWatch"
           },
-          "description": "Observations that have been made by the ` + "`StartRequest.Watches`" + ` (which are supplied at start-up)."
+          "description": "Watches allow observation of key log entries and changing the process ready state."
         }
       },
-      "description": "Process information to create a new process or return from a running process."
+      "description": "StartRequest is the input supplied to the ` + "`Start`" + ` API endpoint."
     },
-    "cynosureRunningRequest": {
+    "cynosureStartResponse": {
       "type": "object",
       "properties": {
-        "filters": {
+        "process": {
+          "$ref": "#/definitions/cynosureProcess",
+          "description": "Process contains the details of the process that was started (the first replica, when there are several)."
+        },
+        "group": {
+          "type": "string",
+          "description": "Group is the identifier of the group the started processes belong to."
+        },
+        "processes": {
           "type": "array",
           "items": {
-            "$ref": "#/definitions/cynosureFilter"
+            "$ref": "#/definitions/cynosureProcess"
           },
-          "description": "Filters allow limiting the processes returned by this call (default = all)."
+          "description": "Processes contains the details of all of the replicas that were started."
         }
       },
-      "description": "RunningRequest is the input supplied to the ` + "`Running`" + ` API endpoint."
+      "description": "StartResponse is the output supplied by the ` + "`Start`" + ` API endpoint."
     },
-    "cynosureRunningResponse": {
+    "cynosureStopResponse": {
       "type": "object",
       "properties": {
-        "processes": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/cynosureProcess"
-          },
-          "description": "Processes contains the running processes that matched the request ` + "`Filter`" + `."
+        "success": {
+          "type": "boolean",
+          "format": "boolean",
+          "description": "Success of the stop request."
         }
       },
-      "description": "RunningResponse is the output supplied by the ` + "`Running`" + ` API endpoint."
+      "description": "StopResponse is the output supplied by the ` + "`Stop`" + ` API endpoint."
     },
-    "cynosureStartResponse": {
+    "cynosureSuspendRequest": {
       "type": "object",
       "properties": {
-        "process": {
-          "$ref": "#/definitions/cynosureProcess",
-          "description": "Process contains the details of the process that was started."
+        "namespace": {
+          "type": "string",
+          "description": "Namespace that the schedule belongs to."
+        },
+        "name": {
+          "type": "string",
+          "description": "Name of the schedule."
         }
       },
-      "description": "StartResponse is the output supplied by the ` + "`Start`" + ` API endpoint."
+      "description": "SuspendRequest is the input supplied to the ` + "`Suspend` and `Resume`" + ` API endpoints."
     },
-    "cynosureStopResponse": {
+    "cynosureUnscheduleResponse": {
       "type": "object",
       "properties": {
         "success": {
           "type": "boolean",
           "format": "boolean",
-          "description": "Success of the stop request."
+          "description": "Success is whether the schedule existed and was deleted."
         }
       },
-      "description": "StopResponse is the output supplied by the ` + "`Stop`" + ` API endpoint."
+      "description": "UnscheduleResponse is the output supplied by the ` + "`Unschedule`" + ` API endpoint."
     },
     "cynosureWatch": {
       "type": "object",
@@ -642,11 +2013,21 @@ const (
           "description": "Match is a string to find in the output that triggers this watch."
         },
         "state": {
-          "$ref": "#/definitions/WatchState",
+          "$ref": "#/definitions/cynosureWatchState",
           "description": "State determines whether this match will make the app ready, not, or do nothing."
         }
       },
       "description": "Watch items enable observation of log lines and keep track of running state."
+    },
+    "cynosureWatchState": {
+      "type": "string",
+      "enum": [
+        "Unchanged",
+        "MakeReady",
+        "NotReady"
+      ],
+      "default": "Unchanged",
+      "description": "State changes.\n\n - Unchanged: Unchanged does not change the state of the process (default).\n - MakeReady: MakeReady changes the process state to ready, if not currently not-ready.\n - NotReady: NotReady changes the process state to not-ready, if currently ready."
     }
   },
   "externalDocs": {
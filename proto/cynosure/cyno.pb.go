@@ -27,6 +27,142 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+// Kind of process to run.
+type StartRequest_Kind int32
+
+const (
+	// Service processes are restarted whenever they exit (default).
+	StartRequest_Service StartRequest_Kind = 0
+	// Job processes run until they complete successfully (or fail too many times).
+	StartRequest_Job StartRequest_Kind = 1
+)
+
+var StartRequest_Kind_name = map[int32]string{
+	0: "Service",
+	1: "Job",
+}
+
+var StartRequest_Kind_value = map[string]int32{
+	"Service": 0,
+	"Job":     1,
+}
+
+func (x StartRequest_Kind) String() string {
+	return proto.EnumName(StartRequest_Kind_name, int32(x))
+}
+
+func (StartRequest_Kind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{6, 0}
+}
+
+// Point in the life of the process at which hooks are run.
+type HookResult_Point int32
+
+const (
+	// PostStart hooks run once the process becomes ready.
+	HookResult_PostStart HookResult_Point = 0
+	// PreStop hooks run before the process is asked to stop.
+	HookResult_PreStop HookResult_Point = 1
+	// PostExit hooks run after the process exits.
+	HookResult_PostExit HookResult_Point = 2
+	// Failed hooks run when the process gives up.
+	HookResult_Failed HookResult_Point = 3
+)
+
+var HookResult_Point_name = map[int32]string{
+	0: "PostStart",
+	1: "PreStop",
+	2: "PostExit",
+	3: "Failed",
+}
+
+var HookResult_Point_value = map[string]int32{
+	"PostStart": 0,
+	"PreStop":   1,
+	"PostExit":  2,
+	"Failed":    3,
+}
+
+func (x HookResult_Point) String() string {
+	return proto.EnumName(HookResult_Point_name, int32(x))
+}
+
+func (HookResult_Point) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{36, 0}
+}
+
+// Lost policies.
+type Deps_Lost int32
+
+const (
+	// Ignore does nothing if the requirement fails while the process is running (default).
+	Deps_Ignore Deps_Lost = 0
+	// NotReady marks the process as not-ready until the requirement is met again.
+	Deps_NotReady Deps_Lost = 1
+	// Stop stops the process, which then waits for its requirements to be met before starting again.
+	Deps_Stop Deps_Lost = 2
+)
+
+var Deps_Lost_name = map[int32]string{
+	0: "Ignore",
+	1: "NotReady",
+	2: "Stop",
+}
+
+var Deps_Lost_value = map[string]int32{
+	"Ignore":   0,
+	"NotReady": 1,
+	"Stop":     2,
+}
+
+func (x Deps_Lost) String() string {
+	return proto.EnumName(Deps_Lost_name, int32(x))
+}
+
+func (Deps_Lost) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{45, 0}
+}
+
+// Operators.
+type Expr_Op int32
+
+const (
+	// Leaf passes if the `dep` passes (default).
+	Expr_Leaf Expr_Op = 0
+	// And passes if all of the `args` pass.
+	Expr_And Expr_Op = 1
+	// Or passes if any of the `args` pass.
+	Expr_Or Expr_Op = 2
+	// Not passes if its single `args` item fails.
+	Expr_Not Expr_Op = 3
+	// Quorum passes if at least `quorum` of the `args` pass.
+	Expr_Quorum Expr_Op = 4
+)
+
+var Expr_Op_name = map[int32]string{
+	0: "Leaf",
+	1: "And",
+	2: "Or",
+	3: "Not",
+	4: "Quorum",
+}
+
+var Expr_Op_value = map[string]int32{
+	"Leaf":   0,
+	"And":    1,
+	"Or":     2,
+	"Not":    3,
+	"Quorum": 4,
+}
+
+func (x Expr_Op) String() string {
+	return proto.EnumName(Expr_Op_name, int32(x))
+}
+
+func (Expr_Op) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{46, 0}
+}
+
 // Type is the kind of thing to match on.
 type Filter_Type int32
 
@@ -35,16 +171,28 @@ const (
 	Filter_Namespace Filter_Type = 0
 	// Label matches on a label used to start a process (requires a `Filter.Key`).
 	Filter_Label Filter_Type = 1
+	// Group matches on the group identifier of the process.
+	Filter_Group Filter_Type = 2
+	// Name matches on the `Command.Name` of the process.
+	Filter_Name Filter_Type = 3
+	// Identifier matches on the identifier of the process.
+	Filter_Identifier Filter_Type = 4
 )
 
 var Filter_Type_name = map[int32]string{
 	0: "Namespace",
 	1: "Label",
+	2: "Group",
+	3: "Name",
+	4: "Identifier",
 }
 
 var Filter_Type_value = map[string]int32{
-	"Namespace": 0,
-	"Label":     1,
+	"Namespace":  0,
+	"Label":      1,
+	"Group":      2,
+	"Name":       3,
+	"Identifier": 4,
 }
 
 func (x Filter_Type) String() string {
@@ -52,7 +200,7 @@ func (x Filter_Type) String() string {
 }
 
 func (Filter_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_cf0ba7c91fc7e6c9, []int{17, 0}
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{47, 0}
 }
 
 type Filter_Op int32
@@ -79,7 +227,71 @@ func (x Filter_Op) String() string {
 }
 
 func (Filter_Op) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_cf0ba7c91fc7e6c9, []int{17, 1}
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{47, 1}
+}
+
+// State of the process.
+type Process_State int32
+
+const (
+	// Running processes are active, waiting for requirements or waiting to be restarted (default).
+	Process_Running Process_State = 0
+	// Succeeded jobs have completed successfully.
+	Process_Succeeded Process_State = 1
+	// Failed processes have given up (e.g. jobs that ran out of retries or passed their deadline).
+	Process_Failed Process_State = 2
+)
+
+var Process_State_name = map[int32]string{
+	0: "Running",
+	1: "Succeeded",
+	2: "Failed",
+}
+
+var Process_State_value = map[string]int32{
+	"Running":   0,
+	"Succeeded": 1,
+	"Failed":    2,
+}
+
+func (x Process_State) String() string {
+	return proto.EnumName(Process_State_name, int32(x))
+}
+
+func (Process_State) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{50, 0}
+}
+
+// Concurrency policies.
+type Schedule_Concurrency int32
+
+const (
+	// Allow starts a new job even if a previous one is still running (default).
+	Schedule_Allow Schedule_Concurrency = 0
+	// Forbid skips the tick if a previous job is still running.
+	Schedule_Forbid Schedule_Concurrency = 1
+	// Replace stops any running jobs before starting the new one.
+	Schedule_Replace Schedule_Concurrency = 2
+)
+
+var Schedule_Concurrency_name = map[int32]string{
+	0: "Allow",
+	1: "Forbid",
+	2: "Replace",
+}
+
+var Schedule_Concurrency_value = map[string]int32{
+	"Allow":   0,
+	"Forbid":  1,
+	"Replace": 2,
+}
+
+func (x Schedule_Concurrency) String() string {
+	return proto.EnumName(Schedule_Concurrency_name, int32(x))
+}
+
+func (Schedule_Concurrency) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{53, 0}
 }
 
 // State changes.
@@ -111,7 +323,7 @@ func (x Watch_State) String() string {
 }
 
 func (Watch_State) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_cf0ba7c91fc7e6c9, []int{21, 0}
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{55, 0}
 }
 
 // RunningRequest is the input supplied to the `Running` API endpoint.
@@ -417,6 +629,16 @@ type StartRequest struct {
 	Labels []*KV `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty"`
 	// Environments to run the command in (stacks environment variables).
 	Environments []string `protobuf:"bytes,5,rep,name=environments,proto3" json:"environments,omitempty"`
+	// Replicas is the number of instances of the command to run as a group (default = 1).
+	//
+	// Each instance is supplied its index within the group as the `CYNO_INSTANCE_INDEX` environment value.
+	Replicas int32 `protobuf:"varint,6,opt,name=replicas,proto3" json:"replicas,omitempty"`
+	// Kind of process to run.
+	Kind StartRequest_Kind `protobuf:"varint,7,opt,name=kind,proto3,enum=cynosure.StartRequest_Kind" json:"kind,omitempty"`
+	// Job contains the completion settings for a `Job` kind of process.
+	//
+	// Jobs run `Job.Parallelism` instances at once (instead of `Replicas`), each of which must complete successfully.
+	Job *Job `protobuf:"bytes,8,opt,name=job,proto3" json:"job,omitempty"`
 	// Watches allow observation of key log entries and changing the process ready state.
 	Watches              map[string]*Watch `protobuf:"bytes,10,rep,name=watches,proto3" json:"watches,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
@@ -477,6 +699,27 @@ func (m *StartRequest) GetEnvironments() []string {
 	return nil
 }
 
+func (m *StartRequest) GetReplicas() int32 {
+	if m != nil {
+		return m.Replicas
+	}
+	return 0
+}
+
+func (m *StartRequest) GetKind() StartRequest_Kind {
+	if m != nil {
+		return m.Kind
+	}
+	return StartRequest_Service
+}
+
+func (m *StartRequest) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
 func (m *StartRequest) GetWatches() map[string]*Watch {
 	if m != nil {
 		return m.Watches
@@ -486,11 +729,15 @@ func (m *StartRequest) GetWatches() map[string]*Watch {
 
 // StartResponse is the output supplied by the `Start` API endpoint.
 type StartResponse struct {
-	// Process contains the details of the process that was started.
-	Process              *Process `protobuf:"bytes,1,opt,name=process,proto3" json:"process,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	// Process contains the details of the process that was started (the first replica, when there are several).
+	Process *Process `protobuf:"bytes,1,opt,name=process,proto3" json:"process,omitempty"`
+	// Group is the identifier of the group the started processes belong to.
+	Group string `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
+	// Processes contains the details of all of the replicas that were started.
+	Processes            []*Process `protobuf:"bytes,3,rep,name=processes,proto3" json:"processes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
 func (m *StartResponse) Reset()         { *m = StartResponse{} }
@@ -525,6 +772,20 @@ func (m *StartResponse) GetProcess() *Process {
 	return nil
 }
 
+func (m *StartResponse) GetGroup() string {
+	if m != nil {
+		return m.Group
+	}
+	return ""
+}
+
+func (m *StartResponse) GetProcesses() []*Process {
+	if m != nil {
+		return m.Processes
+	}
+	return nil
+}
+
 // StopRequest is the input supplied to the `Stop` API endpoint.
 type StopRequest struct {
 	// Identifier of the process to terminate.
@@ -607,698 +868,3261 @@ func (m *StopResponse) GetSuccess() bool {
 	return false
 }
 
-// EnvironmentRequest is the input supplied to the `Environment` API endpoint.
-type EnvironmentRequest struct {
-	// Name of the environment.
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	// Values to provide for the namespace (`key=value`).
-	Values               []string `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+// ScaleRequest is the input supplied to the `Scale` API endpoint.
+type ScaleRequest struct {
+	// Group of processes to scale.
+	Group string `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	// Replicas is the number of instances that should be running.
+	//
+	// When scaling down, the instances with the highest indexes are stopped first.
+	Replicas             int32    `protobuf:"varint,2,opt,name=replicas,proto3" json:"replicas,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *EnvironmentRequest) Reset()         { *m = EnvironmentRequest{} }
-func (m *EnvironmentRequest) String() string { return proto.CompactTextString(m) }
-func (*EnvironmentRequest) ProtoMessage()    {}
-func (*EnvironmentRequest) Descriptor() ([]byte, []int) {
+func (m *ScaleRequest) Reset()         { *m = ScaleRequest{} }
+func (m *ScaleRequest) String() string { return proto.CompactTextString(m) }
+func (*ScaleRequest) ProtoMessage()    {}
+func (*ScaleRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_cf0ba7c91fc7e6c9, []int{10}
 }
 
-func (m *EnvironmentRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_EnvironmentRequest.Unmarshal(m, b)
+func (m *ScaleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ScaleRequest.Unmarshal(m, b)
 }
-func (m *EnvironmentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_EnvironmentRequest.Marshal(b, m, deterministic)
+func (m *ScaleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ScaleRequest.Marshal(b, m, deterministic)
 }
-func (m *EnvironmentRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_EnvironmentRequest.Merge(m, src)
+func (m *ScaleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScaleRequest.Merge(m, src)
 }
-func (m *EnvironmentRequest) XXX_Size() int {
-	return xxx_messageInfo_EnvironmentRequest.Size(m)
+func (m *ScaleRequest) XXX_Size() int {
+	return xxx_messageInfo_ScaleRequest.Size(m)
 }
-func (m *EnvironmentRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_EnvironmentRequest.DiscardUnknown(m)
+func (m *ScaleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScaleRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_EnvironmentRequest proto.InternalMessageInfo
+var xxx_messageInfo_ScaleRequest proto.InternalMessageInfo
 
-func (m *EnvironmentRequest) GetName() string {
+func (m *ScaleRequest) GetGroup() string {
 	if m != nil {
-		return m.Name
+		return m.Group
 	}
 	return ""
 }
 
-func (m *EnvironmentRequest) GetValues() []string {
+func (m *ScaleRequest) GetReplicas() int32 {
 	if m != nil {
-		return m.Values
+		return m.Replicas
 	}
-	return nil
+	return 0
 }
 
-// EnvironmentResponse is the output supplied by the `Environment` API endpoint.
-type EnvironmentResponse struct {
-	// Success of the environment request.
-	Success              bool     `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+// ScaleResponse is the output supplied by the `Scale` API endpoint.
+type ScaleResponse struct {
+	// Processes contains the replicas within the group after scaling.
+	Processes            []*Process `protobuf:"bytes,1,rep,name=processes,proto3" json:"processes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
-func (m *EnvironmentResponse) Reset()         { *m = EnvironmentResponse{} }
-func (m *EnvironmentResponse) String() string { return proto.CompactTextString(m) }
-func (*EnvironmentResponse) ProtoMessage()    {}
-func (*EnvironmentResponse) Descriptor() ([]byte, []int) {
+func (m *ScaleResponse) Reset()         { *m = ScaleResponse{} }
+func (m *ScaleResponse) String() string { return proto.CompactTextString(m) }
+func (*ScaleResponse) ProtoMessage()    {}
+func (*ScaleResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_cf0ba7c91fc7e6c9, []int{11}
 }
 
-func (m *EnvironmentResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_EnvironmentResponse.Unmarshal(m, b)
+func (m *ScaleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ScaleResponse.Unmarshal(m, b)
 }
-func (m *EnvironmentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_EnvironmentResponse.Marshal(b, m, deterministic)
+func (m *ScaleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ScaleResponse.Marshal(b, m, deterministic)
 }
-func (m *EnvironmentResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_EnvironmentResponse.Merge(m, src)
+func (m *ScaleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScaleResponse.Merge(m, src)
 }
-func (m *EnvironmentResponse) XXX_Size() int {
-	return xxx_messageInfo_EnvironmentResponse.Size(m)
+func (m *ScaleResponse) XXX_Size() int {
+	return xxx_messageInfo_ScaleResponse.Size(m)
 }
-func (m *EnvironmentResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_EnvironmentResponse.DiscardUnknown(m)
+func (m *ScaleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScaleResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_EnvironmentResponse proto.InternalMessageInfo
+var xxx_messageInfo_ScaleResponse proto.InternalMessageInfo
 
-func (m *EnvironmentResponse) GetSuccess() bool {
+func (m *ScaleResponse) GetProcesses() []*Process {
 	if m != nil {
-		return m.Success
+		return m.Processes
 	}
-	return false
+	return nil
 }
 
-// ImageRequest is the input supplied to the `Image` API endpoint.
-type ImageRequest struct {
-	// Identity of this image.
-	Identity string `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
-	// Image data (a tar.gz of the file system), if supplied creates the stored image.
-	Image                []byte   `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+// ReplaceRequest is the input supplied to the `Replace` API endpoint.
+type ReplaceRequest struct {
+	// Identifier of the process to replace, or of the group to replace all of its replicas.
+	Identifier string `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`
+	// Start is the new specification to run in place of the existing one.
+	//
+	// When replacing a group, `StartRequest.Replicas` (if set) changes the number of replicas.
+	Start *StartRequest `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	// Deadline is the number of milliseconds to wait for a replacement to become ready (default = 60000).
+	Deadline int64 `protobuf:"varint,10,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	// Surge is the number of replicas within a group to replace at a time (default = 1).
+	Surge                int32    `protobuf:"varint,11,opt,name=surge,proto3" json:"surge,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ImageRequest) Reset()         { *m = ImageRequest{} }
-func (m *ImageRequest) String() string { return proto.CompactTextString(m) }
-func (*ImageRequest) ProtoMessage()    {}
-func (*ImageRequest) Descriptor() ([]byte, []int) {
+func (m *ReplaceRequest) Reset()         { *m = ReplaceRequest{} }
+func (m *ReplaceRequest) String() string { return proto.CompactTextString(m) }
+func (*ReplaceRequest) ProtoMessage()    {}
+func (*ReplaceRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_cf0ba7c91fc7e6c9, []int{12}
 }
 
-func (m *ImageRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ImageRequest.Unmarshal(m, b)
+func (m *ReplaceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReplaceRequest.Unmarshal(m, b)
 }
-func (m *ImageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ImageRequest.Marshal(b, m, deterministic)
+func (m *ReplaceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReplaceRequest.Marshal(b, m, deterministic)
 }
-func (m *ImageRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ImageRequest.Merge(m, src)
+func (m *ReplaceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplaceRequest.Merge(m, src)
 }
-func (m *ImageRequest) XXX_Size() int {
-	return xxx_messageInfo_ImageRequest.Size(m)
+func (m *ReplaceRequest) XXX_Size() int {
+	return xxx_messageInfo_ReplaceRequest.Size(m)
 }
-func (m *ImageRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ImageRequest.DiscardUnknown(m)
+func (m *ReplaceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplaceRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ImageRequest proto.InternalMessageInfo
+var xxx_messageInfo_ReplaceRequest proto.InternalMessageInfo
 
-func (m *ImageRequest) GetIdentity() string {
+func (m *ReplaceRequest) GetIdentifier() string {
 	if m != nil {
-		return m.Identity
+		return m.Identifier
 	}
 	return ""
 }
 
-func (m *ImageRequest) GetImage() []byte {
+func (m *ReplaceRequest) GetStart() *StartRequest {
 	if m != nil {
-		return m.Image
+		return m.Start
 	}
 	return nil
 }
 
-// ImageResponse is the output supplied by the `Image` API endpoint.
-type ImageResponse struct {
-	// Exists if the image exists in the system.
-	Exists bool `protobuf:"varint,1,opt,name=exists,proto3" json:"exists,omitempty"`
-	// Success if the image was created.
-	Success              bool     `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *ReplaceRequest) GetDeadline() int64 {
+	if m != nil {
+		return m.Deadline
+	}
+	return 0
 }
 
-func (m *ImageResponse) Reset()         { *m = ImageResponse{} }
-func (m *ImageResponse) String() string { return proto.CompactTextString(m) }
-func (*ImageResponse) ProtoMessage()    {}
-func (*ImageResponse) Descriptor() ([]byte, []int) {
+func (m *ReplaceRequest) GetSurge() int32 {
+	if m != nil {
+		return m.Surge
+	}
+	return 0
+}
+
+// ReplaceResponse is the output supplied by the `Replace` API endpoint.
+type ReplaceResponse struct {
+	// Success is whether all of the replacements became ready (and the old processes were stopped).
+	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// Message describes why the replacement failed.
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// Group is the identifier of the group that the processes belong to.
+	Group string `protobuf:"bytes,3,opt,name=group,proto3" json:"group,omitempty"`
+	// Processes contains the replicas running within the group after the replacement.
+	Processes            []*Process `protobuf:"bytes,4,rep,name=processes,proto3" json:"processes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ReplaceResponse) Reset()         { *m = ReplaceResponse{} }
+func (m *ReplaceResponse) String() string { return proto.CompactTextString(m) }
+func (*ReplaceResponse) ProtoMessage()    {}
+func (*ReplaceResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_cf0ba7c91fc7e6c9, []int{13}
 }
 
-func (m *ImageResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ImageResponse.Unmarshal(m, b)
+func (m *ReplaceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReplaceResponse.Unmarshal(m, b)
 }
-func (m *ImageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ImageResponse.Marshal(b, m, deterministic)
+func (m *ReplaceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReplaceResponse.Marshal(b, m, deterministic)
 }
-func (m *ImageResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ImageResponse.Merge(m, src)
+func (m *ReplaceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplaceResponse.Merge(m, src)
 }
-func (m *ImageResponse) XXX_Size() int {
-	return xxx_messageInfo_ImageResponse.Size(m)
+func (m *ReplaceResponse) XXX_Size() int {
+	return xxx_messageInfo_ReplaceResponse.Size(m)
 }
-func (m *ImageResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ImageResponse.DiscardUnknown(m)
+func (m *ReplaceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplaceResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ImageResponse proto.InternalMessageInfo
+var xxx_messageInfo_ReplaceResponse proto.InternalMessageInfo
 
-func (m *ImageResponse) GetExists() bool {
+func (m *ReplaceResponse) GetSuccess() bool {
 	if m != nil {
-		return m.Exists
+		return m.Success
 	}
 	return false
 }
 
-func (m *ImageResponse) GetSuccess() bool {
+func (m *ReplaceResponse) GetMessage() string {
 	if m != nil {
-		return m.Success
+		return m.Message
 	}
-	return false
+	return ""
 }
 
-// Command contains command information used to start a process and return information about a running command.
-type Command struct {
-	// Name will be used as the prefix for the identifier.
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	// Image is the uploaded image file to use as the filesystem.
-	Image string `protobuf:"bytes,10,opt,name=image,proto3" json:"image,omitempty"`
-	// Entry is the command to execute as the entry-point.
-	Entry string `protobuf:"bytes,11,opt,name=entry,proto3" json:"entry,omitempty"`
-	// Args are supplied to the executable.
-	Args []string `protobuf:"bytes,12,rep,name=args,proto3" json:"args,omitempty"`
-	// Env provides extra environment variables.
-	Env []string `protobuf:"bytes,13,rep,name=env,proto3" json:"env,omitempty"`
-	// Requirements is a set of dependencies to be met before the command will be run.
-	Requirements map[string]*Deps `protobuf:"bytes,14,rep,name=requirements,proto3" json:"requirements,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	// Lines is the number of log entries that have been produced (read-only).
-	Lines                int64    `protobuf:"varint,50,opt,name=lines,proto3" json:"lines,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
+func (m *ReplaceResponse) GetGroup() string {
+	if m != nil {
+		return m.Group
+	}
+	return ""
+}
+
+func (m *ReplaceResponse) GetProcesses() []*Process {
+	if m != nil {
+		return m.Processes
+	}
+	return nil
+}
+
+// HistoryRequest is the input supplied to the `History` API endpoint.
+type HistoryRequest struct {
+	// Namespace the process runs in.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Name of the process (the `Command.Name`).
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Command) Reset()         { *m = Command{} }
-func (m *Command) String() string { return proto.CompactTextString(m) }
-func (*Command) ProtoMessage()    {}
-func (*Command) Descriptor() ([]byte, []int) {
+func (m *HistoryRequest) Reset()         { *m = HistoryRequest{} }
+func (m *HistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*HistoryRequest) ProtoMessage()    {}
+func (*HistoryRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_cf0ba7c91fc7e6c9, []int{14}
 }
 
-func (m *Command) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Command.Unmarshal(m, b)
+func (m *HistoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HistoryRequest.Unmarshal(m, b)
 }
-func (m *Command) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Command.Marshal(b, m, deterministic)
+func (m *HistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HistoryRequest.Marshal(b, m, deterministic)
 }
-func (m *Command) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Command.Merge(m, src)
+func (m *HistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HistoryRequest.Merge(m, src)
 }
-func (m *Command) XXX_Size() int {
-	return xxx_messageInfo_Command.Size(m)
+func (m *HistoryRequest) XXX_Size() int {
+	return xxx_messageInfo_HistoryRequest.Size(m)
 }
-func (m *Command) XXX_DiscardUnknown() {
-	xxx_messageInfo_Command.DiscardUnknown(m)
+func (m *HistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HistoryRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Command proto.InternalMessageInfo
+var xxx_messageInfo_HistoryRequest proto.InternalMessageInfo
 
-func (m *Command) GetName() string {
+func (m *HistoryRequest) GetNamespace() string {
 	if m != nil {
-		return m.Name
+		return m.Namespace
 	}
 	return ""
 }
 
-func (m *Command) GetImage() string {
+func (m *HistoryRequest) GetName() string {
 	if m != nil {
-		return m.Image
+		return m.Name
 	}
 	return ""
 }
 
-func (m *Command) GetEntry() string {
-	if m != nil {
-		return m.Entry
-	}
-	return ""
+// HistoryResponse is the output supplied by the `History` API endpoint.
+type HistoryResponse struct {
+	// Revisions that have been deployed, oldest first.
+	Revisions            []*Revision `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
-func (m *Command) GetArgs() []string {
-	if m != nil {
-		return m.Args
-	}
-	return nil
+func (m *HistoryResponse) Reset()         { *m = HistoryResponse{} }
+func (m *HistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*HistoryResponse) ProtoMessage()    {}
+func (*HistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{15}
 }
 
-func (m *Command) GetEnv() []string {
-	if m != nil {
-		return m.Env
-	}
-	return nil
+func (m *HistoryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HistoryResponse.Unmarshal(m, b)
 }
-
-func (m *Command) GetRequirements() map[string]*Deps {
-	if m != nil {
-		return m.Requirements
-	}
-	return nil
+func (m *HistoryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HistoryResponse.Marshal(b, m, deterministic)
+}
+func (m *HistoryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HistoryResponse.Merge(m, src)
+}
+func (m *HistoryResponse) XXX_Size() int {
+	return xxx_messageInfo_HistoryResponse.Size(m)
+}
+func (m *HistoryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_HistoryResponse.DiscardUnknown(m)
 }
 
-func (m *Command) GetLines() int64 {
+var xxx_messageInfo_HistoryResponse proto.InternalMessageInfo
+
+func (m *HistoryResponse) GetRevisions() []*Revision {
 	if m != nil {
-		return m.Lines
+		return m.Revisions
 	}
-	return 0
+	return nil
 }
 
-// Dep contains dependency requirements.
-type Dep struct {
-	// Identity of the broker to use, defined within the server configuration.
-	Identity string `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
-	// Wait is the thing to wait for, how it is interpreted/found is up to the provider.
-	Wait                 string   `protobuf:"bytes,10,opt,name=wait,proto3" json:"wait,omitempty"`
+// RollbackRequest is the input supplied to the `Rollback` API endpoint.
+type RollbackRequest struct {
+	// Namespace the process runs in.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Name of the process (the `Command.Name`).
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Revision to redeploy (default = the revision before the current one).
+	Revision int64 `protobuf:"varint,3,opt,name=revision,proto3" json:"revision,omitempty"`
+	// Deadline is the number of milliseconds to wait for a replacement to become ready (default = 60000).
+	Deadline int64 `protobuf:"varint,10,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	// Surge is the number of replicas within a group to replace at a time (default = 1).
+	Surge                int32    `protobuf:"varint,11,opt,name=surge,proto3" json:"surge,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Dep) Reset()         { *m = Dep{} }
-func (m *Dep) String() string { return proto.CompactTextString(m) }
-func (*Dep) ProtoMessage()    {}
-func (*Dep) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cf0ba7c91fc7e6c9, []int{15}
+func (m *RollbackRequest) Reset()         { *m = RollbackRequest{} }
+func (m *RollbackRequest) String() string { return proto.CompactTextString(m) }
+func (*RollbackRequest) ProtoMessage()    {}
+func (*RollbackRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{16}
 }
 
-func (m *Dep) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Dep.Unmarshal(m, b)
+func (m *RollbackRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RollbackRequest.Unmarshal(m, b)
 }
-func (m *Dep) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Dep.Marshal(b, m, deterministic)
+func (m *RollbackRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RollbackRequest.Marshal(b, m, deterministic)
 }
-func (m *Dep) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Dep.Merge(m, src)
+func (m *RollbackRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RollbackRequest.Merge(m, src)
 }
-func (m *Dep) XXX_Size() int {
-	return xxx_messageInfo_Dep.Size(m)
+func (m *RollbackRequest) XXX_Size() int {
+	return xxx_messageInfo_RollbackRequest.Size(m)
 }
-func (m *Dep) XXX_DiscardUnknown() {
-	xxx_messageInfo_Dep.DiscardUnknown(m)
+func (m *RollbackRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RollbackRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Dep proto.InternalMessageInfo
+var xxx_messageInfo_RollbackRequest proto.InternalMessageInfo
 
-func (m *Dep) GetIdentity() string {
+func (m *RollbackRequest) GetNamespace() string {
 	if m != nil {
-		return m.Identity
+		return m.Namespace
 	}
 	return ""
 }
 
-func (m *Dep) GetWait() string {
+func (m *RollbackRequest) GetName() string {
 	if m != nil {
-		return m.Wait
+		return m.Name
 	}
 	return ""
 }
 
-// Deps is a list of Dep entries, any of which can fulfil the requirement.
-type Deps struct {
-	// Deps is the list of `Dep` items.
-	Deps                 []*Dep   `protobuf:"bytes,1,rep,name=deps,proto3" json:"deps,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
-
-func (m *Deps) Reset()         { *m = Deps{} }
-func (m *Deps) String() string { return proto.CompactTextString(m) }
-func (*Deps) ProtoMessage()    {}
-func (*Deps) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cf0ba7c91fc7e6c9, []int{16}
+func (m *RollbackRequest) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
 }
 
-func (m *Deps) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Deps.Unmarshal(m, b)
-}
-func (m *Deps) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Deps.Marshal(b, m, deterministic)
-}
-func (m *Deps) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Deps.Merge(m, src)
-}
-func (m *Deps) XXX_Size() int {
-	return xxx_messageInfo_Deps.Size(m)
-}
-func (m *Deps) XXX_DiscardUnknown() {
-	xxx_messageInfo_Deps.DiscardUnknown(m)
+func (m *RollbackRequest) GetDeadline() int64 {
+	if m != nil {
+		return m.Deadline
+	}
+	return 0
 }
 
-var xxx_messageInfo_Deps proto.InternalMessageInfo
-
-func (m *Deps) GetDeps() []*Dep {
+func (m *RollbackRequest) GetSurge() int32 {
 	if m != nil {
-		return m.Deps
+		return m.Surge
 	}
-	return nil
+	return 0
 }
 
-// Filter expresses how to match a `Process`.
-type Filter struct {
-	// Type of value to filter on.
-	Type Filter_Type `protobuf:"varint,1,opt,name=type,proto3,enum=cynosure.Filter_Type" json:"type,omitempty"`
-	// Op is the matching type.
-	Op Filter_Op `protobuf:"varint,2,opt,name=op,proto3,enum=cynosure.Filter_Op" json:"op,omitempty"`
-	// Values to match.
-	Values []string `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`
-	// Key to match on (type dependant).
-	Key                  string   `protobuf:"bytes,10,opt,name=key,proto3" json:"key,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+// RollbackResponse is the output supplied by the `Rollback` API endpoint.
+type RollbackResponse struct {
+	// Success is whether the revision was redeployed and became ready.
+	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// Message describes why the rollback failed.
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// Revision is the new revision that was recorded for the rollback.
+	Revision *Revision `protobuf:"bytes,3,opt,name=revision,proto3" json:"revision,omitempty"`
+	// Group is the identifier of the group that the processes belong to.
+	Group string `protobuf:"bytes,4,opt,name=group,proto3" json:"group,omitempty"`
+	// Processes contains the replicas running within the group after the rollback.
+	Processes            []*Process `protobuf:"bytes,5,rep,name=processes,proto3" json:"processes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
-func (m *Filter) Reset()         { *m = Filter{} }
-func (m *Filter) String() string { return proto.CompactTextString(m) }
-func (*Filter) ProtoMessage()    {}
-func (*Filter) Descriptor() ([]byte, []int) {
+func (m *RollbackResponse) Reset()         { *m = RollbackResponse{} }
+func (m *RollbackResponse) String() string { return proto.CompactTextString(m) }
+func (*RollbackResponse) ProtoMessage()    {}
+func (*RollbackResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_cf0ba7c91fc7e6c9, []int{17}
 }
 
-func (m *Filter) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Filter.Unmarshal(m, b)
+func (m *RollbackResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RollbackResponse.Unmarshal(m, b)
 }
-func (m *Filter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Filter.Marshal(b, m, deterministic)
+func (m *RollbackResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RollbackResponse.Marshal(b, m, deterministic)
 }
-func (m *Filter) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Filter.Merge(m, src)
+func (m *RollbackResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RollbackResponse.Merge(m, src)
 }
-func (m *Filter) XXX_Size() int {
-	return xxx_messageInfo_Filter.Size(m)
+func (m *RollbackResponse) XXX_Size() int {
+	return xxx_messageInfo_RollbackResponse.Size(m)
 }
-func (m *Filter) XXX_DiscardUnknown() {
-	xxx_messageInfo_Filter.DiscardUnknown(m)
+func (m *RollbackResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RollbackResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Filter proto.InternalMessageInfo
+var xxx_messageInfo_RollbackResponse proto.InternalMessageInfo
 
-func (m *Filter) GetType() Filter_Type {
+func (m *RollbackResponse) GetSuccess() bool {
 	if m != nil {
-		return m.Type
+		return m.Success
 	}
-	return Filter_Namespace
+	return false
 }
 
-func (m *Filter) GetOp() Filter_Op {
+func (m *RollbackResponse) GetMessage() string {
 	if m != nil {
-		return m.Op
+		return m.Message
 	}
-	return Filter_In
+	return ""
 }
 
-func (m *Filter) GetValues() []string {
+func (m *RollbackResponse) GetRevision() *Revision {
 	if m != nil {
-		return m.Values
+		return m.Revision
 	}
 	return nil
 }
 
-func (m *Filter) GetKey() string {
+func (m *RollbackResponse) GetGroup() string {
 	if m != nil {
-		return m.Key
+		return m.Group
 	}
 	return ""
 }
 
-// KV is a simple key/value pair.
-type KV struct {
-	// Key of the value.
-	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	// Value of the key.
-	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+func (m *RollbackResponse) GetProcesses() []*Process {
+	if m != nil {
+		return m.Processes
+	}
+	return nil
+}
+
+// DiffRequest is the input supplied to the `Diff` API endpoint.
+type DiffRequest struct {
+	// Namespace the process runs in.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Name of the process (the `Command.Name`).
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// From is the older revision to compare (default = the revision before `To`).
+	From int64 `protobuf:"varint,3,opt,name=from,proto3" json:"from,omitempty"`
+	// To is the newer revision to compare (default = the current revision).
+	To                   int64    `protobuf:"varint,4,opt,name=to,proto3" json:"to,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *KV) Reset()         { *m = KV{} }
-func (m *KV) String() string { return proto.CompactTextString(m) }
-func (*KV) ProtoMessage()    {}
-func (*KV) Descriptor() ([]byte, []int) {
+func (m *DiffRequest) Reset()         { *m = DiffRequest{} }
+func (m *DiffRequest) String() string { return proto.CompactTextString(m) }
+func (*DiffRequest) ProtoMessage()    {}
+func (*DiffRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_cf0ba7c91fc7e6c9, []int{18}
 }
 
-func (m *KV) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_KV.Unmarshal(m, b)
+func (m *DiffRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DiffRequest.Unmarshal(m, b)
 }
-func (m *KV) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_KV.Marshal(b, m, deterministic)
+func (m *DiffRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DiffRequest.Marshal(b, m, deterministic)
 }
-func (m *KV) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_KV.Merge(m, src)
+func (m *DiffRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiffRequest.Merge(m, src)
 }
-func (m *KV) XXX_Size() int {
-	return xxx_messageInfo_KV.Size(m)
+func (m *DiffRequest) XXX_Size() int {
+	return xxx_messageInfo_DiffRequest.Size(m)
 }
-func (m *KV) XXX_DiscardUnknown() {
-	xxx_messageInfo_KV.DiscardUnknown(m)
+func (m *DiffRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiffRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_KV proto.InternalMessageInfo
+var xxx_messageInfo_DiffRequest proto.InternalMessageInfo
 
-func (m *KV) GetKey() string {
+func (m *DiffRequest) GetNamespace() string {
 	if m != nil {
-		return m.Key
+		return m.Namespace
 	}
 	return ""
 }
 
-func (m *KV) GetValue() string {
+func (m *DiffRequest) GetName() string {
 	if m != nil {
-		return m.Value
+		return m.Name
 	}
 	return ""
 }
 
-// LogEntry of a log line from a process.
-type LogEntry struct {
-	// Pos provides the line number for the log entry (starts at 1).
-	Pos int64 `protobuf:"varint,1,opt,name=pos,proto3" json:"pos,omitempty"`
-	// Time that the log entry was processed.
-	Time string `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
-	// Source of the log entry (`out`, `err`, `debug`, `info`, `warning`, `error`, `fatal`, etc).
-	Source string `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`
-	// Raw contents of the log line.
-	Raw string `protobuf:"bytes,4,opt,name=raw,proto3" json:"raw,omitempty"`
-	// Message that was parsed out of the raw log line.
-	Message string `protobuf:"bytes,10,opt,name=message,proto3" json:"message,omitempty"`
-	// Fields that were parsed out of the raw log line.
-	Fields               string   `protobuf:"bytes,11,opt,name=fields,proto3" json:"fields,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *DiffRequest) GetFrom() int64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
 }
 
-func (m *LogEntry) Reset()         { *m = LogEntry{} }
-func (m *LogEntry) String() string { return proto.CompactTextString(m) }
-func (*LogEntry) ProtoMessage()    {}
-func (*LogEntry) Descriptor() ([]byte, []int) {
+func (m *DiffRequest) GetTo() int64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+// DiffResponse is the output supplied by the `Diff` API endpoint.
+type DiffResponse struct {
+	// From is the older revision that was compared.
+	From int64 `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	// To is the newer revision that was compared.
+	To int64 `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
+	// Changes between the `StartRequest` of the two revisions.
+	Changes              []*Change `protobuf:"bytes,3,rep,name=changes,proto3" json:"changes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *DiffResponse) Reset()         { *m = DiffResponse{} }
+func (m *DiffResponse) String() string { return proto.CompactTextString(m) }
+func (*DiffResponse) ProtoMessage()    {}
+func (*DiffResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_cf0ba7c91fc7e6c9, []int{19}
 }
 
-func (m *LogEntry) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_LogEntry.Unmarshal(m, b)
+func (m *DiffResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DiffResponse.Unmarshal(m, b)
 }
-func (m *LogEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_LogEntry.Marshal(b, m, deterministic)
+func (m *DiffResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DiffResponse.Marshal(b, m, deterministic)
 }
-func (m *LogEntry) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LogEntry.Merge(m, src)
+func (m *DiffResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiffResponse.Merge(m, src)
 }
-func (m *LogEntry) XXX_Size() int {
-	return xxx_messageInfo_LogEntry.Size(m)
+func (m *DiffResponse) XXX_Size() int {
+	return xxx_messageInfo_DiffResponse.Size(m)
 }
-func (m *LogEntry) XXX_DiscardUnknown() {
-	xxx_messageInfo_LogEntry.DiscardUnknown(m)
+func (m *DiffResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiffResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DiffResponse proto.InternalMessageInfo
+
+func (m *DiffResponse) GetFrom() int64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *DiffResponse) GetTo() int64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+func (m *DiffResponse) GetChanges() []*Change {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+// ScheduleRequest is the input supplied to the `Schedule` API endpoint.
+type ScheduleRequest struct {
+	// Namespace that the schedule belongs to.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Name of the schedule (unique within the namespace).
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Cron expression (`minute hour day-of-month month day-of-week`, or a descriptor such as `@hourly`).
+	Cron string `protobuf:"bytes,3,opt,name=cron,proto3" json:"cron,omitempty"`
+	// Timezone the cron expression is evaluated in (e.g. `Australia/Brisbane`, default = server local time).
+	Timezone string `protobuf:"bytes,4,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	// Start is the template used to start a job at each tick (it is always started as a `Job` kind).
+	Start *StartRequest `protobuf:"bytes,5,opt,name=start,proto3" json:"start,omitempty"`
+	// Concurrency determines what happens when a tick occurs while a previous job is still running.
+	Concurrency Schedule_Concurrency `protobuf:"varint,6,opt,name=concurrency,proto3,enum=cynosure.Schedule_Concurrency" json:"concurrency,omitempty"`
+	// Suspended schedules do not start jobs until they are resumed.
+	Suspended            bool     `protobuf:"varint,7,opt,name=suspended,proto3" json:"suspended,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ScheduleRequest) Reset()         { *m = ScheduleRequest{} }
+func (m *ScheduleRequest) String() string { return proto.CompactTextString(m) }
+func (*ScheduleRequest) ProtoMessage()    {}
+func (*ScheduleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{20}
+}
+
+func (m *ScheduleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ScheduleRequest.Unmarshal(m, b)
+}
+func (m *ScheduleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ScheduleRequest.Marshal(b, m, deterministic)
+}
+func (m *ScheduleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScheduleRequest.Merge(m, src)
+}
+func (m *ScheduleRequest) XXX_Size() int {
+	return xxx_messageInfo_ScheduleRequest.Size(m)
+}
+func (m *ScheduleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScheduleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScheduleRequest proto.InternalMessageInfo
+
+func (m *ScheduleRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *ScheduleRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ScheduleRequest) GetCron() string {
+	if m != nil {
+		return m.Cron
+	}
+	return ""
+}
+
+func (m *ScheduleRequest) GetTimezone() string {
+	if m != nil {
+		return m.Timezone
+	}
+	return ""
+}
+
+func (m *ScheduleRequest) GetStart() *StartRequest {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *ScheduleRequest) GetConcurrency() Schedule_Concurrency {
+	if m != nil {
+		return m.Concurrency
+	}
+	return Schedule_Allow
+}
+
+func (m *ScheduleRequest) GetSuspended() bool {
+	if m != nil {
+		return m.Suspended
+	}
+	return false
+}
+
+// ScheduleResponse is the output supplied by the `Schedule`, `Suspend` and `Resume` API endpoints.
+type ScheduleResponse struct {
+	// Schedule as it now stands.
+	Schedule             *Schedule `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ScheduleResponse) Reset()         { *m = ScheduleResponse{} }
+func (m *ScheduleResponse) String() string { return proto.CompactTextString(m) }
+func (*ScheduleResponse) ProtoMessage()    {}
+func (*ScheduleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{21}
+}
+
+func (m *ScheduleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ScheduleResponse.Unmarshal(m, b)
+}
+func (m *ScheduleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ScheduleResponse.Marshal(b, m, deterministic)
+}
+func (m *ScheduleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScheduleResponse.Merge(m, src)
+}
+func (m *ScheduleResponse) XXX_Size() int {
+	return xxx_messageInfo_ScheduleResponse.Size(m)
+}
+func (m *ScheduleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScheduleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScheduleResponse proto.InternalMessageInfo
+
+func (m *ScheduleResponse) GetSchedule() *Schedule {
+	if m != nil {
+		return m.Schedule
+	}
+	return nil
+}
+
+// SchedulesRequest is the input supplied to the `Schedules` API endpoint.
+type SchedulesRequest struct {
+	// Namespace to list the schedules of.
+	Namespace            string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SchedulesRequest) Reset()         { *m = SchedulesRequest{} }
+func (m *SchedulesRequest) String() string { return proto.CompactTextString(m) }
+func (*SchedulesRequest) ProtoMessage()    {}
+func (*SchedulesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{22}
+}
+
+func (m *SchedulesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SchedulesRequest.Unmarshal(m, b)
+}
+func (m *SchedulesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SchedulesRequest.Marshal(b, m, deterministic)
+}
+func (m *SchedulesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchedulesRequest.Merge(m, src)
+}
+func (m *SchedulesRequest) XXX_Size() int {
+	return xxx_messageInfo_SchedulesRequest.Size(m)
+}
+func (m *SchedulesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchedulesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SchedulesRequest proto.InternalMessageInfo
+
+func (m *SchedulesRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+// SchedulesResponse is the output supplied by the `Schedules` API endpoint.
+type SchedulesResponse struct {
+	// Schedules in the namespace (ordered by name).
+	Schedules            []*Schedule `protobuf:"bytes,1,rep,name=schedules,proto3" json:"schedules,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *SchedulesResponse) Reset()         { *m = SchedulesResponse{} }
+func (m *SchedulesResponse) String() string { return proto.CompactTextString(m) }
+func (*SchedulesResponse) ProtoMessage()    {}
+func (*SchedulesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{23}
+}
+
+func (m *SchedulesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SchedulesResponse.Unmarshal(m, b)
+}
+func (m *SchedulesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SchedulesResponse.Marshal(b, m, deterministic)
+}
+func (m *SchedulesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchedulesResponse.Merge(m, src)
+}
+func (m *SchedulesResponse) XXX_Size() int {
+	return xxx_messageInfo_SchedulesResponse.Size(m)
+}
+func (m *SchedulesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchedulesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SchedulesResponse proto.InternalMessageInfo
+
+func (m *SchedulesResponse) GetSchedules() []*Schedule {
+	if m != nil {
+		return m.Schedules
+	}
+	return nil
+}
+
+// SuspendRequest is the input supplied to the `Suspend` and `Resume` API endpoints.
+type SuspendRequest struct {
+	// Namespace that the schedule belongs to.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Name of the schedule.
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SuspendRequest) Reset()         { *m = SuspendRequest{} }
+func (m *SuspendRequest) String() string { return proto.CompactTextString(m) }
+func (*SuspendRequest) ProtoMessage()    {}
+func (*SuspendRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{24}
+}
+
+func (m *SuspendRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SuspendRequest.Unmarshal(m, b)
+}
+func (m *SuspendRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SuspendRequest.Marshal(b, m, deterministic)
+}
+func (m *SuspendRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SuspendRequest.Merge(m, src)
+}
+func (m *SuspendRequest) XXX_Size() int {
+	return xxx_messageInfo_SuspendRequest.Size(m)
+}
+func (m *SuspendRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SuspendRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SuspendRequest proto.InternalMessageInfo
+
+func (m *SuspendRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *SuspendRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// UnscheduleRequest is the input supplied to the `Unschedule` API endpoint.
+type UnscheduleRequest struct {
+	// Namespace that the schedule belongs to.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Name of the schedule.
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnscheduleRequest) Reset()         { *m = UnscheduleRequest{} }
+func (m *UnscheduleRequest) String() string { return proto.CompactTextString(m) }
+func (*UnscheduleRequest) ProtoMessage()    {}
+func (*UnscheduleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{25}
+}
+
+func (m *UnscheduleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnscheduleRequest.Unmarshal(m, b)
+}
+func (m *UnscheduleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnscheduleRequest.Marshal(b, m, deterministic)
+}
+func (m *UnscheduleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnscheduleRequest.Merge(m, src)
+}
+func (m *UnscheduleRequest) XXX_Size() int {
+	return xxx_messageInfo_UnscheduleRequest.Size(m)
+}
+func (m *UnscheduleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnscheduleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnscheduleRequest proto.InternalMessageInfo
+
+func (m *UnscheduleRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *UnscheduleRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// UnscheduleResponse is the output supplied by the `Unschedule` API endpoint.
+type UnscheduleResponse struct {
+	// Success is whether the schedule existed and was deleted.
+	Success              bool     `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnscheduleResponse) Reset()         { *m = UnscheduleResponse{} }
+func (m *UnscheduleResponse) String() string { return proto.CompactTextString(m) }
+func (*UnscheduleResponse) ProtoMessage()    {}
+func (*UnscheduleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{26}
+}
+
+func (m *UnscheduleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnscheduleResponse.Unmarshal(m, b)
+}
+func (m *UnscheduleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnscheduleResponse.Marshal(b, m, deterministic)
+}
+func (m *UnscheduleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnscheduleResponse.Merge(m, src)
+}
+func (m *UnscheduleResponse) XXX_Size() int {
+	return xxx_messageInfo_UnscheduleResponse.Size(m)
+}
+func (m *UnscheduleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnscheduleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnscheduleResponse proto.InternalMessageInfo
+
+func (m *UnscheduleResponse) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+// EnvironmentRequest is the input supplied to the `Environment` API endpoint.
+type EnvironmentRequest struct {
+	// Name of the environment.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Values to provide for the namespace (`key=value`).
+	Values               []string `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EnvironmentRequest) Reset()         { *m = EnvironmentRequest{} }
+func (m *EnvironmentRequest) String() string { return proto.CompactTextString(m) }
+func (*EnvironmentRequest) ProtoMessage()    {}
+func (*EnvironmentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{27}
+}
+
+func (m *EnvironmentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EnvironmentRequest.Unmarshal(m, b)
+}
+func (m *EnvironmentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EnvironmentRequest.Marshal(b, m, deterministic)
+}
+func (m *EnvironmentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EnvironmentRequest.Merge(m, src)
+}
+func (m *EnvironmentRequest) XXX_Size() int {
+	return xxx_messageInfo_EnvironmentRequest.Size(m)
+}
+func (m *EnvironmentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EnvironmentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EnvironmentRequest proto.InternalMessageInfo
+
+func (m *EnvironmentRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *EnvironmentRequest) GetValues() []string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+// EnvironmentResponse is the output supplied by the `Environment` API endpoint.
+type EnvironmentResponse struct {
+	// Success of the environment request.
+	Success              bool     `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EnvironmentResponse) Reset()         { *m = EnvironmentResponse{} }
+func (m *EnvironmentResponse) String() string { return proto.CompactTextString(m) }
+func (*EnvironmentResponse) ProtoMessage()    {}
+func (*EnvironmentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{28}
+}
+
+func (m *EnvironmentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EnvironmentResponse.Unmarshal(m, b)
+}
+func (m *EnvironmentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EnvironmentResponse.Marshal(b, m, deterministic)
+}
+func (m *EnvironmentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EnvironmentResponse.Merge(m, src)
+}
+func (m *EnvironmentResponse) XXX_Size() int {
+	return xxx_messageInfo_EnvironmentResponse.Size(m)
+}
+func (m *EnvironmentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EnvironmentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EnvironmentResponse proto.InternalMessageInfo
+
+func (m *EnvironmentResponse) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+// ImageRequest is the input supplied to the `Image` API endpoint.
+type ImageRequest struct {
+	// Identity of this image.
+	Identity string `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
+	// Image data (a tar.gz of the file system), if supplied creates the stored image.
+	Image                []byte   `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImageRequest) Reset()         { *m = ImageRequest{} }
+func (m *ImageRequest) String() string { return proto.CompactTextString(m) }
+func (*ImageRequest) ProtoMessage()    {}
+func (*ImageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{29}
+}
+
+func (m *ImageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImageRequest.Unmarshal(m, b)
+}
+func (m *ImageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImageRequest.Marshal(b, m, deterministic)
+}
+func (m *ImageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImageRequest.Merge(m, src)
+}
+func (m *ImageRequest) XXX_Size() int {
+	return xxx_messageInfo_ImageRequest.Size(m)
+}
+func (m *ImageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImageRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImageRequest proto.InternalMessageInfo
+
+func (m *ImageRequest) GetIdentity() string {
+	if m != nil {
+		return m.Identity
+	}
+	return ""
+}
+
+func (m *ImageRequest) GetImage() []byte {
+	if m != nil {
+		return m.Image
+	}
+	return nil
+}
+
+// ImageResponse is the output supplied by the `Image` API endpoint.
+type ImageResponse struct {
+	// Exists if the image exists in the system.
+	Exists bool `protobuf:"varint,1,opt,name=exists,proto3" json:"exists,omitempty"`
+	// Success if the image was created.
+	Success              bool     `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImageResponse) Reset()         { *m = ImageResponse{} }
+func (m *ImageResponse) String() string { return proto.CompactTextString(m) }
+func (*ImageResponse) ProtoMessage()    {}
+func (*ImageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{30}
+}
+
+func (m *ImageResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImageResponse.Unmarshal(m, b)
+}
+func (m *ImageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImageResponse.Marshal(b, m, deterministic)
+}
+func (m *ImageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImageResponse.Merge(m, src)
+}
+func (m *ImageResponse) XXX_Size() int {
+	return xxx_messageInfo_ImageResponse.Size(m)
+}
+func (m *ImageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImageResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImageResponse proto.InternalMessageInfo
+
+func (m *ImageResponse) GetExists() bool {
+	if m != nil {
+		return m.Exists
+	}
+	return false
+}
+
+func (m *ImageResponse) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+// Job contains the completion settings for a `Job` kind of process.
+type Job struct {
+	// Retries is the number of times a failed run will be retried before the job fails (default = 0).
+	Retries int32 `protobuf:"varint,1,opt,name=retries,proto3" json:"retries,omitempty"`
+	// Deadline is the number of milliseconds the job has to complete before it is stopped and fails (default = none).
+	Deadline int64 `protobuf:"varint,2,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	// Parallelism is the number of instances of the job to run at once (default = 1).
+	Parallelism          int32    `protobuf:"varint,3,opt,name=parallelism,proto3" json:"parallelism,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Job) Reset()         { *m = Job{} }
+func (m *Job) String() string { return proto.CompactTextString(m) }
+func (*Job) ProtoMessage()    {}
+func (*Job) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{31}
+}
+
+func (m *Job) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Job.Unmarshal(m, b)
+}
+func (m *Job) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Job.Marshal(b, m, deterministic)
+}
+func (m *Job) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Job.Merge(m, src)
+}
+func (m *Job) XXX_Size() int {
+	return xxx_messageInfo_Job.Size(m)
+}
+func (m *Job) XXX_DiscardUnknown() {
+	xxx_messageInfo_Job.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Job proto.InternalMessageInfo
+
+func (m *Job) GetRetries() int32 {
+	if m != nil {
+		return m.Retries
+	}
+	return 0
+}
+
+func (m *Job) GetDeadline() int64 {
+	if m != nil {
+		return m.Deadline
+	}
+	return 0
+}
+
+func (m *Job) GetParallelism() int32 {
+	if m != nil {
+		return m.Parallelism
+	}
+	return 0
+}
+
+// Command contains command information used to start a process and return information about a running command.
+type Command struct {
+	// Name will be used as the prefix for the identifier.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Image is the uploaded image file to use as the filesystem.
+	Image string `protobuf:"bytes,10,opt,name=image,proto3" json:"image,omitempty"`
+	// Entry is the command to execute as the entry-point.
+	Entry string `protobuf:"bytes,11,opt,name=entry,proto3" json:"entry,omitempty"`
+	// Args are supplied to the executable.
+	Args []string `protobuf:"bytes,12,rep,name=args,proto3" json:"args,omitempty"`
+	// Env provides extra environment variables.
+	Env []string `protobuf:"bytes,13,rep,name=env,proto3" json:"env,omitempty"`
+	// Requirements is a set of dependencies to be met before the command will be run.
+	Requirements map[string]*Deps `protobuf:"bytes,14,rep,name=requirements,proto3" json:"requirements,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Ports are names of ports to allocate from the server's free range (supplied as `PORT_{NAME}` environment values).
+	//
+	// Allocated ports may also be referenced within `Args` as `${PORT_{NAME}}`.
+	Ports []string `protobuf:"bytes,15,rep,name=ports,proto3" json:"ports,omitempty"`
+	// Init commands are run in order before the entry-point (each must succeed before the next, or the entry-point, is run).
+	Init []*Init `protobuf:"bytes,16,rep,name=init,proto3" json:"init,omitempty"`
+	// Hooks are run at defined points in the life of the process.
+	Hooks *Hooks `protobuf:"bytes,17,opt,name=hooks,proto3" json:"hooks,omitempty"`
+	// Liveness probe restarts the process (through the normal stop path) when it fails too many times in a row.
+	Liveness *Probe `protobuf:"bytes,18,opt,name=liveness,proto3" json:"liveness,omitempty"`
+	// Readiness probe must be passing (along with any `MakeReady` watches) for the process to be ready.
+	Readiness *Probe `protobuf:"bytes,19,opt,name=readiness,proto3" json:"readiness,omitempty"`
+	// Notify creates a systemd style notify socket for the process (supplied as `NOTIFY_SOCKET`).
+	//
+	// The process is not ready until it sends `READY=1`, `STATUS=` is reported as `Process.Status`,
+	// and `MAINPID=` changes the PID that is reported and signalled.
+	// Messages (and `MAINPID=` values) are only accepted from within the process tree of the command, which includes
+	// processes left running after their parent exited (such as a forked daemon), as the server adopts them. If the
+	// command exits without notifying a `MAINPID=`, any such processes have 5 seconds to notify one before the process
+	// is treated as exited. For a command run within an `image`, the socket is hard-linked into the image at the same
+	// path.
+	Notify bool `protobuf:"varint,20,opt,name=notify,proto3" json:"notify,omitempty"`
+	// Watchdog is the number of milliseconds within which a notifying process must send `WATCHDOG=1` before it is
+	// restarted (supplied as `WATCHDOG_USEC`, default = none).
+	Watchdog int64 `protobuf:"varint,21,opt,name=watchdog,proto3" json:"watchdog,omitempty"`
+	// Sockets are bound by the server and passed to the process using the `LISTEN_FDS` convention (starting at fd 3).
+	//
+	// The sockets stay open while any process uses them, so they are kept across restarts and replacements.
+	// Sockets are only shared within the group, and allocated ports referenced by their address are handed over
+	// to the replacement of each replica.
+	Sockets []*Socket `protobuf:"bytes,22,rep,name=sockets,proto3" json:"sockets,omitempty"`
+	// Recheck is the number of milliseconds between checks of the requirements while the process is running (default = 10000).
+	//
+	// Only requirements with a `Deps.Lost` policy other than `Ignore` are rechecked.
+	Recheck int64 `protobuf:"varint,23,opt,name=recheck,proto3" json:"recheck,omitempty"`
+	// Deadline is the number of milliseconds to wait for all of the requirements to be met before the process fails
+	// (default = forever).
+	Deadline int64 `protobuf:"varint,24,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	// Lines is the number of log entries that have been produced (read-only).
+	Lines                int64    `protobuf:"varint,50,opt,name=lines,proto3" json:"lines,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Command) Reset()         { *m = Command{} }
+func (m *Command) String() string { return proto.CompactTextString(m) }
+func (*Command) ProtoMessage()    {}
+func (*Command) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{32}
+}
+
+func (m *Command) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Command.Unmarshal(m, b)
+}
+func (m *Command) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Command.Marshal(b, m, deterministic)
+}
+func (m *Command) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Command.Merge(m, src)
+}
+func (m *Command) XXX_Size() int {
+	return xxx_messageInfo_Command.Size(m)
+}
+func (m *Command) XXX_DiscardUnknown() {
+	xxx_messageInfo_Command.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Command proto.InternalMessageInfo
+
+func (m *Command) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Command) GetImage() string {
+	if m != nil {
+		return m.Image
+	}
+	return ""
+}
+
+func (m *Command) GetEntry() string {
+	if m != nil {
+		return m.Entry
+	}
+	return ""
+}
+
+func (m *Command) GetArgs() []string {
+	if m != nil {
+		return m.Args
+	}
+	return nil
+}
+
+func (m *Command) GetEnv() []string {
+	if m != nil {
+		return m.Env
+	}
+	return nil
+}
+
+func (m *Command) GetRequirements() map[string]*Deps {
+	if m != nil {
+		return m.Requirements
+	}
+	return nil
+}
+
+func (m *Command) GetPorts() []string {
+	if m != nil {
+		return m.Ports
+	}
+	return nil
+}
+
+func (m *Command) GetInit() []*Init {
+	if m != nil {
+		return m.Init
+	}
+	return nil
+}
+
+func (m *Command) GetHooks() *Hooks {
+	if m != nil {
+		return m.Hooks
+	}
+	return nil
+}
+
+func (m *Command) GetLiveness() *Probe {
+	if m != nil {
+		return m.Liveness
+	}
+	return nil
+}
+
+func (m *Command) GetReadiness() *Probe {
+	if m != nil {
+		return m.Readiness
+	}
+	return nil
+}
+
+func (m *Command) GetNotify() bool {
+	if m != nil {
+		return m.Notify
+	}
+	return false
+}
+
+func (m *Command) GetWatchdog() int64 {
+	if m != nil {
+		return m.Watchdog
+	}
+	return 0
+}
+
+func (m *Command) GetSockets() []*Socket {
+	if m != nil {
+		return m.Sockets
+	}
+	return nil
+}
+
+func (m *Command) GetRecheck() int64 {
+	if m != nil {
+		return m.Recheck
+	}
+	return 0
+}
+
+func (m *Command) GetDeadline() int64 {
+	if m != nil {
+		return m.Deadline
+	}
+	return 0
+}
+
+func (m *Command) GetLines() int64 {
+	if m != nil {
+		return m.Lines
+	}
+	return 0
+}
+
+// Init is a setup command that is run before the entry-point of a `Command` (with the same environment).
+type Init struct {
+	// Name of the init command (used in log output, default = its position starting at 1).
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Entry is the command to execute.
+	Entry string `protobuf:"bytes,2,opt,name=entry,proto3" json:"entry,omitempty"`
+	// Args are supplied to the executable.
+	Args []string `protobuf:"bytes,3,rep,name=args,proto3" json:"args,omitempty"`
+	// Env provides extra environment variables (in addition to those of the `Command`).
+	Env                  []string `protobuf:"bytes,4,rep,name=env,proto3" json:"env,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Init) Reset()         { *m = Init{} }
+func (m *Init) String() string { return proto.CompactTextString(m) }
+func (*Init) ProtoMessage()    {}
+func (*Init) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{33}
+}
+
+func (m *Init) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Init.Unmarshal(m, b)
+}
+func (m *Init) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Init.Marshal(b, m, deterministic)
+}
+func (m *Init) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Init.Merge(m, src)
+}
+func (m *Init) XXX_Size() int {
+	return xxx_messageInfo_Init.Size(m)
+}
+func (m *Init) XXX_DiscardUnknown() {
+	xxx_messageInfo_Init.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Init proto.InternalMessageInfo
+
+func (m *Init) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Init) GetEntry() string {
+	if m != nil {
+		return m.Entry
+	}
+	return ""
+}
+
+func (m *Init) GetArgs() []string {
+	if m != nil {
+		return m.Args
+	}
+	return nil
+}
+
+func (m *Init) GetEnv() []string {
+	if m != nil {
+		return m.Env
+	}
+	return nil
+}
+
+// Hooks are run at defined points in the life of a process.
+type Hooks struct {
+	// PostStart is run once the process becomes ready after each start.
+	// The process is not reported as ready (e.g. to `Replace`) until the hook finishes.
+	PostStart *Hook `protobuf:"bytes,1,opt,name=post_start,json=postStart,proto3" json:"post_start,omitempty"`
+	// PreStop is run before the process is asked to stop.
+	PreStop *Hook `protobuf:"bytes,2,opt,name=pre_stop,json=preStop,proto3" json:"pre_stop,omitempty"`
+	// PostExit is run after each exit of the process (with its exit code as `CYNO_EXIT_CODE`).
+	PostExit *Hook `protobuf:"bytes,3,opt,name=post_exit,json=postExit,proto3" json:"post_exit,omitempty"`
+	// Failed is run when the process gives up (e.g. its requirements were not met before their deadline).
+	Failed               *Hook    `protobuf:"bytes,4,opt,name=failed,proto3" json:"failed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Hooks) Reset()         { *m = Hooks{} }
+func (m *Hooks) String() string { return proto.CompactTextString(m) }
+func (*Hooks) ProtoMessage()    {}
+func (*Hooks) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{34}
+}
+
+func (m *Hooks) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Hooks.Unmarshal(m, b)
+}
+func (m *Hooks) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Hooks.Marshal(b, m, deterministic)
+}
+func (m *Hooks) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Hooks.Merge(m, src)
+}
+func (m *Hooks) XXX_Size() int {
+	return xxx_messageInfo_Hooks.Size(m)
+}
+func (m *Hooks) XXX_DiscardUnknown() {
+	xxx_messageInfo_Hooks.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Hooks proto.InternalMessageInfo
+
+func (m *Hooks) GetPostStart() *Hook {
+	if m != nil {
+		return m.PostStart
+	}
+	return nil
+}
+
+func (m *Hooks) GetPreStop() *Hook {
+	if m != nil {
+		return m.PreStop
+	}
+	return nil
+}
+
+func (m *Hooks) GetPostExit() *Hook {
+	if m != nil {
+		return m.PostExit
+	}
+	return nil
+}
+
+func (m *Hooks) GetFailed() *Hook {
+	if m != nil {
+		return m.Failed
+	}
+	return nil
+}
+
+// Hook is either a command executed within the instance or an HTTP call to the process.
+type Hook struct {
+	// Entry is the command to execute (for an exec hook).
+	Entry string `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	// Args are supplied to the executable.
+	Args []string `protobuf:"bytes,2,rep,name=args,proto3" json:"args,omitempty"`
+	// Env provides extra environment variables (in addition to those of the `Command`).
+	Env []string `protobuf:"bytes,3,rep,name=env,proto3" json:"env,omitempty"`
+	// URL to call (for an HTTP hook), allocated ports may be referenced as `${PORT_{NAME}}`.
+	Url string `protobuf:"bytes,10,opt,name=url,proto3" json:"url,omitempty"`
+	// Method of the HTTP call (default = GET).
+	Method string `protobuf:"bytes,11,opt,name=method,proto3" json:"method,omitempty"`
+	// Timeout is the number of milliseconds the hook has to complete (default = 10000).
+	Timeout int64 `protobuf:"varint,20,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// NotReady marks the process as not-ready if a `PostStart` hook fails (until the next start).
+	NotReady             bool     `protobuf:"varint,21,opt,name=not_ready,json=notReady,proto3" json:"not_ready,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Hook) Reset()         { *m = Hook{} }
+func (m *Hook) String() string { return proto.CompactTextString(m) }
+func (*Hook) ProtoMessage()    {}
+func (*Hook) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{35}
+}
+
+func (m *Hook) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Hook.Unmarshal(m, b)
+}
+func (m *Hook) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Hook.Marshal(b, m, deterministic)
+}
+func (m *Hook) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Hook.Merge(m, src)
+}
+func (m *Hook) XXX_Size() int {
+	return xxx_messageInfo_Hook.Size(m)
+}
+func (m *Hook) XXX_DiscardUnknown() {
+	xxx_messageInfo_Hook.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Hook proto.InternalMessageInfo
+
+func (m *Hook) GetEntry() string {
+	if m != nil {
+		return m.Entry
+	}
+	return ""
+}
+
+func (m *Hook) GetArgs() []string {
+	if m != nil {
+		return m.Args
+	}
+	return nil
+}
+
+func (m *Hook) GetEnv() []string {
+	if m != nil {
+		return m.Env
+	}
+	return nil
+}
+
+func (m *Hook) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *Hook) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *Hook) GetTimeout() int64 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+func (m *Hook) GetNotReady() bool {
+	if m != nil {
+		return m.NotReady
+	}
+	return false
+}
+
+// HookResult records the outcome of a hook being run.
+type HookResult struct {
+	// Point at which the hook was run.
+	Point HookResult_Point `protobuf:"varint,1,opt,name=point,proto3,enum=cynosure.HookResult_Point" json:"point,omitempty"`
+	// Time in milliseconds since epoch that the hook was run.
+	Time int64 `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
+	// Duration in milliseconds that the hook took to complete.
+	Duration int64 `protobuf:"varint,3,opt,name=duration,proto3" json:"duration,omitempty"`
+	// Success is whether the hook completed successfully.
+	Success bool `protobuf:"varint,4,opt,name=success,proto3" json:"success,omitempty"`
+	// Message describes the outcome of the hook.
+	Message              string   `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HookResult) Reset()         { *m = HookResult{} }
+func (m *HookResult) String() string { return proto.CompactTextString(m) }
+func (*HookResult) ProtoMessage()    {}
+func (*HookResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{36}
+}
+
+func (m *HookResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HookResult.Unmarshal(m, b)
+}
+func (m *HookResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HookResult.Marshal(b, m, deterministic)
+}
+func (m *HookResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HookResult.Merge(m, src)
+}
+func (m *HookResult) XXX_Size() int {
+	return xxx_messageInfo_HookResult.Size(m)
+}
+func (m *HookResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_HookResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HookResult proto.InternalMessageInfo
+
+func (m *HookResult) GetPoint() HookResult_Point {
+	if m != nil {
+		return m.Point
+	}
+	return HookResult_PostStart
+}
+
+func (m *HookResult) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *HookResult) GetDuration() int64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+func (m *HookResult) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+func (m *HookResult) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+// Probe periodically checks the health of a running process.
+//
+// Either `Dep` (checked by a broker such as `http` or `port`) or `Exec` (run within the instance) should be supplied.
+// Allocated ports may be referenced within either as `${PORT_{NAME}}`.
+type Probe struct {
+	// Dep is checked using a broker defined within the server configuration.
+	Dep *Dep `protobuf:"bytes,1,opt,name=dep,proto3" json:"dep,omitempty"`
+	// Exec is a command (entry followed by args) run within the instance, which passes if it exits successfully.
+	Exec []string `protobuf:"bytes,2,rep,name=exec,proto3" json:"exec,omitempty"`
+	// InitialDelay is the number of milliseconds after start before the first check (default = 0).
+	InitialDelay int64 `protobuf:"varint,10,opt,name=initial_delay,json=initialDelay,proto3" json:"initial_delay,omitempty"`
+	// Interval is the number of milliseconds between checks (default = 10000).
+	Interval int64 `protobuf:"varint,11,opt,name=interval,proto3" json:"interval,omitempty"`
+	// Timeout is the number of milliseconds a check has to pass (default = 1000).
+	Timeout int64 `protobuf:"varint,12,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// FailureThreshold is the number of consecutive failed checks before the probe fails (default = 3).
+	FailureThreshold     int32    `protobuf:"varint,13,opt,name=failure_threshold,json=failureThreshold,proto3" json:"failure_threshold,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Probe) Reset()         { *m = Probe{} }
+func (m *Probe) String() string { return proto.CompactTextString(m) }
+func (*Probe) ProtoMessage()    {}
+func (*Probe) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{37}
+}
+
+func (m *Probe) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Probe.Unmarshal(m, b)
+}
+func (m *Probe) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Probe.Marshal(b, m, deterministic)
+}
+func (m *Probe) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Probe.Merge(m, src)
+}
+func (m *Probe) XXX_Size() int {
+	return xxx_messageInfo_Probe.Size(m)
+}
+func (m *Probe) XXX_DiscardUnknown() {
+	xxx_messageInfo_Probe.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Probe proto.InternalMessageInfo
+
+func (m *Probe) GetDep() *Dep {
+	if m != nil {
+		return m.Dep
+	}
+	return nil
+}
+
+func (m *Probe) GetExec() []string {
+	if m != nil {
+		return m.Exec
+	}
+	return nil
+}
+
+func (m *Probe) GetInitialDelay() int64 {
+	if m != nil {
+		return m.InitialDelay
+	}
+	return 0
+}
+
+func (m *Probe) GetInterval() int64 {
+	if m != nil {
+		return m.Interval
+	}
+	return 0
+}
+
+func (m *Probe) GetTimeout() int64 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+func (m *Probe) GetFailureThreshold() int32 {
+	if m != nil {
+		return m.FailureThreshold
+	}
+	return 0
+}
+
+// ProbeStatus is the current state of a `Probe`.
+type ProbeStatus struct {
+	// Passing is whether the probe is currently passing (it fails after `FailureThreshold` consecutive failures).
+	Passing bool `protobuf:"varint,1,opt,name=passing,proto3" json:"passing,omitempty"`
+	// Failures is the number of consecutive failed checks.
+	Failures int32 `protobuf:"varint,2,opt,name=failures,proto3" json:"failures,omitempty"`
+	// Checked time in milliseconds since epoch of the last check.
+	Checked int64 `protobuf:"varint,3,opt,name=checked,proto3" json:"checked,omitempty"`
+	// Message from the last check.
+	Message string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	// Restarts is the number of times the process has been restarted by the probe.
+	Restarts             int32    `protobuf:"varint,5,opt,name=restarts,proto3" json:"restarts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProbeStatus) Reset()         { *m = ProbeStatus{} }
+func (m *ProbeStatus) String() string { return proto.CompactTextString(m) }
+func (*ProbeStatus) ProtoMessage()    {}
+func (*ProbeStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{38}
+}
+
+func (m *ProbeStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProbeStatus.Unmarshal(m, b)
+}
+func (m *ProbeStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProbeStatus.Marshal(b, m, deterministic)
+}
+func (m *ProbeStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProbeStatus.Merge(m, src)
+}
+func (m *ProbeStatus) XXX_Size() int {
+	return xxx_messageInfo_ProbeStatus.Size(m)
+}
+func (m *ProbeStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProbeStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProbeStatus proto.InternalMessageInfo
+
+func (m *ProbeStatus) GetPassing() bool {
+	if m != nil {
+		return m.Passing
+	}
+	return false
+}
+
+func (m *ProbeStatus) GetFailures() int32 {
+	if m != nil {
+		return m.Failures
+	}
+	return 0
+}
+
+func (m *ProbeStatus) GetChecked() int64 {
+	if m != nil {
+		return m.Checked
+	}
+	return 0
+}
+
+func (m *ProbeStatus) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *ProbeStatus) GetRestarts() int32 {
+	if m != nil {
+		return m.Restarts
+	}
+	return 0
+}
+
+// Event records a notable change to a process.
+type Event struct {
+	// Time in milliseconds since epoch of the event.
+	Time int64 `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	// Reason is a short machine readable name for the event (e.g. `Ready`, `NotReady`).
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Message describes the event.
+	Message              string   `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Event) Reset()         { *m = Event{} }
+func (m *Event) String() string { return proto.CompactTextString(m) }
+func (*Event) ProtoMessage()    {}
+func (*Event) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{39}
+}
+
+func (m *Event) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Event.Unmarshal(m, b)
+}
+func (m *Event) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Event.Marshal(b, m, deterministic)
+}
+func (m *Event) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Event.Merge(m, src)
+}
+func (m *Event) XXX_Size() int {
+	return xxx_messageInfo_Event.Size(m)
+}
+func (m *Event) XXX_DiscardUnknown() {
+	xxx_messageInfo_Event.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Event proto.InternalMessageInfo
+
+func (m *Event) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *Event) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *Event) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+// Socket is a listen socket that is bound by the server and passed to a process.
+type Socket struct {
+	// Name of the socket (supplied in `LISTEN_FDNAMES`).
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Address to bind (e.g. `:8080`, or a path for a unix socket), allocated ports may be referenced as `${PORT_{NAME}}`.
+	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	// Network is one of `tcp`, `tcp4`, `tcp6`, `udp`, `udp4`, `udp6` or `unix` (default = tcp).
+	Network              string   `protobuf:"bytes,3,opt,name=network,proto3" json:"network,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Socket) Reset()         { *m = Socket{} }
+func (m *Socket) String() string { return proto.CompactTextString(m) }
+func (*Socket) ProtoMessage()    {}
+func (*Socket) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{40}
+}
+
+func (m *Socket) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Socket.Unmarshal(m, b)
+}
+func (m *Socket) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Socket.Marshal(b, m, deterministic)
+}
+func (m *Socket) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Socket.Merge(m, src)
+}
+func (m *Socket) XXX_Size() int {
+	return xxx_messageInfo_Socket.Size(m)
+}
+func (m *Socket) XXX_DiscardUnknown() {
+	xxx_messageInfo_Socket.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Socket proto.InternalMessageInfo
+
+func (m *Socket) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Socket) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *Socket) GetNetwork() string {
+	if m != nil {
+		return m.Network
+	}
+	return ""
+}
+
+// RequirementStatus is the result of the last check of a requirement.
+type RequirementStatus struct {
+	// Name of the requirement.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Passed is whether any of the requirement's dependencies passed.
+	Passed bool `protobuf:"varint,2,opt,name=passed,proto3" json:"passed,omitempty"`
+	// Message summarising the check.
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// Checked time in milliseconds since epoch of the last check.
+	Checked int64 `protobuf:"varint,4,opt,name=checked,proto3" json:"checked,omitempty"`
+	// Deps are the results of each of the requirement's dependencies (in order).
+	Deps []*DepStatus `protobuf:"bytes,5,rep,name=deps,proto3" json:"deps,omitempty"`
+	// Expr is the evaluated `Deps.expr` tree (if the requirement has one).
+	Expr                 *ExprStatus `protobuf:"bytes,6,opt,name=expr,proto3" json:"expr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *RequirementStatus) Reset()         { *m = RequirementStatus{} }
+func (m *RequirementStatus) String() string { return proto.CompactTextString(m) }
+func (*RequirementStatus) ProtoMessage()    {}
+func (*RequirementStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{41}
+}
+
+func (m *RequirementStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RequirementStatus.Unmarshal(m, b)
+}
+func (m *RequirementStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RequirementStatus.Marshal(b, m, deterministic)
+}
+func (m *RequirementStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequirementStatus.Merge(m, src)
+}
+func (m *RequirementStatus) XXX_Size() int {
+	return xxx_messageInfo_RequirementStatus.Size(m)
+}
+func (m *RequirementStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequirementStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequirementStatus proto.InternalMessageInfo
+
+func (m *RequirementStatus) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RequirementStatus) GetPassed() bool {
+	if m != nil {
+		return m.Passed
+	}
+	return false
+}
+
+func (m *RequirementStatus) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *RequirementStatus) GetChecked() int64 {
+	if m != nil {
+		return m.Checked
+	}
+	return 0
+}
+
+func (m *RequirementStatus) GetDeps() []*DepStatus {
+	if m != nil {
+		return m.Deps
+	}
+	return nil
+}
+
+func (m *RequirementStatus) GetExpr() *ExprStatus {
+	if m != nil {
+		return m.Expr
+	}
+	return nil
+}
+
+// DepStatus is the result of the last check of a single `Dep`.
+type DepStatus struct {
+	// Identity of the broker that was used.
+	Identity string `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
+	// Wait is the thing that was waited for.
+	Wait string `protobuf:"bytes,2,opt,name=wait,proto3" json:"wait,omitempty"`
+	// Checked is whether the dependency was checked (those after the first that passes are not).
+	Checked bool `protobuf:"varint,3,opt,name=checked,proto3" json:"checked,omitempty"`
+	// Passed is whether the dependency passed.
+	Passed bool `protobuf:"varint,4,opt,name=passed,proto3" json:"passed,omitempty"`
+	// Message from the check.
+	Message              string   `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DepStatus) Reset()         { *m = DepStatus{} }
+func (m *DepStatus) String() string { return proto.CompactTextString(m) }
+func (*DepStatus) ProtoMessage()    {}
+func (*DepStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{42}
+}
+
+func (m *DepStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DepStatus.Unmarshal(m, b)
+}
+func (m *DepStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DepStatus.Marshal(b, m, deterministic)
+}
+func (m *DepStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DepStatus.Merge(m, src)
+}
+func (m *DepStatus) XXX_Size() int {
+	return xxx_messageInfo_DepStatus.Size(m)
+}
+func (m *DepStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_DepStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DepStatus proto.InternalMessageInfo
+
+func (m *DepStatus) GetIdentity() string {
+	if m != nil {
+		return m.Identity
+	}
+	return ""
+}
+
+func (m *DepStatus) GetWait() string {
+	if m != nil {
+		return m.Wait
+	}
+	return ""
+}
+
+func (m *DepStatus) GetChecked() bool {
+	if m != nil {
+		return m.Checked
+	}
+	return false
+}
+
+func (m *DepStatus) GetPassed() bool {
+	if m != nil {
+		return m.Passed
+	}
+	return false
+}
+
+func (m *DepStatus) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+// ExprStatus is the result of the last evaluation of an `Expr`.
+type ExprStatus struct {
+	// Op is the operator of the expression.
+	Op Expr_Op `protobuf:"varint,1,opt,name=op,proto3,enum=cynosure.Expr_Op" json:"op,omitempty"`
+	// Dep is the result of the dependency (for a `Leaf`).
+	Dep *DepStatus `protobuf:"bytes,2,opt,name=dep,proto3" json:"dep,omitempty"`
+	// Args are the results of each of the operands (in order).
+	Args []*ExprStatus `protobuf:"bytes,3,rep,name=args,proto3" json:"args,omitempty"`
+	// Quorum is the number of operands that had to pass (for a `Quorum`).
+	Quorum int32 `protobuf:"varint,4,opt,name=quorum,proto3" json:"quorum,omitempty"`
+	// Checked is whether the expression was evaluated (operands after the outcome is known are not).
+	Checked bool `protobuf:"varint,5,opt,name=checked,proto3" json:"checked,omitempty"`
+	// Passed is whether the expression passed.
+	Passed bool `protobuf:"varint,6,opt,name=passed,proto3" json:"passed,omitempty"`
+	// Message describing the outcome (for a failure, the branch that is blocking).
+	Message              string   `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExprStatus) Reset()         { *m = ExprStatus{} }
+func (m *ExprStatus) String() string { return proto.CompactTextString(m) }
+func (*ExprStatus) ProtoMessage()    {}
+func (*ExprStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{43}
+}
+
+func (m *ExprStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExprStatus.Unmarshal(m, b)
+}
+func (m *ExprStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExprStatus.Marshal(b, m, deterministic)
+}
+func (m *ExprStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExprStatus.Merge(m, src)
+}
+func (m *ExprStatus) XXX_Size() int {
+	return xxx_messageInfo_ExprStatus.Size(m)
+}
+func (m *ExprStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExprStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExprStatus proto.InternalMessageInfo
+
+func (m *ExprStatus) GetOp() Expr_Op {
+	if m != nil {
+		return m.Op
+	}
+	return Expr_Leaf
+}
+
+func (m *ExprStatus) GetDep() *DepStatus {
+	if m != nil {
+		return m.Dep
+	}
+	return nil
+}
+
+func (m *ExprStatus) GetArgs() []*ExprStatus {
+	if m != nil {
+		return m.Args
+	}
+	return nil
+}
+
+func (m *ExprStatus) GetQuorum() int32 {
+	if m != nil {
+		return m.Quorum
+	}
+	return 0
+}
+
+func (m *ExprStatus) GetChecked() bool {
+	if m != nil {
+		return m.Checked
+	}
+	return false
+}
+
+func (m *ExprStatus) GetPassed() bool {
+	if m != nil {
+		return m.Passed
+	}
+	return false
+}
+
+func (m *ExprStatus) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+// Dep contains dependency requirements.
+type Dep struct {
+	// Identity of the broker to use, defined within the server configuration.
+	Identity string `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
+	// Wait is the thing to wait for, how it is interpreted/found is up to the provider.
+	Wait                 string   `protobuf:"bytes,10,opt,name=wait,proto3" json:"wait,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Dep) Reset()         { *m = Dep{} }
+func (m *Dep) String() string { return proto.CompactTextString(m) }
+func (*Dep) ProtoMessage()    {}
+func (*Dep) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{44}
+}
+
+func (m *Dep) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Dep.Unmarshal(m, b)
+}
+func (m *Dep) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Dep.Marshal(b, m, deterministic)
+}
+func (m *Dep) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Dep.Merge(m, src)
+}
+func (m *Dep) XXX_Size() int {
+	return xxx_messageInfo_Dep.Size(m)
+}
+func (m *Dep) XXX_DiscardUnknown() {
+	xxx_messageInfo_Dep.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Dep proto.InternalMessageInfo
+
+func (m *Dep) GetIdentity() string {
+	if m != nil {
+		return m.Identity
+	}
+	return ""
+}
+
+func (m *Dep) GetWait() string {
+	if m != nil {
+		return m.Wait
+	}
+	return ""
+}
+
+// Deps is a list of Dep entries, any of which can fulfil the requirement (or an `Expr` over them that must pass).
+type Deps struct {
+	// Deps is the list of `Dep` items.
+	Deps []*Dep `protobuf:"bytes,1,rep,name=deps,proto3" json:"deps,omitempty"`
+	// Lost determines what happens if the requirement fails after the process has started.
+	Lost Deps_Lost `protobuf:"varint,2,opt,name=lost,proto3,enum=cynosure.Deps_Lost" json:"lost,omitempty"`
+	// Deadline is the number of milliseconds to wait for the requirement to be met before the process fails (default = forever).
+	Deadline int64 `protobuf:"varint,3,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	// Expr is an expression over dependencies that must be met, used instead of the `deps` list if supplied.
+	Expr                 *Expr    `protobuf:"bytes,4,opt,name=expr,proto3" json:"expr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Deps) Reset()         { *m = Deps{} }
+func (m *Deps) String() string { return proto.CompactTextString(m) }
+func (*Deps) ProtoMessage()    {}
+func (*Deps) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{45}
+}
+
+func (m *Deps) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Deps.Unmarshal(m, b)
+}
+func (m *Deps) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Deps.Marshal(b, m, deterministic)
+}
+func (m *Deps) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Deps.Merge(m, src)
+}
+func (m *Deps) XXX_Size() int {
+	return xxx_messageInfo_Deps.Size(m)
+}
+func (m *Deps) XXX_DiscardUnknown() {
+	xxx_messageInfo_Deps.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Deps proto.InternalMessageInfo
+
+func (m *Deps) GetDeps() []*Dep {
+	if m != nil {
+		return m.Deps
+	}
+	return nil
+}
+
+func (m *Deps) GetLost() Deps_Lost {
+	if m != nil {
+		return m.Lost
+	}
+	return Deps_Ignore
+}
+
+func (m *Deps) GetDeadline() int64 {
+	if m != nil {
+		return m.Deadline
+	}
+	return 0
+}
+
+func (m *Deps) GetExpr() *Expr {
+	if m != nil {
+		return m.Expr
+	}
+	return nil
+}
+
+// Expr is a boolean expression over `Dep` items.
+type Expr struct {
+	// Op is the operator of the expression.
+	Op Expr_Op `protobuf:"varint,1,opt,name=op,proto3,enum=cynosure.Expr_Op" json:"op,omitempty"`
+	// Dep is the dependency to check (for a `Leaf`).
+	Dep *Dep `protobuf:"bytes,2,opt,name=dep,proto3" json:"dep,omitempty"`
+	// Args are the operands of the expression.
+	Args []*Expr `protobuf:"bytes,3,rep,name=args,proto3" json:"args,omitempty"`
+	// Quorum is the number of `args` that must pass (for a `Quorum`, default = a majority).
+	Quorum               int32    `protobuf:"varint,4,opt,name=quorum,proto3" json:"quorum,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Expr) Reset()         { *m = Expr{} }
+func (m *Expr) String() string { return proto.CompactTextString(m) }
+func (*Expr) ProtoMessage()    {}
+func (*Expr) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{46}
+}
+
+func (m *Expr) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Expr.Unmarshal(m, b)
+}
+func (m *Expr) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Expr.Marshal(b, m, deterministic)
+}
+func (m *Expr) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Expr.Merge(m, src)
+}
+func (m *Expr) XXX_Size() int {
+	return xxx_messageInfo_Expr.Size(m)
+}
+func (m *Expr) XXX_DiscardUnknown() {
+	xxx_messageInfo_Expr.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Expr proto.InternalMessageInfo
+
+func (m *Expr) GetOp() Expr_Op {
+	if m != nil {
+		return m.Op
+	}
+	return Expr_Leaf
+}
+
+func (m *Expr) GetDep() *Dep {
+	if m != nil {
+		return m.Dep
+	}
+	return nil
+}
+
+func (m *Expr) GetArgs() []*Expr {
+	if m != nil {
+		return m.Args
+	}
+	return nil
+}
+
+func (m *Expr) GetQuorum() int32 {
+	if m != nil {
+		return m.Quorum
+	}
+	return 0
+}
+
+// Filter expresses how to match a `Process`.
+type Filter struct {
+	// Type of value to filter on.
+	Type Filter_Type `protobuf:"varint,1,opt,name=type,proto3,enum=cynosure.Filter_Type" json:"type,omitempty"`
+	// Op is the matching type.
+	Op Filter_Op `protobuf:"varint,2,opt,name=op,proto3,enum=cynosure.Filter_Op" json:"op,omitempty"`
+	// Values to match.
+	Values []string `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`
+	// Key to match on (type dependant).
+	Key                  string   `protobuf:"bytes,10,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Filter) Reset()         { *m = Filter{} }
+func (m *Filter) String() string { return proto.CompactTextString(m) }
+func (*Filter) ProtoMessage()    {}
+func (*Filter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{47}
+}
+
+func (m *Filter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Filter.Unmarshal(m, b)
+}
+func (m *Filter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Filter.Marshal(b, m, deterministic)
+}
+func (m *Filter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Filter.Merge(m, src)
+}
+func (m *Filter) XXX_Size() int {
+	return xxx_messageInfo_Filter.Size(m)
+}
+func (m *Filter) XXX_DiscardUnknown() {
+	xxx_messageInfo_Filter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Filter proto.InternalMessageInfo
+
+func (m *Filter) GetType() Filter_Type {
+	if m != nil {
+		return m.Type
+	}
+	return Filter_Namespace
+}
+
+func (m *Filter) GetOp() Filter_Op {
+	if m != nil {
+		return m.Op
+	}
+	return Filter_In
+}
+
+func (m *Filter) GetValues() []string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+func (m *Filter) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+// KV is a simple key/value pair.
+type KV struct {
+	// Key of the value.
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Value of the key.
+	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KV) Reset()         { *m = KV{} }
+func (m *KV) String() string { return proto.CompactTextString(m) }
+func (*KV) ProtoMessage()    {}
+func (*KV) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{48}
+}
+
+func (m *KV) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KV.Unmarshal(m, b)
+}
+func (m *KV) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KV.Marshal(b, m, deterministic)
+}
+func (m *KV) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KV.Merge(m, src)
+}
+func (m *KV) XXX_Size() int {
+	return xxx_messageInfo_KV.Size(m)
+}
+func (m *KV) XXX_DiscardUnknown() {
+	xxx_messageInfo_KV.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KV proto.InternalMessageInfo
+
+func (m *KV) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *KV) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+// LogEntry of a log line from a process.
+type LogEntry struct {
+	// Pos provides the line number for the log entry (starts at 1).
+	Pos int64 `protobuf:"varint,1,opt,name=pos,proto3" json:"pos,omitempty"`
+	// Time that the log entry was processed.
+	Time string `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	// Source of the log entry (`out`, `err`, `debug`, `info`, `warning`, `error`, `fatal`, etc).
+	Source string `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`
+	// Raw contents of the log line.
+	Raw string `protobuf:"bytes,4,opt,name=raw,proto3" json:"raw,omitempty"`
+	// Message that was parsed out of the raw log line.
+	Message string `protobuf:"bytes,10,opt,name=message,proto3" json:"message,omitempty"`
+	// Fields that were parsed out of the raw log line.
+	Fields               string   `protobuf:"bytes,11,opt,name=fields,proto3" json:"fields,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LogEntry) Reset()         { *m = LogEntry{} }
+func (m *LogEntry) String() string { return proto.CompactTextString(m) }
+func (*LogEntry) ProtoMessage()    {}
+func (*LogEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{49}
+}
+
+func (m *LogEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogEntry.Unmarshal(m, b)
+}
+func (m *LogEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogEntry.Marshal(b, m, deterministic)
+}
+func (m *LogEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogEntry.Merge(m, src)
+}
+func (m *LogEntry) XXX_Size() int {
+	return xxx_messageInfo_LogEntry.Size(m)
+}
+func (m *LogEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogEntry.DiscardUnknown(m)
 }
 
 var xxx_messageInfo_LogEntry proto.InternalMessageInfo
 
-func (m *LogEntry) GetPos() int64 {
+func (m *LogEntry) GetPos() int64 {
+	if m != nil {
+		return m.Pos
+	}
+	return 0
+}
+
+func (m *LogEntry) GetTime() string {
+	if m != nil {
+		return m.Time
+	}
+	return ""
+}
+
+func (m *LogEntry) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *LogEntry) GetRaw() string {
+	if m != nil {
+		return m.Raw
+	}
+	return ""
+}
+
+func (m *LogEntry) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *LogEntry) GetFields() string {
+	if m != nil {
+		return m.Fields
+	}
+	return ""
+}
+
+// Process information to create a new process or return from a running process.
+type Process struct {
+	// Identifier is the unique ID that is assigned to this instance of the command.
+	Identifier string `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`
+	// Namespace that the process is running in.
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Group is the identifier shared by all of the replicas started from the same request.
+	Group string `protobuf:"bytes,3,opt,name=group,proto3" json:"group,omitempty"`
+	// Index of this replica within its group (starts at 0).
+	Index int32 `protobuf:"varint,4,opt,name=index,proto3" json:"index,omitempty"`
+	// Labels that were assigned to the process at start.
+	Labels []*KV `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty"`
+	// Pid is the process ID of the command.
+	Pid int32 `protobuf:"varint,10,opt,name=pid,proto3" json:"pid,omitempty"`
+	// Started time in milliseconds since epoch that the current PID started.
+	Started int64 `protobuf:"varint,11,opt,name=started,proto3" json:"started,omitempty"`
+	// Running duration in milliseconds that the process has been running.
+	Running int64 `protobuf:"varint,12,opt,name=running,proto3" json:"running,omitempty"`
+	// Ready is whether the process thinks it's ready (running, with any `MakeReady` watches matched and any readiness probe passing).
+	Ready bool `protobuf:"varint,13,opt,name=ready,proto3" json:"ready,omitempty"`
+	// State of the process.
+	State Process_State `protobuf:"varint,14,opt,name=state,proto3,enum=cynosure.Process_State" json:"state,omitempty"`
+	// Attempts is the number of times the command has been run.
+	Attempts int32 `protobuf:"varint,15,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	// ExitCode of the last run of the command.
+	ExitCode int32 `protobuf:"varint,16,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	// ExitMessage describes how the last run of the command exited (or why the process failed).
+	ExitMessage string `protobuf:"bytes,17,opt,name=exit_message,json=exitMessage,proto3" json:"exit_message,omitempty"`
+	// Finished time in milliseconds since epoch that the process reached a finished state.
+	Finished int64 `protobuf:"varint,18,opt,name=finished,proto3" json:"finished,omitempty"`
+	// Command to run (or that is running)
+	Command *Command `protobuf:"bytes,20,opt,name=command,proto3" json:"command,omitempty"`
+	// Ports that are open (TCP/UDP for listening) by the process.
+	Ports []string `protobuf:"bytes,21,rep,name=ports,proto3" json:"ports,omitempty"`
+	// Observations that have been made by the `StartRequest.Watches` (which are supplied at start-up).
+	Observations map[string]string `protobuf:"bytes,22,rep,name=observations,proto3" json:"observations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Allocations are the ports allocated to the `Command.Ports` names (released when the process is stopped).
+	Allocations map[string]int32 `protobuf:"bytes,23,rep,name=allocations,proto3" json:"allocations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// Hooks are the results of the most recent hooks run for the process (oldest first).
+	Hooks []*HookResult `protobuf:"bytes,24,rep,name=hooks,proto3" json:"hooks,omitempty"`
+	// Liveness is the status of the `Command.Liveness` probe.
+	Liveness *ProbeStatus `protobuf:"bytes,25,opt,name=liveness,proto3" json:"liveness,omitempty"`
+	// Readiness is the status of the `Command.Readiness` probe.
+	Readiness *ProbeStatus `protobuf:"bytes,26,opt,name=readiness,proto3" json:"readiness,omitempty"`
+	// Events are the most recent notable changes to the process (oldest first).
+	Events []*Event `protobuf:"bytes,27,rep,name=events,proto3" json:"events,omitempty"`
+	// Status is the last `STATUS=` message sent to the notify socket.
+	Status string `protobuf:"bytes,28,opt,name=status,proto3" json:"status,omitempty"`
+	// Requirements are the results of the last check of each of the `Command.Requirements` (ordered by name).
+	Requirements         []*RequirementStatus `protobuf:"bytes,29,rep,name=requirements,proto3" json:"requirements,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Process) Reset()         { *m = Process{} }
+func (m *Process) String() string { return proto.CompactTextString(m) }
+func (*Process) ProtoMessage()    {}
+func (*Process) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{50}
+}
+
+func (m *Process) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Process.Unmarshal(m, b)
+}
+func (m *Process) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Process.Marshal(b, m, deterministic)
+}
+func (m *Process) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Process.Merge(m, src)
+}
+func (m *Process) XXX_Size() int {
+	return xxx_messageInfo_Process.Size(m)
+}
+func (m *Process) XXX_DiscardUnknown() {
+	xxx_messageInfo_Process.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Process proto.InternalMessageInfo
+
+func (m *Process) GetIdentifier() string {
+	if m != nil {
+		return m.Identifier
+	}
+	return ""
+}
+
+func (m *Process) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *Process) GetGroup() string {
+	if m != nil {
+		return m.Group
+	}
+	return ""
+}
+
+func (m *Process) GetIndex() int32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *Process) GetLabels() []*KV {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *Process) GetPid() int32 {
 	if m != nil {
-		return m.Pos
+		return m.Pid
 	}
 	return 0
 }
 
-func (m *LogEntry) GetTime() string {
+func (m *Process) GetStarted() int64 {
+	if m != nil {
+		return m.Started
+	}
+	return 0
+}
+
+func (m *Process) GetRunning() int64 {
+	if m != nil {
+		return m.Running
+	}
+	return 0
+}
+
+func (m *Process) GetReady() bool {
+	if m != nil {
+		return m.Ready
+	}
+	return false
+}
+
+func (m *Process) GetState() Process_State {
+	if m != nil {
+		return m.State
+	}
+	return Process_Running
+}
+
+func (m *Process) GetAttempts() int32 {
+	if m != nil {
+		return m.Attempts
+	}
+	return 0
+}
+
+func (m *Process) GetExitCode() int32 {
+	if m != nil {
+		return m.ExitCode
+	}
+	return 0
+}
+
+func (m *Process) GetExitMessage() string {
+	if m != nil {
+		return m.ExitMessage
+	}
+	return ""
+}
+
+func (m *Process) GetFinished() int64 {
+	if m != nil {
+		return m.Finished
+	}
+	return 0
+}
+
+func (m *Process) GetCommand() *Command {
+	if m != nil {
+		return m.Command
+	}
+	return nil
+}
+
+func (m *Process) GetPorts() []string {
+	if m != nil {
+		return m.Ports
+	}
+	return nil
+}
+
+func (m *Process) GetObservations() map[string]string {
+	if m != nil {
+		return m.Observations
+	}
+	return nil
+}
+
+func (m *Process) GetAllocations() map[string]int32 {
+	if m != nil {
+		return m.Allocations
+	}
+	return nil
+}
+
+func (m *Process) GetHooks() []*HookResult {
+	if m != nil {
+		return m.Hooks
+	}
+	return nil
+}
+
+func (m *Process) GetLiveness() *ProbeStatus {
+	if m != nil {
+		return m.Liveness
+	}
+	return nil
+}
+
+func (m *Process) GetReadiness() *ProbeStatus {
+	if m != nil {
+		return m.Readiness
+	}
+	return nil
+}
+
+func (m *Process) GetEvents() []*Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *Process) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *Process) GetRequirements() []*RequirementStatus {
+	if m != nil {
+		return m.Requirements
+	}
+	return nil
+}
+
+// Revision is a `StartRequest` that was deployed for a named process.
+type Revision struct {
+	// Revision number (starts at 1 and increases with each change).
+	Revision int64 `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	// Time in milliseconds since epoch that the revision was deployed.
+	Time int64 `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
+	// Identity is the common name of the client certificate that made the change.
+	Identity string `protobuf:"bytes,3,opt,name=identity,proto3" json:"identity,omitempty"`
+	// Action that created the revision (`start`, `replace` or `rollback`).
+	Action string `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	// Source is the revision that was redeployed (for a `rollback` action).
+	Source int64 `protobuf:"varint,5,opt,name=source,proto3" json:"source,omitempty"`
+	// Start is the request that was deployed.
+	Start                *StartRequest `protobuf:"bytes,10,opt,name=start,proto3" json:"start,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *Revision) Reset()         { *m = Revision{} }
+func (m *Revision) String() string { return proto.CompactTextString(m) }
+func (*Revision) ProtoMessage()    {}
+func (*Revision) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{51}
+}
+
+func (m *Revision) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Revision.Unmarshal(m, b)
+}
+func (m *Revision) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Revision.Marshal(b, m, deterministic)
+}
+func (m *Revision) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Revision.Merge(m, src)
+}
+func (m *Revision) XXX_Size() int {
+	return xxx_messageInfo_Revision.Size(m)
+}
+func (m *Revision) XXX_DiscardUnknown() {
+	xxx_messageInfo_Revision.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Revision proto.InternalMessageInfo
+
+func (m *Revision) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *Revision) GetTime() int64 {
 	if m != nil {
 		return m.Time
 	}
+	return 0
+}
+
+func (m *Revision) GetIdentity() string {
+	if m != nil {
+		return m.Identity
+	}
 	return ""
 }
 
-func (m *LogEntry) GetSource() string {
+func (m *Revision) GetAction() string {
 	if m != nil {
-		return m.Source
+		return m.Action
 	}
 	return ""
 }
 
-func (m *LogEntry) GetRaw() string {
+func (m *Revision) GetSource() int64 {
 	if m != nil {
-		return m.Raw
+		return m.Source
+	}
+	return 0
+}
+
+func (m *Revision) GetStart() *StartRequest {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+// Change is a difference in a single value between two revisions.
+type Change struct {
+	// Path to the value that changed, as a JSON pointer (e.g. `/command/args/1`).
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// From is the JSON encoded old value (empty if it was added).
+	From string `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	// To is the JSON encoded new value (empty if it was removed).
+	To                   string   `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Change) Reset()         { *m = Change{} }
+func (m *Change) String() string { return proto.CompactTextString(m) }
+func (*Change) ProtoMessage()    {}
+func (*Change) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{52}
+}
+
+func (m *Change) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Change.Unmarshal(m, b)
+}
+func (m *Change) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Change.Marshal(b, m, deterministic)
+}
+func (m *Change) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Change.Merge(m, src)
+}
+func (m *Change) XXX_Size() int {
+	return xxx_messageInfo_Change.Size(m)
+}
+func (m *Change) XXX_DiscardUnknown() {
+	xxx_messageInfo_Change.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Change proto.InternalMessageInfo
+
+func (m *Change) GetPath() string {
+	if m != nil {
+		return m.Path
 	}
 	return ""
 }
 
-func (m *LogEntry) GetMessage() string {
+func (m *Change) GetFrom() string {
 	if m != nil {
-		return m.Message
+		return m.From
 	}
 	return ""
 }
 
-func (m *LogEntry) GetFields() string {
+func (m *Change) GetTo() string {
 	if m != nil {
-		return m.Fields
+		return m.To
 	}
 	return ""
 }
 
-// Process information to create a new process or return from a running process.
-type Process struct {
-	// Identifier is the unique ID that is assigned to this instance of the command.
-	Identifier string `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`
-	// Namespace that the process is running in.
-	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	// Pid is the process ID of the command.
-	Pid int32 `protobuf:"varint,10,opt,name=pid,proto3" json:"pid,omitempty"`
-	// Started time in milliseconds since epoch that the current PID started.
-	Started int64 `protobuf:"varint,11,opt,name=started,proto3" json:"started,omitempty"`
-	// Running duration in milliseconds that the process has been running.
-	Running int64 `protobuf:"varint,12,opt,name=running,proto3" json:"running,omitempty"`
-	// Ready is whether the process thinks it's ready (determined by watches on start).
-	Ready bool `protobuf:"varint,13,opt,name=ready,proto3" json:"ready,omitempty"`
-	// Command to run (or that is running)
-	Command *Command `protobuf:"bytes,20,opt,name=command,proto3" json:"command,omitempty"`
-	// Ports that are open (TCP/UDP for listening) by the process.
-	Ports []string `protobuf:"bytes,21,rep,name=ports,proto3" json:"ports,omitempty"`
-	// Observations that have been made by the `StartRequest.Watches` (which are supplied at start-up).
-	Observations         map[string]string `protobuf:"bytes,22,rep,name=observations,proto3" json:"observations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+// Schedule is a cron expression that starts jobs from a `StartRequest` template.
+type Schedule struct {
+	// Namespace that the schedule belongs to.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Name of the schedule.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Cron expression that determines when jobs are started.
+	Cron string `protobuf:"bytes,3,opt,name=cron,proto3" json:"cron,omitempty"`
+	// Timezone the cron expression is evaluated in.
+	Timezone string `protobuf:"bytes,4,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	// Start is the template used to start each job.
+	Start *StartRequest `protobuf:"bytes,5,opt,name=start,proto3" json:"start,omitempty"`
+	// Concurrency determines what happens when a tick occurs while a previous job is still running.
+	Concurrency Schedule_Concurrency `protobuf:"varint,6,opt,name=concurrency,proto3,enum=cynosure.Schedule_Concurrency" json:"concurrency,omitempty"`
+	// Suspended schedules do not start jobs until they are resumed.
+	Suspended bool `protobuf:"varint,7,opt,name=suspended,proto3" json:"suspended,omitempty"`
+	// Created time in milliseconds since epoch that the schedule was created.
+	Created int64 `protobuf:"varint,10,opt,name=created,proto3" json:"created,omitempty"`
+	// Next time in milliseconds since epoch that a job will be started (0 if suspended).
+	Next int64 `protobuf:"varint,11,opt,name=next,proto3" json:"next,omitempty"`
+	// Runs are the most recent ticks of the schedule (oldest first).
+	Runs                 []*ScheduleRun `protobuf:"bytes,12,rep,name=runs,proto3" json:"runs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
-func (m *Process) Reset()         { *m = Process{} }
-func (m *Process) String() string { return proto.CompactTextString(m) }
-func (*Process) ProtoMessage()    {}
-func (*Process) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cf0ba7c91fc7e6c9, []int{20}
+func (m *Schedule) Reset()         { *m = Schedule{} }
+func (m *Schedule) String() string { return proto.CompactTextString(m) }
+func (*Schedule) ProtoMessage()    {}
+func (*Schedule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{53}
 }
 
-func (m *Process) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Process.Unmarshal(m, b)
+func (m *Schedule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Schedule.Unmarshal(m, b)
 }
-func (m *Process) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Process.Marshal(b, m, deterministic)
+func (m *Schedule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Schedule.Marshal(b, m, deterministic)
 }
-func (m *Process) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Process.Merge(m, src)
+func (m *Schedule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Schedule.Merge(m, src)
 }
-func (m *Process) XXX_Size() int {
-	return xxx_messageInfo_Process.Size(m)
+func (m *Schedule) XXX_Size() int {
+	return xxx_messageInfo_Schedule.Size(m)
 }
-func (m *Process) XXX_DiscardUnknown() {
-	xxx_messageInfo_Process.DiscardUnknown(m)
+func (m *Schedule) XXX_DiscardUnknown() {
+	xxx_messageInfo_Schedule.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Process proto.InternalMessageInfo
+var xxx_messageInfo_Schedule proto.InternalMessageInfo
 
-func (m *Process) GetIdentifier() string {
+func (m *Schedule) GetNamespace() string {
 	if m != nil {
-		return m.Identifier
+		return m.Namespace
 	}
 	return ""
 }
 
-func (m *Process) GetNamespace() string {
+func (m *Schedule) GetName() string {
 	if m != nil {
-		return m.Namespace
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Schedule) GetCron() string {
+	if m != nil {
+		return m.Cron
+	}
+	return ""
+}
+
+func (m *Schedule) GetTimezone() string {
+	if m != nil {
+		return m.Timezone
 	}
 	return ""
 }
-
-func (m *Process) GetPid() int32 {
-	if m != nil {
-		return m.Pid
-	}
-	return 0
+
+func (m *Schedule) GetStart() *StartRequest {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *Schedule) GetConcurrency() Schedule_Concurrency {
+	if m != nil {
+		return m.Concurrency
+	}
+	return Schedule_Allow
+}
+
+func (m *Schedule) GetSuspended() bool {
+	if m != nil {
+		return m.Suspended
+	}
+	return false
+}
+
+func (m *Schedule) GetCreated() int64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+func (m *Schedule) GetNext() int64 {
+	if m != nil {
+		return m.Next
+	}
+	return 0
+}
+
+func (m *Schedule) GetRuns() []*ScheduleRun {
+	if m != nil {
+		return m.Runs
+	}
+	return nil
+}
+
+// ScheduleRun records what happened at a tick of a schedule.
+type ScheduleRun struct {
+	// Time in milliseconds since epoch of the tick.
+	Time int64 `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	// Group is the identifier of the job group that was started (empty if the run was skipped or failed to start).
+	Group string `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
+	// State of the job (`Running` until all of its processes have finished, and left unset for a skipped run).
+	State Process_State `protobuf:"varint,3,opt,name=state,proto3,enum=cynosure.Process_State" json:"state,omitempty"`
+	// Skipped is whether the tick was skipped because a previous job was still running.
+	Skipped bool `protobuf:"varint,4,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	// Message describes why the run was skipped, failed to start or failed.
+	Message string `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	// Finished time in milliseconds since epoch that the job finished.
+	Finished             int64    `protobuf:"varint,6,opt,name=finished,proto3" json:"finished,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ScheduleRun) Reset()         { *m = ScheduleRun{} }
+func (m *ScheduleRun) String() string { return proto.CompactTextString(m) }
+func (*ScheduleRun) ProtoMessage()    {}
+func (*ScheduleRun) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{54}
+}
+
+func (m *ScheduleRun) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ScheduleRun.Unmarshal(m, b)
+}
+func (m *ScheduleRun) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ScheduleRun.Marshal(b, m, deterministic)
+}
+func (m *ScheduleRun) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScheduleRun.Merge(m, src)
+}
+func (m *ScheduleRun) XXX_Size() int {
+	return xxx_messageInfo_ScheduleRun.Size(m)
+}
+func (m *ScheduleRun) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScheduleRun.DiscardUnknown(m)
 }
 
-func (m *Process) GetStarted() int64 {
+var xxx_messageInfo_ScheduleRun proto.InternalMessageInfo
+
+func (m *ScheduleRun) GetTime() int64 {
 	if m != nil {
-		return m.Started
+		return m.Time
 	}
 	return 0
 }
 
-func (m *Process) GetRunning() int64 {
+func (m *ScheduleRun) GetGroup() string {
 	if m != nil {
-		return m.Running
+		return m.Group
 	}
-	return 0
+	return ""
 }
 
-func (m *Process) GetReady() bool {
+func (m *ScheduleRun) GetState() Process_State {
 	if m != nil {
-		return m.Ready
+		return m.State
 	}
-	return false
+	return Process_Running
 }
 
-func (m *Process) GetCommand() *Command {
+func (m *ScheduleRun) GetSkipped() bool {
 	if m != nil {
-		return m.Command
+		return m.Skipped
 	}
-	return nil
+	return false
 }
 
-func (m *Process) GetPorts() []string {
+func (m *ScheduleRun) GetMessage() string {
 	if m != nil {
-		return m.Ports
+		return m.Message
 	}
-	return nil
+	return ""
 }
 
-func (m *Process) GetObservations() map[string]string {
+func (m *ScheduleRun) GetFinished() int64 {
 	if m != nil {
-		return m.Observations
+		return m.Finished
 	}
-	return nil
+	return 0
 }
 
 // Watch items enable observation of log lines and keep track of running state.
@@ -1316,7 +4140,7 @@ func (m *Watch) Reset()         { *m = Watch{} }
 func (m *Watch) String() string { return proto.CompactTextString(m) }
 func (*Watch) ProtoMessage()    {}
 func (*Watch) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cf0ba7c91fc7e6c9, []int{21}
+	return fileDescriptor_cf0ba7c91fc7e6c9, []int{55}
 }
 
 func (m *Watch) XXX_Unmarshal(b []byte) error {
@@ -1352,8 +4176,14 @@ func (m *Watch) GetState() Watch_State {
 }
 
 func init() {
+	proto.RegisterEnum("cynosure.StartRequest_Kind", StartRequest_Kind_name, StartRequest_Kind_value)
+	proto.RegisterEnum("cynosure.HookResult_Point", HookResult_Point_name, HookResult_Point_value)
+	proto.RegisterEnum("cynosure.Deps_Lost", Deps_Lost_name, Deps_Lost_value)
+	proto.RegisterEnum("cynosure.Expr_Op", Expr_Op_name, Expr_Op_value)
 	proto.RegisterEnum("cynosure.Filter_Type", Filter_Type_name, Filter_Type_value)
 	proto.RegisterEnum("cynosure.Filter_Op", Filter_Op_name, Filter_Op_value)
+	proto.RegisterEnum("cynosure.Process_State", Process_State_name, Process_State_value)
+	proto.RegisterEnum("cynosure.Schedule_Concurrency", Schedule_Concurrency_name, Schedule_Concurrency_value)
 	proto.RegisterEnum("cynosure.Watch_State", Watch_State_name, Watch_State_value)
 	proto.RegisterType((*RunningRequest)(nil), "cynosure.RunningRequest")
 	proto.RegisterType((*RunningResponse)(nil), "cynosure.RunningResponse")
@@ -1366,111 +4196,274 @@ func init() {
 	proto.RegisterType((*StartResponse)(nil), "cynosure.StartResponse")
 	proto.RegisterType((*StopRequest)(nil), "cynosure.StopRequest")
 	proto.RegisterType((*StopResponse)(nil), "cynosure.StopResponse")
+	proto.RegisterType((*ScaleRequest)(nil), "cynosure.ScaleRequest")
+	proto.RegisterType((*ScaleResponse)(nil), "cynosure.ScaleResponse")
+	proto.RegisterType((*ReplaceRequest)(nil), "cynosure.ReplaceRequest")
+	proto.RegisterType((*ReplaceResponse)(nil), "cynosure.ReplaceResponse")
+	proto.RegisterType((*HistoryRequest)(nil), "cynosure.HistoryRequest")
+	proto.RegisterType((*HistoryResponse)(nil), "cynosure.HistoryResponse")
+	proto.RegisterType((*RollbackRequest)(nil), "cynosure.RollbackRequest")
+	proto.RegisterType((*RollbackResponse)(nil), "cynosure.RollbackResponse")
+	proto.RegisterType((*DiffRequest)(nil), "cynosure.DiffRequest")
+	proto.RegisterType((*DiffResponse)(nil), "cynosure.DiffResponse")
+	proto.RegisterType((*ScheduleRequest)(nil), "cynosure.ScheduleRequest")
+	proto.RegisterType((*ScheduleResponse)(nil), "cynosure.ScheduleResponse")
+	proto.RegisterType((*SchedulesRequest)(nil), "cynosure.SchedulesRequest")
+	proto.RegisterType((*SchedulesResponse)(nil), "cynosure.SchedulesResponse")
+	proto.RegisterType((*SuspendRequest)(nil), "cynosure.SuspendRequest")
+	proto.RegisterType((*UnscheduleRequest)(nil), "cynosure.UnscheduleRequest")
+	proto.RegisterType((*UnscheduleResponse)(nil), "cynosure.UnscheduleResponse")
 	proto.RegisterType((*EnvironmentRequest)(nil), "cynosure.EnvironmentRequest")
 	proto.RegisterType((*EnvironmentResponse)(nil), "cynosure.EnvironmentResponse")
 	proto.RegisterType((*ImageRequest)(nil), "cynosure.ImageRequest")
 	proto.RegisterType((*ImageResponse)(nil), "cynosure.ImageResponse")
+	proto.RegisterType((*Job)(nil), "cynosure.Job")
 	proto.RegisterType((*Command)(nil), "cynosure.Command")
 	proto.RegisterMapType((map[string]*Deps)(nil), "cynosure.Command.RequirementsEntry")
+	proto.RegisterType((*Init)(nil), "cynosure.Init")
+	proto.RegisterType((*Hooks)(nil), "cynosure.Hooks")
+	proto.RegisterType((*Hook)(nil), "cynosure.Hook")
+	proto.RegisterType((*HookResult)(nil), "cynosure.HookResult")
+	proto.RegisterType((*Probe)(nil), "cynosure.Probe")
+	proto.RegisterType((*ProbeStatus)(nil), "cynosure.ProbeStatus")
+	proto.RegisterType((*Event)(nil), "cynosure.Event")
+	proto.RegisterType((*Socket)(nil), "cynosure.Socket")
+	proto.RegisterType((*RequirementStatus)(nil), "cynosure.RequirementStatus")
+	proto.RegisterType((*DepStatus)(nil), "cynosure.DepStatus")
+	proto.RegisterType((*ExprStatus)(nil), "cynosure.ExprStatus")
 	proto.RegisterType((*Dep)(nil), "cynosure.Dep")
 	proto.RegisterType((*Deps)(nil), "cynosure.Deps")
+	proto.RegisterType((*Expr)(nil), "cynosure.Expr")
 	proto.RegisterType((*Filter)(nil), "cynosure.Filter")
 	proto.RegisterType((*KV)(nil), "cynosure.KV")
 	proto.RegisterType((*LogEntry)(nil), "cynosure.LogEntry")
 	proto.RegisterType((*Process)(nil), "cynosure.Process")
+	proto.RegisterMapType((map[string]int32)(nil), "cynosure.Process.AllocationsEntry")
 	proto.RegisterMapType((map[string]string)(nil), "cynosure.Process.ObservationsEntry")
+	proto.RegisterType((*Revision)(nil), "cynosure.Revision")
+	proto.RegisterType((*Change)(nil), "cynosure.Change")
+	proto.RegisterType((*Schedule)(nil), "cynosure.Schedule")
+	proto.RegisterType((*ScheduleRun)(nil), "cynosure.ScheduleRun")
 	proto.RegisterType((*Watch)(nil), "cynosure.Watch")
 }
 
 func init() { proto.RegisterFile("cyno.proto", fileDescriptor_cf0ba7c91fc7e6c9) }
 
 var fileDescriptor_cf0ba7c91fc7e6c9 = []byte{
-	// 1353 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x57, 0xdd, 0x6e, 0x13, 0xc7,
-	0x17, 0xc7, 0x6b, 0x3b, 0x8e, 0x8f, 0x9d, 0x60, 0x86, 0xc4, 0xec, 0x7f, 0xff, 0xd0, 0x9a, 0x01,
-	0xa4, 0x10, 0x88, 0xb7, 0x18, 0x21, 0x55, 0x81, 0xaa, 0xe5, 0xab, 0x34, 0x82, 0x26, 0x74, 0xa1,
-	0x54, 0xe2, 0x6e, 0xb3, 0x9e, 0x6c, 0x56, 0xd8, 0x33, 0xcb, 0xce, 0x38, 0xa9, 0x85, 0xa2, 0x4a,
-	0xbd, 0xee, 0x55, 0xfb, 0x00, 0xbd, 0xef, 0x4d, 0x9f, 0xa0, 0x4f, 0xd1, 0x57, 0xe8, 0x03, 0xf4,
-	0x11, 0xaa, 0xf9, 0x58, 0xef, 0xf8, 0xa3, 0x10, 0xf5, 0xee, 0x9c, 0x39, 0xe7, 0xfc, 0x7e, 0x67,
-	0xcf, 0x9c, 0x99, 0x33, 0x0b, 0x10, 0x8d, 0x29, 0xeb, 0xa6, 0x19, 0x13, 0x0c, 0x2d, 0x4b, 0x99,
-	0x8f, 0x32, 0xe2, 0xdd, 0x54, 0x0b, 0xd1, 0x56, 0x4c, 0xe8, 0x16, 0x3f, 0x0e, 0xe3, 0x98, 0x64,
-	0x3e, 0x4b, 0x45, 0xc2, 0x28, 0xf7, 0x43, 0x4a, 0x99, 0x08, 0x95, 0xac, 0xe3, 0xbc, 0x8b, 0x31,
-	0x63, 0xf1, 0x80, 0xf8, 0x61, 0x9a, 0xcc, 0x5b, 0xf1, 0x3d, 0x58, 0x0d, 0x46, 0x94, 0x26, 0x34,
-	0x0e, 0xc8, 0xdb, 0x11, 0xe1, 0x02, 0x6d, 0x42, 0xed, 0x20, 0x19, 0x08, 0x92, 0x71, 0xb7, 0xd4,
-	0x29, 0x6f, 0x34, 0x7a, 0xad, 0x6e, 0xce, 0xdc, 0xfd, 0x52, 0x19, 0x82, 0xdc, 0x01, 0x3f, 0x80,
-	0xb3, 0x93, 0x68, 0x9e, 0x32, 0xca, 0x09, 0xf2, 0xa1, 0x9e, 0x66, 0x2c, 0x22, 0x9c, 0x93, 0x1c,
-	0xe0, 0x5c, 0x01, 0xf0, 0x5c, 0x9b, 0x82, 0xc2, 0x07, 0x6f, 0x41, 0x63, 0x87, 0x1e, 0xb0, 0x9c,
-	0xfe, 0x23, 0x80, 0xa4, 0x4f, 0xa8, 0x48, 0x0e, 0x12, 0x92, 0xb9, 0xa5, 0x4e, 0x69, 0xa3, 0x1e,
-	0x58, 0x2b, 0xf8, 0x2e, 0x34, 0xb5, 0xbb, 0xe1, 0xbb, 0x01, 0x35, 0x83, 0xa5, 0x9c, 0x17, 0xb2,
-	0xe5, 0x1e, 0xf8, 0x0d, 0x34, 0x9e, 0xb1, 0x98, 0x9f, 0x92, 0x0b, 0x21, 0xa8, 0x1c, 0x92, 0xb0,
-	0xef, 0x42, 0xa7, 0xb4, 0x51, 0x0e, 0x94, 0x2c, 0xd7, 0x44, 0x98, 0x0c, 0xdc, 0x86, 0x5e, 0x93,
-	0x32, 0x5a, 0x83, 0x2a, 0x4f, 0x68, 0x44, 0xdc, 0xa6, 0x82, 0xd0, 0x0a, 0xa6, 0xd0, 0xd4, 0x64,
-	0x26, 0xd3, 0x9b, 0x50, 0x23, 0x54, 0x64, 0xc9, 0xa4, 0x2e, 0xa8, 0xc8, 0xf4, 0x19, 0x8b, 0x1f,
-	0x53, 0x91, 0x8d, 0x83, 0xdc, 0x45, 0x62, 0x46, 0x6c, 0x44, 0x85, 0xeb, 0x28, 0x22, 0xad, 0x20,
-	0x0f, 0x96, 0x23, 0x46, 0x45, 0x42, 0x47, 0xc4, 0x2d, 0x2b, 0xb2, 0x89, 0x8e, 0x7f, 0x77, 0xa0,
-	0xf9, 0x42, 0x84, 0x99, 0xc8, 0x3f, 0xef, 0x06, 0xd4, 0x22, 0x36, 0x1c, 0x86, 0xb4, 0x3f, 0x5f,
-	0x9a, 0x87, 0xda, 0x10, 0xe4, 0x1e, 0xe8, 0x22, 0xd4, 0x69, 0x38, 0x24, 0x3c, 0x0d, 0x23, 0xa2,
-	0x38, 0xeb, 0x41, 0xb1, 0x80, 0xae, 0xc2, 0xd2, 0x20, 0xdc, 0x27, 0x03, 0xee, 0x96, 0x55, 0xea,
-	0xcd, 0x02, 0xe9, 0xe9, 0xab, 0xc0, 0xd8, 0x10, 0x86, 0x26, 0xa1, 0x47, 0x49, 0xc6, 0xe8, 0x90,
-	0x50, 0xc1, 0xdd, 0x6a, 0xa7, 0xbc, 0x51, 0x0f, 0xa6, 0xd6, 0xd0, 0x67, 0x50, 0x3b, 0x0e, 0x45,
-	0x74, 0x48, 0xb8, 0x0b, 0x0a, 0xea, 0x4a, 0x01, 0x65, 0x67, 0xdf, 0xfd, 0x4e, 0x7b, 0x99, 0xb2,
-	0x98, 0x18, 0xef, 0x29, 0x34, 0x6d, 0x03, 0x6a, 0x41, 0xf9, 0x0d, 0x19, 0x9b, 0xbd, 0x93, 0x22,
-	0xba, 0x06, 0xd5, 0xa3, 0x70, 0x30, 0xd2, 0x1f, 0xd1, 0xe8, 0x9d, 0x2d, 0xe0, 0x55, 0x60, 0xa0,
-	0xad, 0xdb, 0xce, 0xa7, 0x25, 0x7c, 0x0f, 0x56, 0x0c, 0xe5, 0x7f, 0x69, 0xa6, 0x2d, 0x68, 0xbc,
-	0x10, 0x2c, 0x3d, 0x6d, 0xe3, 0x6e, 0xc8, 0xdd, 0x91, 0xee, 0x86, 0xcb, 0x85, 0x1a, 0x1f, 0x45,
-	0x13, 0xae, 0xe5, 0x20, 0x57, 0xf1, 0x17, 0x80, 0x1e, 0x17, 0x25, 0xcb, 0xf1, 0x11, 0x54, 0xe4,
-	0x7e, 0x18, 0x64, 0x25, 0xa3, 0x36, 0x2c, 0xa9, 0xaf, 0xe1, 0xae, 0xa3, 0x4a, 0x6d, 0x34, 0xec,
-	0xc3, 0xf9, 0x29, 0x84, 0x53, 0x50, 0x36, 0x77, 0x86, 0x61, 0x4c, 0x72, 0x32, 0x0f, 0x96, 0x75,
-	0xea, 0x22, 0xaf, 0xed, 0x44, 0x97, 0x9d, 0x99, 0x48, 0x5f, 0x55, 0xe0, 0x66, 0xa0, 0x15, 0x7c,
-	0x1f, 0x56, 0x0c, 0x82, 0x21, 0x6b, 0xc3, 0x12, 0xf9, 0x3e, 0xe1, 0x22, 0xe7, 0x32, 0x9a, 0x9d,
-	0x84, 0x33, 0x9d, 0xc4, 0xaf, 0x0e, 0xd4, 0x4c, 0x5f, 0x2e, 0xfc, 0xda, 0x09, 0x31, 0xe8, 0x63,
-	0xa6, 0x14, 0xb9, 0x2a, 0xcf, 0xcc, 0x58, 0x9d, 0xc8, 0x7a, 0xa0, 0x15, 0x19, 0x1f, 0x66, 0x31,
-	0x77, 0x9b, 0xaa, 0x2e, 0x4a, 0x96, 0xbd, 0x42, 0xe8, 0x91, 0xbb, 0xa2, 0x96, 0xa4, 0x88, 0x9e,
-	0x40, 0x33, 0x23, 0x6f, 0x47, 0x49, 0x46, 0x74, 0xc3, 0xae, 0xce, 0x76, 0xa4, 0x49, 0xa7, 0x1b,
-	0x58, 0x5e, 0xba, 0x23, 0xa7, 0x02, 0x65, 0x12, 0x83, 0x84, 0x12, 0xee, 0xf6, 0xf4, 0x69, 0x55,
-	0x8a, 0xb7, 0x07, 0xe7, 0xe6, 0x02, 0x17, 0x74, 0xec, 0xd5, 0xe9, 0x8e, 0x5d, 0x2d, 0xe8, 0x1f,
-	0x91, 0x94, 0xdb, 0x0d, 0x7b, 0x07, 0xca, 0x8f, 0x48, 0xfa, 0xde, 0xdd, 0x41, 0x50, 0x39, 0x0e,
-	0x13, 0x61, 0x6a, 0xa4, 0x64, 0x7c, 0x1d, 0x2a, 0x12, 0x09, 0x5d, 0x86, 0x4a, 0x9f, 0xa4, 0xf9,
-	0xf5, 0xb3, 0x32, 0xc5, 0x13, 0x28, 0x13, 0xfe, 0xa3, 0x04, 0x4b, 0xfa, 0x96, 0x47, 0xd7, 0xa1,
-	0x22, 0xc6, 0xa9, 0xde, 0x82, 0xd5, 0xde, 0xfa, 0xec, 0x14, 0xe8, 0xbe, 0x1c, 0xa7, 0x24, 0x50,
-	0x2e, 0xe8, 0x0a, 0x38, 0x2c, 0x55, 0xe9, 0xaf, 0xf6, 0xce, 0xcf, 0x39, 0xee, 0xa5, 0x81, 0xc3,
-	0x52, 0xab, 0x59, 0xcb, 0x76, 0xb3, 0xe6, 0x05, 0x81, 0x49, 0x41, 0x70, 0x07, 0x2a, 0x12, 0x1c,
-	0xad, 0x40, 0x7d, 0x37, 0xbf, 0x82, 0x5a, 0x67, 0x50, 0x1d, 0xaa, 0xcf, 0xe4, 0x45, 0xd3, 0x2a,
-	0xe1, 0x0b, 0xe0, 0xec, 0xa5, 0x68, 0x09, 0x9c, 0x1d, 0xaa, 0x0d, 0xbb, 0x4c, 0xec, 0xd0, 0x56,
-	0x09, 0xdf, 0x04, 0xe7, 0xe9, 0xab, 0x05, 0x35, 0x5e, 0xb3, 0x6b, 0x5c, 0x37, 0x35, 0xc5, 0x3f,
-	0x95, 0x60, 0x39, 0xbf, 0x7a, 0x65, 0x50, 0xca, 0x74, 0xb7, 0x96, 0x03, 0x29, 0xaa, 0xbb, 0x3e,
-	0x19, 0xe6, 0x31, 0x4a, 0x96, 0x5f, 0xc1, 0xd9, 0x28, 0x8b, 0xf2, 0xfb, 0xd7, 0x68, 0x32, 0x3a,
-	0x0b, 0x8f, 0xdd, 0x8a, 0xa6, 0xcc, 0xc2, 0x63, 0xd9, 0xe8, 0x43, 0xc2, 0x79, 0xd1, 0xb0, 0xb9,
-	0x2a, 0x31, 0x0e, 0x12, 0x32, 0xe8, 0x73, 0xd3, 0xb3, 0x46, 0xc3, 0x7f, 0x3b, 0x50, 0x33, 0xd7,
-	0xcc, 0x07, 0x67, 0xd3, 0xfb, 0xef, 0x6b, 0xf9, 0x2d, 0x89, 0x1e, 0x5c, 0xd5, 0x40, 0x8a, 0xea,
-	0xd8, 0xc9, 0xbb, 0x8e, 0xf4, 0xcd, 0xe8, 0xca, 0x55, 0x69, 0xc9, 0xf4, 0x10, 0x57, 0xf3, 0xab,
-	0x1c, 0xe4, 0xaa, 0x2c, 0x5a, 0x46, 0xc2, 0xfe, 0xd8, 0x5d, 0x51, 0x07, 0x55, 0x2b, 0xf6, 0x58,
-	0x59, 0xfb, 0xe0, 0x58, 0x59, 0x83, 0x6a, 0xca, 0x32, 0xc1, 0xdd, 0x75, 0xb5, 0xe7, 0x5a, 0x91,
-	0xe7, 0x8e, 0xed, 0x73, 0x92, 0x1d, 0xe9, 0xb7, 0x88, 0xdb, 0x9e, 0x3d, 0x77, 0xa6, 0x0a, 0xdd,
-	0x3d, 0xcb, 0xcb, 0x9c, 0x3b, 0x3b, 0xd0, 0xfb, 0x1c, 0xce, 0xcd, 0xb9, 0x9c, 0x76, 0xf7, 0xd5,
-	0x89, 0xfa, 0x01, 0xaa, 0x6a, 0x2c, 0x48, 0x97, 0xa1, 0x14, 0x4c, 0x98, 0x56, 0xd0, 0x0d, 0xa8,
-	0x72, 0x11, 0x0a, 0x62, 0x7a, 0x7b, 0x7d, 0x66, 0x98, 0xc8, 0x89, 0x25, 0x48, 0xa0, 0x7d, 0xf0,
-	0x6d, 0xa8, 0x2a, 0x5d, 0xf6, 0xed, 0xb7, 0x34, 0x3a, 0x0c, 0x69, 0x4c, 0xfa, 0xad, 0x33, 0x52,
-	0xfd, 0x3a, 0x7c, 0x43, 0x02, 0x59, 0xbd, 0x56, 0x09, 0x35, 0x61, 0x79, 0x97, 0x09, 0xad, 0x39,
-	0xbd, 0xdf, 0xaa, 0x50, 0xbe, 0xff, 0x7c, 0x07, 0x11, 0xa8, 0x99, 0xa7, 0x14, 0x72, 0x0b, 0x96,
-	0xe9, 0xb7, 0x99, 0xf7, 0xbf, 0x05, 0x16, 0x7d, 0xdd, 0xe2, 0x6b, 0x3f, 0xfe, 0xf9, 0xd7, 0x2f,
-	0xce, 0xc7, 0xa8, 0xe1, 0x1f, 0xdd, 0xf2, 0xcd, 0x06, 0xbe, 0x6e, 0x61, 0x5b, 0xdd, 0x2e, 0x6d,
-	0xa2, 0x97, 0x50, 0x91, 0xcf, 0x27, 0x64, 0x7d, 0x89, 0xf5, 0xfa, 0xf2, 0xda, 0xb3, 0xcb, 0x06,
-	0xfd, 0x92, 0x42, 0xbf, 0x80, 0xd6, 0x25, 0x5c, 0x42, 0x0f, 0x98, 0xff, 0xae, 0xe8, 0xc5, 0x13,
-	0x89, 0x2a, 0x9f, 0x3a, 0x36, 0xaa, 0xf5, 0xce, 0xb2, 0x51, 0xed, 0x17, 0xd1, 0x34, 0xea, 0x80,
-	0xc5, 0x7c, 0x16, 0xb5, 0xaa, 0xc6, 0x33, 0x6a, 0x2f, 0x7e, 0x22, 0x78, 0x17, 0xe6, 0xd6, 0x0d,
-	0xb0, 0xa7, 0x80, 0xd7, 0x70, 0x5d, 0x02, 0xab, 0x3e, 0xdf, 0x9e, 0x74, 0xe4, 0x4b, 0xa8, 0xc8,
-	0x39, 0x6c, 0xe7, 0x6a, 0x8d, 0x71, 0xaf, 0x3d, 0xbb, 0x3c, 0x9d, 0xeb, 0xe6, 0xba, 0x86, 0x64,
-	0xe9, 0x74, 0xae, 0x43, 0x68, 0x58, 0x13, 0x17, 0x5d, 0x2c, 0x50, 0xe6, 0x47, 0xb9, 0x77, 0xe9,
-	0x5f, 0xac, 0x86, 0xea, 0xb2, 0xa2, 0xfa, 0x3f, 0x6e, 0x4b, 0x2a, 0xeb, 0xf1, 0xe4, 0xbf, 0x93,
-	0x07, 0xfc, 0x44, 0x6e, 0xe3, 0x08, 0xaa, 0x6a, 0xda, 0xda, 0xa5, 0xb1, 0x07, 0xb8, 0x5d, 0x9a,
-	0xa9, 0xb1, 0x8c, 0xef, 0x2a, 0xf0, 0x3b, 0x68, 0x4d, 0xed, 0xa4, 0x34, 0xe5, 0x1f, 0x22, 0xc6,
-	0x27, 0xaf, 0x2f, 0xe1, 0x85, 0xeb, 0xdb, 0x7a, 0xd6, 0x3e, 0xf8, 0xe6, 0xe7, 0xfb, 0xbb, 0xa8,
-	0xda, 0x2b, 0xdf, 0xea, 0x7e, 0xb2, 0x59, 0x72, 0xb2, 0x07, 0xe0, 0x3d, 0x34, 0x2c, 0x9d, 0x27,
-	0x89, 0xf8, 0x6a, 0xb4, 0xdf, 0xc9, 0x48, 0xca, 0x78, 0x22, 0x58, 0x36, 0x46, 0x57, 0x0f, 0x85,
-	0x48, 0xf9, 0xb6, 0xef, 0xc7, 0x89, 0x38, 0x1c, 0xed, 0x77, 0x23, 0x36, 0xf4, 0x29, 0xcb, 0xe2,
-	0x90, 0xd2, 0xd0, 0xcf, 0xb3, 0xdb, 0x5f, 0x52, 0xff, 0x21, 0xb7, 0xff, 0x09, 0x00, 0x00, 0xff,
-	0xff, 0x99, 0x49, 0x36, 0xdd, 0xeb, 0x0c, 0x00, 0x00,
+	// 3402 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x3a, 0xdb, 0x72, 0xdc, 0xc6,
+	0xb1, 0xc2, 0x62, 0x77, 0xb9, 0xdb, 0xbb, 0x24, 0x97, 0x23, 0x91, 0x84, 0x96, 0x92, 0x4d, 0x41,
+	0xbe, 0xd0, 0xba, 0x70, 0x25, 0xfa, 0xb8, 0xea, 0x94, 0x7c, 0x7c, 0xac, 0x1b, 0x2d, 0xd3, 0x92,
+	0x25, 0x1a, 0x94, 0x75, 0xaa, 0x7c, 0x2a, 0x51, 0x81, 0xc0, 0x70, 0x89, 0x70, 0x17, 0x03, 0x03,
+	0x58, 0x4a, 0x8c, 0xcb, 0x95, 0x4a, 0x52, 0x95, 0xbc, 0xe4, 0x21, 0x89, 0x3f, 0x21, 0xbf, 0x90,
+	0xf2, 0x4b, 0x2e, 0x4f, 0xa9, 0xfc, 0x40, 0xbe, 0x20, 0x29, 0x3f, 0xe6, 0x29, 0x5f, 0x90, 0xea,
+	0xb9, 0x00, 0x03, 0x2c, 0x28, 0xb1, 0xe4, 0xc7, 0x3c, 0x2d, 0x7a, 0xa6, 0xa7, 0xa7, 0x6f, 0xd3,
+	0xdd, 0xd3, 0xb3, 0x00, 0xde, 0x51, 0xc8, 0xd6, 0xa3, 0x98, 0xa5, 0x8c, 0xb4, 0xf0, 0x3b, 0x99,
+	0xc4, 0xb4, 0x7f, 0x85, 0x0f, 0x78, 0x57, 0x87, 0x34, 0xbc, 0x9a, 0x3c, 0x73, 0x87, 0x43, 0x1a,
+	0x0f, 0x58, 0x94, 0x06, 0x2c, 0x4c, 0x06, 0x6e, 0x18, 0xb2, 0xd4, 0xe5, 0xdf, 0x62, 0x5d, 0xff,
+	0xdc, 0x90, 0xb1, 0xe1, 0x88, 0x0e, 0xdc, 0x28, 0x98, 0x9e, 0xb5, 0xff, 0x07, 0xe6, 0x9c, 0x49,
+	0x18, 0x06, 0xe1, 0xd0, 0xa1, 0x5f, 0x4e, 0x68, 0x92, 0x92, 0x4b, 0x30, 0xb3, 0x17, 0x8c, 0x52,
+	0x1a, 0x27, 0x96, 0xb1, 0x6a, 0xae, 0x75, 0x36, 0x7a, 0xeb, 0x6a, 0xe7, 0xf5, 0x8f, 0xf8, 0x84,
+	0xa3, 0x10, 0xec, 0xdb, 0x30, 0x9f, 0xad, 0x4e, 0x22, 0x16, 0x26, 0x94, 0x0c, 0xa0, 0x1d, 0xc5,
+	0xcc, 0xa3, 0x49, 0x42, 0x15, 0x81, 0x85, 0x9c, 0xc0, 0xb6, 0x98, 0x72, 0x72, 0x1c, 0xfb, 0x2a,
+	0x74, 0xb6, 0xc2, 0x3d, 0xa6, 0xb6, 0x7f, 0x0d, 0x20, 0xf0, 0x69, 0x98, 0x06, 0x7b, 0x01, 0x8d,
+	0x2d, 0x63, 0xd5, 0x58, 0x6b, 0x3b, 0xda, 0x88, 0xfd, 0x3e, 0x74, 0x05, 0xba, 0xdc, 0xef, 0x32,
+	0xcc, 0x48, 0x5a, 0x1c, 0xb9, 0x72, 0x37, 0x85, 0x61, 0x1f, 0x40, 0xe7, 0x01, 0x1b, 0x26, 0x27,
+	0xdc, 0x8b, 0x10, 0xa8, 0xef, 0x53, 0xd7, 0xb7, 0x60, 0xd5, 0x58, 0x33, 0x1d, 0xfe, 0x8d, 0x63,
+	0xa9, 0x1b, 0x8c, 0xac, 0x8e, 0x18, 0xc3, 0x6f, 0x72, 0x06, 0x1a, 0x49, 0x10, 0x7a, 0xd4, 0xea,
+	0x72, 0x12, 0x02, 0xb0, 0x43, 0xe8, 0x8a, 0xcd, 0x24, 0xa7, 0x57, 0x60, 0x86, 0x86, 0x69, 0x1c,
+	0x64, 0x7a, 0x21, 0x39, 0xa7, 0x0f, 0xd8, 0x70, 0x33, 0x4c, 0xe3, 0x23, 0x47, 0xa1, 0x20, 0x4d,
+	0x8f, 0x4d, 0xc2, 0xd4, 0xaa, 0xf1, 0x8d, 0x04, 0x40, 0xfa, 0xd0, 0xf2, 0x58, 0x98, 0x06, 0xe1,
+	0x84, 0x5a, 0x26, 0xdf, 0x2c, 0x83, 0xed, 0x3f, 0x98, 0xd0, 0xdd, 0x49, 0xdd, 0x38, 0x55, 0xe2,
+	0x5d, 0x86, 0x19, 0x8f, 0x8d, 0xc7, 0x6e, 0xe8, 0x4f, 0xab, 0xe6, 0x8e, 0x98, 0x70, 0x14, 0x06,
+	0x39, 0x07, 0xed, 0xd0, 0x1d, 0xd3, 0x24, 0x72, 0x3d, 0xca, 0xf7, 0x6c, 0x3b, 0xf9, 0x00, 0x79,
+	0x03, 0x9a, 0x23, 0x77, 0x97, 0x8e, 0x12, 0xcb, 0xe4, 0xac, 0x77, 0x73, 0x4a, 0xf7, 0x9f, 0x38,
+	0x72, 0x8e, 0xd8, 0xd0, 0xa5, 0xe1, 0x61, 0x10, 0xb3, 0x70, 0x4c, 0xc3, 0x34, 0xb1, 0x1a, 0xab,
+	0xe6, 0x5a, 0xdb, 0x29, 0x8c, 0xa1, 0x04, 0x31, 0x8d, 0x46, 0x81, 0xe7, 0x26, 0x56, 0x73, 0xd5,
+	0x58, 0x6b, 0x38, 0x19, 0x4c, 0x06, 0x50, 0x3f, 0x08, 0x42, 0xdf, 0x9a, 0x59, 0x35, 0xd6, 0xe6,
+	0x36, 0x56, 0xf2, 0x3d, 0x74, 0xb1, 0xd6, 0xef, 0x07, 0xa1, 0xef, 0x70, 0x44, 0xf2, 0x3a, 0x98,
+	0x3f, 0x62, 0xbb, 0x56, 0x8b, 0x4b, 0x37, 0x9b, 0xe3, 0x7f, 0xc2, 0x76, 0x1d, 0x9c, 0x21, 0x1f,
+	0xc0, 0xcc, 0x33, 0x37, 0xf5, 0xf6, 0x69, 0x62, 0x01, 0x67, 0xfc, 0xe2, 0x31, 0x44, 0xff, 0x4f,
+	0x60, 0x49, 0x23, 0xc8, 0x35, 0xfd, 0xfb, 0xd0, 0xd5, 0x27, 0x48, 0x0f, 0xcc, 0x03, 0x7a, 0x24,
+	0x3d, 0x05, 0x3f, 0xc9, 0x9b, 0xd0, 0x38, 0x74, 0x47, 0x13, 0xa1, 0xb2, 0xce, 0xc6, 0x7c, 0x4e,
+	0x9e, 0x2f, 0x74, 0xc4, 0xec, 0x8d, 0xda, 0x7f, 0x1b, 0xf6, 0x39, 0xa8, 0x23, 0xeb, 0xa4, 0x03,
+	0x33, 0x3b, 0x34, 0x3e, 0x0c, 0x3c, 0xda, 0x3b, 0x45, 0x66, 0xc0, 0xfc, 0x84, 0xed, 0xf6, 0x0c,
+	0xfb, 0xe7, 0x06, 0xcc, 0x4a, 0x8e, 0x5e, 0xc1, 0xb3, 0xd1, 0x5d, 0x86, 0x31, 0x9b, 0x44, 0xd2,
+	0x74, 0x02, 0x28, 0x1e, 0x46, 0xf3, 0x64, 0x87, 0x71, 0x27, 0x65, 0xd1, 0x49, 0x0f, 0xe3, 0x1a,
+	0x74, 0x05, 0xba, 0x64, 0xd9, 0x82, 0x99, 0x64, 0xe2, 0x65, 0x2c, 0xb7, 0x1c, 0x05, 0xda, 0x37,
+	0xa1, 0xbb, 0xe3, 0xb9, 0x23, 0xaa, 0x28, 0x67, 0xfc, 0x1a, 0x3a, 0xbf, 0xba, 0x73, 0xd4, 0x8a,
+	0xce, 0x61, 0xdf, 0x84, 0x59, 0x49, 0xe1, 0x55, 0x23, 0xcd, 0xaf, 0x0d, 0x98, 0x73, 0x68, 0x34,
+	0x72, 0x3d, 0x7a, 0xd2, 0x08, 0x70, 0x05, 0x1a, 0x09, 0x1a, 0x45, 0x9a, 0x77, 0xa9, 0xda, 0x7b,
+	0x1c, 0x81, 0x84, 0xec, 0xfb, 0xd4, 0xf5, 0x47, 0x41, 0x48, 0x65, 0xcc, 0xc8, 0x60, 0x1e, 0x23,
+	0x26, 0xf1, 0x90, 0xf2, 0xc0, 0xd1, 0x70, 0x04, 0x60, 0xff, 0xca, 0x80, 0xf9, 0x8c, 0xa5, 0x97,
+	0x29, 0x11, 0x67, 0xc6, 0x34, 0x49, 0xdc, 0xa1, 0x3a, 0xa1, 0x0a, 0xcc, 0xd5, 0x69, 0x1e, 0x6b,
+	0xfe, 0xfa, 0x09, 0x34, 0x74, 0x1b, 0xe6, 0x3e, 0x0e, 0x92, 0x94, 0xc5, 0x47, 0x4a, 0x41, 0x85,
+	0xb0, 0x60, 0x94, 0xc3, 0x02, 0x81, 0x3a, 0x02, 0x92, 0x1b, 0xfe, 0x6d, 0xdf, 0x81, 0xf9, 0x8c,
+	0x86, 0x94, 0xe8, 0x1a, 0xb4, 0x63, 0x7a, 0x18, 0x24, 0x98, 0x77, 0xa6, 0x63, 0x9f, 0x23, 0xa7,
+	0x9c, 0x1c, 0xc9, 0xfe, 0x0d, 0xea, 0x85, 0x8d, 0x46, 0xbb, 0xae, 0x77, 0xf0, 0xca, 0xac, 0x08,
+	0x77, 0x12, 0x24, 0xb9, 0x62, 0x4c, 0x27, 0x83, 0x5f, 0xc1, 0x56, 0x7f, 0x32, 0xa0, 0x97, 0xf3,
+	0xf4, 0x3d, 0x8c, 0xb5, 0x5e, 0x62, 0xab, 0x5a, 0x1b, 0x39, 0xab, 0x99, 0x71, 0xeb, 0xc7, 0x1a,
+	0xb7, 0x71, 0x02, 0xe3, 0x7a, 0xd0, 0xb9, 0x1b, 0xec, 0xed, 0xbd, 0xba, 0x3a, 0x09, 0xd4, 0xf7,
+	0x62, 0x36, 0x96, 0xaa, 0xe4, 0xdf, 0x64, 0x0e, 0x6a, 0x29, 0xe3, 0x8c, 0x99, 0x4e, 0x2d, 0x65,
+	0xf6, 0x0f, 0xa1, 0x2b, 0x36, 0x91, 0xfa, 0x51, 0x6b, 0x8c, 0xa9, 0x35, 0x35, 0xb5, 0x06, 0x2b,
+	0x0e, 0x6f, 0xdf, 0x0d, 0x87, 0x59, 0x8c, 0xd2, 0x2a, 0x8e, 0x3b, 0x7c, 0xc2, 0x51, 0x08, 0xf6,
+	0x4f, 0x6b, 0x30, 0xbf, 0xe3, 0xed, 0x53, 0x7f, 0x32, 0xa2, 0xdf, 0x4b, 0x12, 0x2f, 0x96, 0xda,
+	0x6f, 0x3b, 0xfc, 0x1b, 0x1d, 0x22, 0x0d, 0xc6, 0xf4, 0xc7, 0x2c, 0xa4, 0x52, 0xd1, 0x19, 0x9c,
+	0x87, 0x81, 0xc6, 0x49, 0xc2, 0xc0, 0x4d, 0xe8, 0x78, 0x2c, 0xf4, 0x26, 0x71, 0x4c, 0x43, 0xef,
+	0x88, 0x67, 0xb9, 0xb9, 0x8d, 0xd7, 0xb4, 0x35, 0x92, 0xff, 0xf5, 0x3b, 0x39, 0x96, 0xa3, 0x2f,
+	0x41, 0x89, 0x92, 0x49, 0x12, 0xd1, 0xd0, 0xa7, 0x22, 0x1b, 0xb6, 0x9c, 0x7c, 0xc0, 0xbe, 0x0d,
+	0xbd, 0x5c, 0x05, 0x52, 0xcf, 0xeb, 0xd0, 0x4a, 0xe4, 0x98, 0x65, 0x94, 0x7d, 0x2a, 0xc3, 0xce,
+	0x70, 0xec, 0x6b, 0x39, 0x8d, 0xe4, 0x44, 0x7a, 0xb4, 0x37, 0x61, 0x41, 0x5b, 0x91, 0x9f, 0x6c,
+	0x45, 0xb2, 0xe2, 0x64, 0x67, 0xfb, 0xe6, 0x48, 0x18, 0x62, 0x76, 0x84, 0x24, 0xaf, 0x1e, 0x62,
+	0x36, 0x61, 0xe1, 0xf3, 0x30, 0xf9, 0xbe, 0x5e, 0x60, 0xaf, 0x03, 0xd1, 0xc9, 0x9c, 0x20, 0x87,
+	0x91, 0xcd, 0xbc, 0x94, 0x51, 0xfb, 0x2a, 0xca, 0x86, 0xe6, 0x5f, 0x4b, 0xd0, 0xe4, 0x79, 0x1f,
+	0xb3, 0x18, 0x96, 0x40, 0x12, 0xb2, 0x07, 0x70, 0xba, 0x40, 0xe1, 0x24, 0x69, 0x73, 0x6b, 0xec,
+	0x0e, 0x33, 0x21, 0xfb, 0xd0, 0x12, 0xd9, 0x29, 0x55, 0x55, 0x48, 0x06, 0x63, 0x98, 0x08, 0xc6,
+	0x2a, 0xdc, 0x74, 0x1d, 0x01, 0xd8, 0xb7, 0x60, 0x56, 0x52, 0x90, 0x9b, 0x2d, 0x41, 0x93, 0x3e,
+	0x0f, 0x92, 0x54, 0xed, 0x25, 0x21, 0x9d, 0x89, 0x5a, 0x91, 0x89, 0x1f, 0xf0, 0x1a, 0x05, 0x11,
+	0x62, 0xaa, 0xea, 0x57, 0x8c, 0x8b, 0x0a, 0x2c, 0xc4, 0xd2, 0x5a, 0x29, 0x96, 0xae, 0x42, 0x27,
+	0x72, 0x63, 0x77, 0x34, 0xa2, 0xa3, 0x20, 0x11, 0xb1, 0xa3, 0xe1, 0xe8, 0x43, 0xf6, 0xbf, 0xea,
+	0x30, 0x23, 0xcb, 0xd1, 0x4a, 0x65, 0x66, 0x72, 0x81, 0x08, 0x7f, 0x1c, 0xc0, 0x51, 0x2c, 0x95,
+	0x8f, 0x78, 0x8c, 0x6e, 0x3b, 0x02, 0xc0, 0xf5, 0x6e, 0x3c, 0x4c, 0xac, 0x2e, 0x57, 0x3b, 0xff,
+	0xc6, 0xa2, 0x8d, 0x86, 0x87, 0xd6, 0x2c, 0x1f, 0xc2, 0x4f, 0x72, 0x0f, 0xba, 0x31, 0xfd, 0x72,
+	0x12, 0xc4, 0x54, 0xd4, 0xa9, 0x73, 0xe5, 0xd2, 0x50, 0xb2, 0xb3, 0xee, 0x68, 0x58, 0xa2, 0x34,
+	0x2c, 0x2c, 0x44, 0x26, 0x22, 0x16, 0xa7, 0x89, 0x35, 0xcf, 0x89, 0x0b, 0x80, 0xd8, 0x50, 0x0f,
+	0xc2, 0x20, 0xb5, 0x7a, 0x9c, 0xec, 0x5c, 0x4e, 0x76, 0x2b, 0x0c, 0x52, 0x87, 0xcf, 0x61, 0xdd,
+	0xb8, 0xcf, 0xd8, 0x41, 0x62, 0x2d, 0x94, 0xeb, 0xc6, 0x8f, 0x71, 0xd8, 0x11, 0xb3, 0xe4, 0x32,
+	0xb4, 0x46, 0xc1, 0x21, 0x0d, 0xd1, 0x2a, 0xa4, 0x8c, 0xb9, 0x1d, 0xb3, 0x5d, 0xea, 0x64, 0x08,
+	0xe4, 0x2a, 0xa6, 0x59, 0xd7, 0x0f, 0x38, 0xf6, 0xe9, 0x6a, 0xec, 0x1c, 0x03, 0x1d, 0x21, 0x64,
+	0x69, 0xb0, 0x77, 0x64, 0x9d, 0x11, 0x8e, 0x20, 0x20, 0xb4, 0x26, 0xaf, 0x7f, 0x7d, 0x36, 0xb4,
+	0x16, 0x85, 0x35, 0x15, 0x8c, 0xa1, 0x3a, 0x61, 0xde, 0x01, 0x4d, 0x13, 0x6b, 0xa9, 0x1c, 0xaa,
+	0x77, 0xf8, 0x84, 0xa3, 0x10, 0x84, 0xbf, 0x78, 0xfb, 0xd4, 0x3b, 0xb0, 0x96, 0x39, 0x19, 0x05,
+	0x16, 0xfc, 0xc5, 0x9a, 0xce, 0xbd, 0xf8, 0x9b, 0x58, 0x1b, 0x7c, 0x42, 0x00, 0xfd, 0x47, 0xb0,
+	0x30, 0x65, 0x8b, 0x8a, 0x6a, 0xfc, 0x8d, 0x62, 0x35, 0xae, 0xa9, 0xfe, 0x2e, 0x8d, 0x12, 0xbd,
+	0x18, 0x7f, 0x02, 0x75, 0xb4, 0xc6, 0x71, 0x0e, 0x27, 0x5c, 0xab, 0x56, 0xe5, 0x5a, 0xe6, 0xb4,
+	0x6b, 0xd5, 0x33, 0xd7, 0xb2, 0xbf, 0x35, 0xa0, 0xc1, 0x2d, 0x48, 0xae, 0x02, 0x44, 0x2c, 0x49,
+	0x9f, 0x8a, 0xc4, 0x61, 0x94, 0x19, 0x42, 0x24, 0xa7, 0x8d, 0x18, 0x3c, 0x85, 0x90, 0x77, 0xa0,
+	0x15, 0xc5, 0xf4, 0x69, 0x92, 0xb2, 0xc8, 0xaa, 0x55, 0x22, 0xcf, 0x44, 0x31, 0xc5, 0x6a, 0x9b,
+	0x5c, 0x06, 0xbe, 0xee, 0x29, 0x7d, 0x1e, 0xa4, 0x96, 0x59, 0x89, 0xdb, 0x42, 0x84, 0xcd, 0xe7,
+	0x41, 0x4a, 0xde, 0x82, 0xe6, 0x9e, 0x1b, 0x8c, 0xa8, 0x6f, 0xd5, 0x2b, 0x31, 0xe5, 0xac, 0xfd,
+	0x3b, 0x03, 0xea, 0x38, 0x90, 0x4b, 0x6f, 0x54, 0x49, 0x5f, 0x9b, 0x96, 0xde, 0xcc, 0x0f, 0x56,
+	0x0f, 0xcc, 0x49, 0x3c, 0x92, 0x07, 0x15, 0x3f, 0xd1, 0xc9, 0xc6, 0x34, 0xdd, 0x67, 0xbe, 0x3c,
+	0xa7, 0x12, 0x42, 0xe7, 0xc0, 0xec, 0xca, 0x26, 0x29, 0xf7, 0x3e, 0xd3, 0x51, 0x20, 0x59, 0x81,
+	0x76, 0xc8, 0xd2, 0xa7, 0xe8, 0xa7, 0x47, 0xdc, 0xff, 0x5a, 0x4e, 0x2b, 0x64, 0xa9, 0x83, 0xb0,
+	0xfd, 0x77, 0x03, 0x80, 0xb3, 0x4d, 0x93, 0xc9, 0x28, 0x25, 0xd7, 0xf0, 0xfc, 0x05, 0xa1, 0x50,
+	0xef, 0xdc, 0x46, 0xbf, 0x24, 0x1b, 0x47, 0x5a, 0xdf, 0x46, 0x0c, 0x47, 0x20, 0xf2, 0xeb, 0x7b,
+	0x30, 0x56, 0x61, 0x8a, 0x7f, 0x73, 0x77, 0x9c, 0xc4, 0xbc, 0x2d, 0xa2, 0xca, 0x44, 0x05, 0xeb,
+	0x51, 0xb1, 0x7e, 0x6c, 0x7d, 0xd7, 0x28, 0xd4, 0x77, 0xf6, 0x07, 0xd0, 0xe0, 0x7b, 0x92, 0x59,
+	0x68, 0x6f, 0x2b, 0x03, 0xf7, 0x4e, 0xe1, 0xc5, 0x6f, 0x5b, 0x98, 0xb0, 0x67, 0x90, 0x2e, 0xb4,
+	0xb6, 0xa5, 0x8d, 0x7a, 0x35, 0x02, 0xd0, 0xfc, 0x88, 0xdb, 0xa1, 0x67, 0xda, 0x7f, 0x31, 0xa0,
+	0xc1, 0x0f, 0x2b, 0x5e, 0x6f, 0x7d, 0x1a, 0x59, 0x46, 0xf9, 0x7a, 0x7b, 0x97, 0x46, 0x0e, 0xce,
+	0xa0, 0x34, 0xf4, 0x39, 0xf5, 0x94, 0x55, 0xf0, 0x9b, 0x5c, 0x84, 0x59, 0x8c, 0x30, 0x81, 0x3b,
+	0x7a, 0xea, 0xd3, 0x91, 0x7b, 0x24, 0xab, 0xdb, 0xae, 0x1c, 0xbc, 0x8b, 0x63, 0x3c, 0x8f, 0x84,
+	0x29, 0x8d, 0x0f, 0x5d, 0xd5, 0xc9, 0xc8, 0x60, 0xdd, 0x34, 0xdd, 0xa2, 0x69, 0x2e, 0xc3, 0x02,
+	0x7a, 0xcb, 0x24, 0xa6, 0x4f, 0xd3, 0xfd, 0x98, 0x26, 0xfb, 0x6c, 0xe4, 0x5b, 0xb3, 0x3c, 0xa2,
+	0xf7, 0xe4, 0xc4, 0x63, 0x35, 0x6e, 0x7f, 0x63, 0x40, 0x87, 0x8b, 0xb1, 0x93, 0xba, 0xe9, 0x84,
+	0xeb, 0x2b, 0x72, 0x93, 0x24, 0x08, 0x87, 0x2a, 0xc9, 0x49, 0x10, 0x99, 0x91, 0xab, 0xb3, 0x5b,
+	0x9f, 0x82, 0x71, 0x15, 0x8f, 0x19, 0xd4, 0x97, 0xa6, 0x51, 0xa0, 0xae, 0xff, 0x7a, 0xb1, 0xbe,
+	0xe6, 0x65, 0x3f, 0x3f, 0x76, 0x89, 0xd5, 0x50, 0xb7, 0x48, 0x01, 0xdb, 0x9f, 0x42, 0x63, 0xf3,
+	0x90, 0x6a, 0x8e, 0x60, 0x68, 0x8e, 0xb0, 0x04, 0xcd, 0x98, 0xba, 0x09, 0x0b, 0xe5, 0xc9, 0x97,
+	0x90, 0xbe, 0x95, 0x59, 0x34, 0xf5, 0x36, 0x34, 0x45, 0xd8, 0xab, 0x0c, 0x24, 0x16, 0xcc, 0xb8,
+	0xbe, 0x1f, 0xab, 0x94, 0xda, 0x76, 0x14, 0x88, 0x33, 0x21, 0x4d, 0x9f, 0xb1, 0xf8, 0x40, 0x51,
+	0x94, 0xa0, 0xfd, 0x57, 0xa3, 0x10, 0xea, 0xa4, 0xf2, 0x8e, 0x29, 0x32, 0x50, 0x83, 0xd4, 0x97,
+	0xf9, 0x5a, 0x42, 0xc7, 0x73, 0xab, 0x2b, 0xb3, 0x5e, 0x54, 0xe6, 0xdb, 0x50, 0xf7, 0x69, 0xa4,
+	0xee, 0x11, 0xa7, 0x0b, 0xae, 0x26, 0x58, 0x70, 0x38, 0x02, 0x59, 0x43, 0x8f, 0x8b, 0x62, 0x5e,
+	0xd4, 0x76, 0x36, 0xce, 0xe4, 0x88, 0x9b, 0xcf, 0xa3, 0x58, 0x61, 0x22, 0x86, 0xfd, 0x4b, 0x03,
+	0xda, 0xd9, 0xea, 0x17, 0x16, 0x2e, 0x04, 0xea, 0xcf, 0xdc, 0x20, 0x55, 0xb5, 0x19, 0x7e, 0x97,
+	0xed, 0xde, 0xca, 0x59, 0xcd, 0xc5, 0xae, 0x1f, 0x27, 0x76, 0xe9, 0x3c, 0x7e, 0x67, 0x00, 0xe4,
+	0xec, 0x91, 0x0b, 0x50, 0x63, 0x91, 0x8c, 0x18, 0x0b, 0x45, 0x01, 0xd6, 0x1f, 0x45, 0x4e, 0x8d,
+	0x45, 0xe4, 0x4d, 0x71, 0xf0, 0x44, 0x1c, 0xae, 0xd4, 0x06, 0x3f, 0x7e, 0x6b, 0x5a, 0x4a, 0x38,
+	0x56, 0x19, 0x88, 0x81, 0x4c, 0x7f, 0x39, 0x61, 0xf1, 0x64, 0xcc, 0x99, 0x6e, 0x38, 0x12, 0xd2,
+	0xc5, 0x6c, 0x1c, 0x27, 0x66, 0xf3, 0x38, 0x31, 0x67, 0x8a, 0x62, 0xbe, 0x07, 0xe6, 0x5d, 0x1a,
+	0x9d, 0x48, 0xd3, 0x90, 0x6b, 0xda, 0xfe, 0xb3, 0x01, 0x75, 0xcc, 0x8e, 0xe4, 0x82, 0xf4, 0x01,
+	0x51, 0xc6, 0x97, 0xc2, 0x8d, 0xb0, 0xfe, 0xdb, 0x50, 0x1f, 0xb1, 0x44, 0x58, 0x6a, 0xae, 0xa4,
+	0x98, 0x64, 0xfd, 0x01, 0x4b, 0x52, 0x87, 0x23, 0x14, 0x32, 0xbc, 0x59, 0xca, 0xf0, 0xb6, 0x74,
+	0xa1, 0xa9, 0x7c, 0x84, 0x5a, 0x93, 0xce, 0x73, 0x09, 0xea, 0x48, 0x0d, 0xe3, 0xe2, 0xd6, 0x30,
+	0x64, 0x31, 0xb6, 0xca, 0xba, 0xd0, 0x7a, 0x28, 0xf3, 0x40, 0xcf, 0x20, 0x2d, 0xa8, 0xf3, 0x48,
+	0x5a, 0xb3, 0xff, 0x68, 0x40, 0x1d, 0x97, 0x9e, 0xc4, 0xb0, 0xaf, 0xeb, 0x86, 0xad, 0x8a, 0xa8,
+	0x76, 0xc1, 0xa4, 0x53, 0xcc, 0xbd, 0xc8, 0x98, 0xf6, 0x7f, 0x41, 0xed, 0x51, 0x84, 0x8c, 0x3d,
+	0xa0, 0xee, 0x9e, 0xe8, 0xed, 0xdd, 0x0a, 0xfd, 0x9e, 0x41, 0x9a, 0x50, 0x7b, 0x14, 0xf7, 0x6a,
+	0x38, 0xf0, 0x90, 0xa5, 0x3d, 0x13, 0xc5, 0xfa, 0x8c, 0xaf, 0xe9, 0xd5, 0xed, 0x7f, 0x18, 0xd0,
+	0x14, 0x7d, 0x75, 0xf2, 0x0e, 0xd4, 0xd3, 0xa3, 0x88, 0x4a, 0x11, 0x16, 0xcb, 0x7d, 0xf7, 0xf5,
+	0xc7, 0x47, 0x11, 0x75, 0x38, 0x0a, 0xb9, 0xc8, 0x65, 0x9d, 0xb2, 0x83, 0x44, 0x94, 0xd2, 0xe6,
+	0xd7, 0x10, 0x53, 0xbf, 0x86, 0xa8, 0xc2, 0x09, 0xb2, 0xc2, 0xc9, 0xde, 0x84, 0x3a, 0x12, 0xc7,
+	0x8c, 0xf5, 0x50, 0xdd, 0x99, 0x7a, 0xa7, 0x48, 0x1b, 0x1a, 0x0f, 0xb0, 0xb5, 0xdb, 0x33, 0xf0,
+	0xf3, 0x1e, 0xf6, 0x1d, 0x7a, 0x35, 0x94, 0x10, 0x91, 0x7a, 0x26, 0x99, 0x03, 0xd8, 0xca, 0x9a,
+	0x65, 0xbd, 0xba, 0xbd, 0xcc, 0x35, 0xd0, 0x84, 0xda, 0x56, 0x28, 0x56, 0x3f, 0x64, 0xe9, 0x56,
+	0xd8, 0x33, 0xec, 0x2b, 0x50, 0xbb, 0xff, 0xa4, 0xa2, 0x60, 0x3b, 0xa3, 0x17, 0x6c, 0x6d, 0x59,
+	0xa0, 0x61, 0x57, 0xac, 0xa5, 0x3a, 0xe2, 0xb8, 0x28, 0x62, 0x89, 0x8c, 0xd3, 0xf8, 0x59, 0xc8,
+	0xe1, 0xed, 0x3c, 0x74, 0x27, 0x6c, 0x12, 0x7b, 0x2a, 0xe6, 0x49, 0x08, 0x57, 0xc7, 0xee, 0x33,
+	0x99, 0x21, 0xf0, 0x53, 0x3f, 0x40, 0x50, 0x0c, 0x8f, 0x4b, 0xd0, 0xdc, 0x0b, 0xe8, 0xc8, 0x4f,
+	0x54, 0xad, 0x22, 0x20, 0xfb, 0xdb, 0x16, 0x26, 0x6e, 0xd1, 0x67, 0x7d, 0x59, 0xc3, 0xf0, 0xc5,
+	0x6d, 0xf4, 0xea, 0x36, 0x1d, 0x5e, 0x70, 0x42, 0x9f, 0x3e, 0x97, 0xee, 0x24, 0x00, 0xad, 0xe5,
+	0xde, 0x78, 0x41, 0xcb, 0x1d, 0xb5, 0x13, 0x88, 0x17, 0x8a, 0x86, 0x83, 0x9f, 0x28, 0x1f, 0xcf,
+	0x75, 0xd4, 0x97, 0x99, 0x5d, 0x81, 0x38, 0x13, 0x8b, 0xd7, 0x1a, 0x95, 0xd8, 0x25, 0x88, 0x1c,
+	0x88, 0x7a, 0x6b, 0x96, 0xc7, 0x1a, 0x01, 0x90, 0xab, 0xbc, 0xeb, 0x91, 0x52, 0x6b, 0x8e, 0xbb,
+	0xd9, 0xf2, 0x54, 0x77, 0x09, 0xbb, 0x1f, 0x29, 0x75, 0x04, 0x16, 0x9e, 0x79, 0x37, 0x4d, 0xe9,
+	0x38, 0xe2, 0xf7, 0x21, 0x9e, 0x76, 0x15, 0x8c, 0x45, 0x1d, 0x56, 0xab, 0x4f, 0x3d, 0xe6, 0x53,
+	0xab, 0x27, 0x26, 0x71, 0xe0, 0x0e, 0xf3, 0x29, 0xb9, 0x00, 0x5d, 0x3e, 0xa9, 0xcc, 0xb2, 0xc0,
+	0x95, 0xd3, 0xc1, 0xb1, 0x4f, 0xf3, 0x94, 0xbe, 0x17, 0x84, 0x41, 0xb2, 0x4f, 0x7d, 0x7e, 0x0f,
+	0x32, 0x9d, 0x0c, 0xd6, 0x9f, 0x39, 0xce, 0xbc, 0xf4, 0x99, 0x23, 0xbb, 0xb1, 0x2d, 0xea, 0x37,
+	0xb6, 0x7b, 0xd0, 0x65, 0xbb, 0x09, 0x96, 0x3f, 0xfc, 0x6d, 0xcc, 0x5a, 0x2a, 0x5f, 0x08, 0x95,
+	0xc0, 0x8f, 0x34, 0x2c, 0x79, 0x21, 0xd4, 0x17, 0x92, 0xbb, 0xd0, 0x71, 0x47, 0x23, 0xe6, 0x49,
+	0x3a, 0xcb, 0x9c, 0x8e, 0x3d, 0x4d, 0xe7, 0x56, 0x8e, 0x24, 0xc8, 0xe8, 0xcb, 0xc8, 0x25, 0x75,
+	0x39, 0xb4, 0xca, 0x89, 0x25, 0x2f, 0x6b, 0xd5, 0x0d, 0xf1, 0xba, 0x76, 0x43, 0x3c, 0xcb, 0xc5,
+	0x5f, 0x2c, 0xdd, 0xf9, 0x64, 0x22, 0xca, 0xd0, 0xc8, 0xbb, 0xfa, 0x3d, 0xb1, 0xff, 0xa2, 0x35,
+	0x39, 0x1e, 0x79, 0x1b, 0x9a, 0xf4, 0x90, 0xdf, 0x96, 0x57, 0x56, 0xcd, 0xe2, 0xcd, 0x92, 0x17,
+	0x54, 0x8e, 0x9c, 0xe6, 0x27, 0x91, 0xaf, 0xb6, 0xce, 0xc9, 0x93, 0xc8, 0x21, 0xf2, 0x61, 0xe9,
+	0xd2, 0x7d, 0x9e, 0x93, 0x59, 0xd1, 0x3b, 0x9f, 0xa5, 0xaa, 0xa7, 0x78, 0xd9, 0xee, 0x7f, 0x08,
+	0x0b, 0x53, 0xea, 0x3f, 0x69, 0x48, 0xc1, 0x3b, 0x5f, 0xff, 0x7f, 0xa1, 0x57, 0xd6, 0xfb, 0xcb,
+	0xd6, 0x37, 0xf4, 0x3b, 0xe3, 0x00, 0x1a, 0xdc, 0xe1, 0xb1, 0x90, 0x97, 0xcf, 0x9e, 0xbd, 0x53,
+	0x18, 0x32, 0x77, 0xf0, 0x4a, 0x40, 0x7d, 0x8a, 0xb1, 0x3e, 0xaf, 0xe4, 0x6b, 0xf6, 0xef, 0x0d,
+	0x68, 0x39, 0x5a, 0xc3, 0x39, 0xeb, 0xfa, 0x1a, 0xa5, 0x66, 0xf4, 0x31, 0xb7, 0x92, 0x2c, 0x8f,
+	0x9b, 0xa5, 0x3c, 0xbe, 0x04, 0x4d, 0xd7, 0xe3, 0xf7, 0x15, 0x11, 0xd8, 0x24, 0xa4, 0x45, 0xc1,
+	0x06, 0xa7, 0x24, 0xa1, 0xbc, 0x7f, 0x09, 0x27, 0xe8, 0x5f, 0xda, 0x37, 0xa1, 0x29, 0xda, 0xae,
+	0xc8, 0x57, 0xe4, 0xa6, 0xfb, 0xaa, 0xec, 0xc4, 0xef, 0xac, 0xa3, 0x2b, 0xa3, 0xaf, 0xd6, 0xd1,
+	0x15, 0x5c, 0x62, 0x17, 0xf8, 0x17, 0x26, 0xb4, 0x54, 0xf3, 0xef, 0x3f, 0xad, 0x3d, 0xcb, 0x6b,
+	0xba, 0x98, 0xba, 0x18, 0x80, 0x41, 0x56, 0xd9, 0x02, 0xe4, 0xb2, 0xd2, 0xe7, 0xa9, 0x7a, 0x3b,
+	0xc6, 0x6f, 0xcc, 0xf9, 0xf1, 0x24, 0x14, 0x1d, 0xab, 0xc2, 0x39, 0x54, 0x6c, 0x38, 0x93, 0xd0,
+	0xe1, 0x28, 0xf6, 0x75, 0xe8, 0x68, 0x2c, 0x61, 0x7a, 0x45, 0x77, 0x7e, 0xd6, 0x3b, 0xc5, 0x9d,
+	0x8e, 0xc5, 0xbb, 0x01, 0x3a, 0x20, 0x3a, 0xa7, 0x78, 0x51, 0xea, 0xd5, 0xb0, 0x1d, 0xd1, 0xd1,
+	0x08, 0x55, 0xde, 0x7a, 0xaa, 0x9f, 0x0e, 0xb3, 0xe0, 0x6f, 0x9e, 0x28, 0xf8, 0x63, 0xd6, 0x39,
+	0x08, 0xa2, 0x28, 0x2b, 0xcb, 0x15, 0x78, 0x7c, 0x5d, 0x5e, 0x08, 0xea, 0xcd, 0x62, 0x50, 0xb7,
+	0x7f, 0x02, 0x0d, 0xfe, 0x80, 0x8a, 0xdc, 0x8d, 0xf1, 0x43, 0xb5, 0x23, 0x38, 0x40, 0x2e, 0x2b,
+	0xee, 0x6a, 0xe5, 0x52, 0x89, 0xaf, 0x2a, 0xf0, 0x66, 0xbf, 0xab, 0xce, 0xed, 0x2c, 0xb4, 0x3f,
+	0x0f, 0xc5, 0x4b, 0x82, 0x2f, 0x4e, 0xee, 0xa7, 0xee, 0x01, 0x55, 0x15, 0xa5, 0x5e, 0x5f, 0xd6,
+	0x36, 0xfe, 0xd9, 0x05, 0xf3, 0xd6, 0xf6, 0x16, 0xa1, 0xd9, 0x59, 0x27, 0x56, 0xbe, 0x4b, 0xf1,
+	0x3f, 0x13, 0xfd, 0xb3, 0x15, 0x33, 0xa2, 0xdd, 0x6a, 0xbf, 0xf9, 0xb3, 0xbf, 0x7d, 0xf7, 0x4d,
+	0xed, 0x75, 0xd2, 0x19, 0x1c, 0x5e, 0x1f, 0xc8, 0x7c, 0xfb, 0x45, 0xcf, 0xd6, 0xc1, 0x1b, 0xc6,
+	0x25, 0xf2, 0x18, 0xfb, 0x51, 0x7b, 0x8c, 0x2c, 0xea, 0xdd, 0xc2, 0xec, 0x5f, 0x11, 0xfd, 0xa5,
+	0xf2, 0xb0, 0xa4, 0x7e, 0x9e, 0x53, 0x5f, 0x26, 0x8b, 0x48, 0x2e, 0x08, 0xf7, 0xd8, 0xe0, 0xab,
+	0xbc, 0x18, 0xf9, 0x1a, 0xa9, 0xe2, 0x5f, 0x10, 0x74, 0xaa, 0xda, 0xff, 0x1f, 0xfa, 0x4b, 0xe5,
+	0xe1, 0x2a, 0xaa, 0x23, 0x36, 0x4c, 0xca, 0x54, 0x1b, 0xa2, 0x67, 0x75, 0xcc, 0x41, 0xeb, 0x2f,
+	0x4f, 0x8d, 0x4b, 0xc2, 0x7d, 0x4e, 0xf8, 0x8c, 0xdd, 0x46, 0xc2, 0xfc, 0x34, 0xde, 0xc8, 0x32,
+	0xf3, 0x63, 0x51, 0xd0, 0xeb, 0xbc, 0x6a, 0x4f, 0xd1, 0xfd, 0xa5, 0xf2, 0x70, 0x91, 0xd7, 0x4b,
+	0x8b, 0x82, 0x24, 0x8b, 0x8a, 0xbc, 0x8e, 0xa1, 0xa3, 0x75, 0xdc, 0xc9, 0x39, 0x2d, 0x6b, 0x4d,
+	0xb5, 0xf2, 0xfb, 0xe7, 0x8f, 0x99, 0x95, 0x5b, 0x5d, 0xe0, 0x5b, 0xad, 0xd8, 0x4b, 0xb8, 0x95,
+	0xf6, 0xa7, 0x86, 0xc1, 0x57, 0x18, 0xb5, 0xbe, 0x46, 0x33, 0x3e, 0x81, 0x06, 0x7f, 0xa4, 0x2e,
+	0xa8, 0x46, 0x7b, 0xf7, 0xee, 0x2f, 0x4f, 0x8d, 0x4b, 0xe2, 0xe7, 0x38, 0xf1, 0x25, 0x7b, 0x81,
+	0xcb, 0x81, 0x53, 0x83, 0xaf, 0xf8, 0x49, 0xe4, 0x74, 0xbd, 0xec, 0x50, 0x17, 0xbc, 0xb0, 0xf0,
+	0x98, 0xdd, 0x3f, 0x5b, 0x31, 0x23, 0xa9, 0x5f, 0xe4, 0xd4, 0xcf, 0xdb, 0x16, 0x77, 0x3b, 0x31,
+	0x59, 0x50, 0x14, 0x6e, 0xf2, 0x05, 0xcc, 0xc8, 0x97, 0x5b, 0x7d, 0x93, 0xe2, 0x83, 0x70, 0xff,
+	0x6c, 0xc5, 0x4c, 0xd1, 0xba, 0x84, 0xe0, 0x26, 0xfb, 0x62, 0x52, 0xea, 0x86, 0xb8, 0xd0, 0x52,
+	0x6f, 0xa7, 0x44, 0xe7, 0xb3, 0xf8, 0xc6, 0xdb, 0xef, 0x57, 0x4d, 0x49, 0xf2, 0xaf, 0x71, 0xf2,
+	0x96, 0x7d, 0x9a, 0xcb, 0x20, 0x67, 0x35, 0xdd, 0x3f, 0x84, 0x3a, 0x3e, 0x3d, 0xea, 0x0e, 0xa4,
+	0xbd, 0x77, 0xf6, 0x97, 0xca, 0xc3, 0x92, 0xec, 0x32, 0x27, 0xbb, 0x40, 0xe6, 0x91, 0xac, 0x1f,
+	0xec, 0xed, 0x69, 0x2c, 0x67, 0x39, 0xec, 0x6c, 0x45, 0x5c, 0x9e, 0x66, 0xb9, 0xfc, 0x2a, 0x57,
+	0x64, 0x59, 0xbd, 0x34, 0x69, 0x2c, 0xff, 0x3f, 0xb4, 0xd5, 0x9a, 0x84, 0x54, 0x10, 0xca, 0x4e,
+	0xea, 0x4a, 0xe5, 0x9c, 0xdc, 0x65, 0x91, 0xef, 0x32, 0x4f, 0x66, 0xf5, 0x5d, 0x12, 0x32, 0x84,
+	0x19, 0xf9, 0xd2, 0xa6, 0x9b, 0xb3, 0xf8, 0xf8, 0xf6, 0x42, 0xee, 0xdf, 0xe2, 0x74, 0x57, 0xed,
+	0x95, 0x0a, 0xee, 0x07, 0x32, 0xdf, 0xa1, 0x14, 0x14, 0x9a, 0x0e, 0x4d, 0x26, 0x63, 0xfa, 0x8a,
+	0xfb, 0xc8, 0x10, 0x69, 0xf7, 0xab, 0xf6, 0x89, 0x39, 0x65, 0xdc, 0xc6, 0x07, 0xc8, 0x9f, 0xeb,
+	0x88, 0xa6, 0x91, 0xa9, 0xb7, 0xc0, 0xfe, 0xb9, 0xea, 0x49, 0xb9, 0xdf, 0x0a, 0xdf, 0x6f, 0xf1,
+	0x52, 0x95, 0x55, 0xc8, 0x04, 0x1a, 0xfc, 0xbd, 0x4c, 0x3f, 0xc1, 0xfa, 0x13, 0x5c, 0x7f, 0x79,
+	0x6a, 0x5c, 0x92, 0x7d, 0x9f, 0x93, 0x7d, 0x8f, 0x9c, 0xe1, 0xb1, 0x18, 0xa7, 0xd4, 0x09, 0x4b,
+	0x8f, 0xbe, 0xfe, 0xe2, 0xbc, 0x5d, 0x39, 0x7e, 0x43, 0x3c, 0x67, 0xdd, 0xfe, 0xec, 0xb7, 0xb7,
+	0x1e, 0x92, 0xc6, 0x86, 0x79, 0x7d, 0xfd, 0xda, 0x25, 0xa3, 0x16, 0xdf, 0x86, 0xfe, 0x1d, 0xb9,
+	0xcb, 0xea, 0xbd, 0x20, 0xfd, 0x78, 0xb2, 0xbb, 0x1a, 0xd3, 0x88, 0x25, 0x01, 0x3f, 0x9d, 0x6f,
+	0xec, 0xa7, 0x69, 0x94, 0xdc, 0x18, 0x0c, 0x86, 0x41, 0xba, 0x3f, 0xd9, 0x5d, 0xf7, 0xd8, 0x78,
+	0x10, 0xb2, 0x78, 0xe8, 0x86, 0xa1, 0x3b, 0x50, 0xdc, 0xed, 0x36, 0xf9, 0x3f, 0xfc, 0xde, 0xfd,
+	0xf7, 0x00, 0xbc, 0x87, 0x63, 0x48, 0x45, 0x28, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1497,6 +4490,28 @@ type APIClient interface {
 	Stop(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*StopResponse, error)
 	// Environment allows setting default environment values for all processes started in the specified namespace.
 	Environment(ctx context.Context, in *EnvironmentRequest, opts ...grpc.CallOption) (*EnvironmentResponse, error)
+	// Scale changes the number of replicas running within a process group.
+	Scale(ctx context.Context, in *ScaleRequest, opts ...grpc.CallOption) (*ScaleResponse, error)
+	// Replace starts a new specification alongside a running process (or group), only stopping the old process once the new one is ready.
+	Replace(ctx context.Context, in *ReplaceRequest, opts ...grpc.CallOption) (*ReplaceResponse, error)
+	// History lists the revisions of the `StartRequest` that have been deployed for a named process in a namespace.
+	//
+	// The history is saved within the server root, so it is kept across restarts of the server.
+	History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryResponse, error)
+	// Rollback redeploys a previous revision of a named process.
+	Rollback(ctx context.Context, in *RollbackRequest, opts ...grpc.CallOption) (*RollbackResponse, error)
+	// Diff shows the changes between two revisions of a named process.
+	Diff(ctx context.Context, in *DiffRequest, opts ...grpc.CallOption) (*DiffResponse, error)
+	// Schedule creates (or updates) a schedule that starts a job from a `StartRequest` template at each tick of a cron expression.
+	Schedule(ctx context.Context, in *ScheduleRequest, opts ...grpc.CallOption) (*ScheduleResponse, error)
+	// Schedules lists the schedules (and their run history) in a namespace.
+	Schedules(ctx context.Context, in *SchedulesRequest, opts ...grpc.CallOption) (*SchedulesResponse, error)
+	// Suspend stops a schedule from starting any more jobs until it is resumed.
+	Suspend(ctx context.Context, in *SuspendRequest, opts ...grpc.CallOption) (*ScheduleResponse, error)
+	// Resume continues starting jobs for a suspended schedule (from the next tick).
+	Resume(ctx context.Context, in *SuspendRequest, opts ...grpc.CallOption) (*ScheduleResponse, error)
+	// Unschedule deletes a schedule (jobs that it has already started are left to finish).
+	Unschedule(ctx context.Context, in *UnscheduleRequest, opts ...grpc.CallOption) (*UnscheduleResponse, error)
 	Image(ctx context.Context, in *ImageRequest, opts ...grpc.CallOption) (*ImageResponse, error)
 }
 
@@ -1562,6 +4577,96 @@ func (c *aPIClient) Environment(ctx context.Context, in *EnvironmentRequest, opt
 	return out, nil
 }
 
+func (c *aPIClient) Scale(ctx context.Context, in *ScaleRequest, opts ...grpc.CallOption) (*ScaleResponse, error) {
+	out := new(ScaleResponse)
+	err := c.cc.Invoke(ctx, "/cynosure.API/Scale", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) Replace(ctx context.Context, in *ReplaceRequest, opts ...grpc.CallOption) (*ReplaceResponse, error) {
+	out := new(ReplaceResponse)
+	err := c.cc.Invoke(ctx, "/cynosure.API/Replace", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryResponse, error) {
+	out := new(HistoryResponse)
+	err := c.cc.Invoke(ctx, "/cynosure.API/History", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) Rollback(ctx context.Context, in *RollbackRequest, opts ...grpc.CallOption) (*RollbackResponse, error) {
+	out := new(RollbackResponse)
+	err := c.cc.Invoke(ctx, "/cynosure.API/Rollback", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) Diff(ctx context.Context, in *DiffRequest, opts ...grpc.CallOption) (*DiffResponse, error) {
+	out := new(DiffResponse)
+	err := c.cc.Invoke(ctx, "/cynosure.API/Diff", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) Schedule(ctx context.Context, in *ScheduleRequest, opts ...grpc.CallOption) (*ScheduleResponse, error) {
+	out := new(ScheduleResponse)
+	err := c.cc.Invoke(ctx, "/cynosure.API/Schedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) Schedules(ctx context.Context, in *SchedulesRequest, opts ...grpc.CallOption) (*SchedulesResponse, error) {
+	out := new(SchedulesResponse)
+	err := c.cc.Invoke(ctx, "/cynosure.API/Schedules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) Suspend(ctx context.Context, in *SuspendRequest, opts ...grpc.CallOption) (*ScheduleResponse, error) {
+	out := new(ScheduleResponse)
+	err := c.cc.Invoke(ctx, "/cynosure.API/Suspend", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) Resume(ctx context.Context, in *SuspendRequest, opts ...grpc.CallOption) (*ScheduleResponse, error) {
+	out := new(ScheduleResponse)
+	err := c.cc.Invoke(ctx, "/cynosure.API/Resume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) Unschedule(ctx context.Context, in *UnscheduleRequest, opts ...grpc.CallOption) (*UnscheduleResponse, error) {
+	out := new(UnscheduleResponse)
+	err := c.cc.Invoke(ctx, "/cynosure.API/Unschedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aPIClient) Image(ctx context.Context, in *ImageRequest, opts ...grpc.CallOption) (*ImageResponse, error) {
 	out := new(ImageResponse)
 	err := c.cc.Invoke(ctx, "/cynosure.API/Image", in, out, opts...)
@@ -1585,6 +4690,28 @@ type APIServer interface {
 	Stop(context.Context, *StopRequest) (*StopResponse, error)
 	// Environment allows setting default environment values for all processes started in the specified namespace.
 	Environment(context.Context, *EnvironmentRequest) (*EnvironmentResponse, error)
+	// Scale changes the number of replicas running within a process group.
+	Scale(context.Context, *ScaleRequest) (*ScaleResponse, error)
+	// Replace starts a new specification alongside a running process (or group), only stopping the old process once the new one is ready.
+	Replace(context.Context, *ReplaceRequest) (*ReplaceResponse, error)
+	// History lists the revisions of the `StartRequest` that have been deployed for a named process in a namespace.
+	//
+	// The history is saved within the server root, so it is kept across restarts of the server.
+	History(context.Context, *HistoryRequest) (*HistoryResponse, error)
+	// Rollback redeploys a previous revision of a named process.
+	Rollback(context.Context, *RollbackRequest) (*RollbackResponse, error)
+	// Diff shows the changes between two revisions of a named process.
+	Diff(context.Context, *DiffRequest) (*DiffResponse, error)
+	// Schedule creates (or updates) a schedule that starts a job from a `StartRequest` template at each tick of a cron expression.
+	Schedule(context.Context, *ScheduleRequest) (*ScheduleResponse, error)
+	// Schedules lists the schedules (and their run history) in a namespace.
+	Schedules(context.Context, *SchedulesRequest) (*SchedulesResponse, error)
+	// Suspend stops a schedule from starting any more jobs until it is resumed.
+	Suspend(context.Context, *SuspendRequest) (*ScheduleResponse, error)
+	// Resume continues starting jobs for a suspended schedule (from the next tick).
+	Resume(context.Context, *SuspendRequest) (*ScheduleResponse, error)
+	// Unschedule deletes a schedule (jobs that it has already started are left to finish).
+	Unschedule(context.Context, *UnscheduleRequest) (*UnscheduleResponse, error)
 	Image(context.Context, *ImageRequest) (*ImageResponse, error)
 }
 
@@ -1610,6 +4737,36 @@ func (*UnimplementedAPIServer) Stop(ctx context.Context, req *StopRequest) (*Sto
 func (*UnimplementedAPIServer) Environment(ctx context.Context, req *EnvironmentRequest) (*EnvironmentResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Environment not implemented")
 }
+func (*UnimplementedAPIServer) Scale(ctx context.Context, req *ScaleRequest) (*ScaleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Scale not implemented")
+}
+func (*UnimplementedAPIServer) Replace(ctx context.Context, req *ReplaceRequest) (*ReplaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Replace not implemented")
+}
+func (*UnimplementedAPIServer) History(ctx context.Context, req *HistoryRequest) (*HistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method History not implemented")
+}
+func (*UnimplementedAPIServer) Rollback(ctx context.Context, req *RollbackRequest) (*RollbackResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Rollback not implemented")
+}
+func (*UnimplementedAPIServer) Diff(ctx context.Context, req *DiffRequest) (*DiffResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Diff not implemented")
+}
+func (*UnimplementedAPIServer) Schedule(ctx context.Context, req *ScheduleRequest) (*ScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Schedule not implemented")
+}
+func (*UnimplementedAPIServer) Schedules(ctx context.Context, req *SchedulesRequest) (*SchedulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Schedules not implemented")
+}
+func (*UnimplementedAPIServer) Suspend(ctx context.Context, req *SuspendRequest) (*ScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Suspend not implemented")
+}
+func (*UnimplementedAPIServer) Resume(ctx context.Context, req *SuspendRequest) (*ScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Resume not implemented")
+}
+func (*UnimplementedAPIServer) Unschedule(ctx context.Context, req *UnscheduleRequest) (*UnscheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unschedule not implemented")
+}
 func (*UnimplementedAPIServer) Image(ctx context.Context, req *ImageRequest) (*ImageResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Image not implemented")
 }
@@ -1726,6 +4883,186 @@ func _API_Environment_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_Scale_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScaleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).Scale(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cynosure.API/Scale",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).Scale(ctx, req.(*ScaleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_Replace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).Replace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cynosure.API/Replace",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).Replace(ctx, req.(*ReplaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_History_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).History(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cynosure.API/History",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).History(ctx, req.(*HistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_Rollback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).Rollback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cynosure.API/Rollback",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).Rollback(ctx, req.(*RollbackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_Diff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).Diff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cynosure.API/Diff",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).Diff(ctx, req.(*DiffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_Schedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).Schedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cynosure.API/Schedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).Schedule(ctx, req.(*ScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_Schedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SchedulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).Schedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cynosure.API/Schedules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).Schedules(ctx, req.(*SchedulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_Suspend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuspendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).Suspend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cynosure.API/Suspend",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).Suspend(ctx, req.(*SuspendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_Resume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuspendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).Resume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cynosure.API/Resume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).Resume(ctx, req.(*SuspendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_Unschedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnscheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).Unschedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cynosure.API/Unschedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).Unschedule(ctx, req.(*UnscheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _API_Image_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ImageRequest)
 	if err := dec(in); err != nil {
@@ -1772,6 +5109,46 @@ var _API_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Environment",
 			Handler:    _API_Environment_Handler,
 		},
+		{
+			MethodName: "Scale",
+			Handler:    _API_Scale_Handler,
+		},
+		{
+			MethodName: "Replace",
+			Handler:    _API_Replace_Handler,
+		},
+		{
+			MethodName: "History",
+			Handler:    _API_History_Handler,
+		},
+		{
+			MethodName: "Rollback",
+			Handler:    _API_Rollback_Handler,
+		},
+		{
+			MethodName: "Diff",
+			Handler:    _API_Diff_Handler,
+		},
+		{
+			MethodName: "Schedule",
+			Handler:    _API_Schedule_Handler,
+		},
+		{
+			MethodName: "Schedules",
+			Handler:    _API_Schedules_Handler,
+		},
+		{
+			MethodName: "Suspend",
+			Handler:    _API_Suspend_Handler,
+		},
+		{
+			MethodName: "Resume",
+			Handler:    _API_Resume_Handler,
+		},
+		{
+			MethodName: "Unschedule",
+			Handler:    _API_Unschedule_Handler,
+		},
 		{
 			MethodName: "Image",
 			Handler:    _API_Image_Handler,
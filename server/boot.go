@@ -9,12 +9,14 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gogo/gateway"
 	grpc_validator "github.com/grpc-ecosystem/go-grpc-middleware/validator"
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"github.com/norganna/cynosure/common"
 	"github.com/norganna/cynosure/deps"
+	"github.com/norganna/cynosure/process"
 	"github.com/norganna/cynosure/proto/cynosure"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -43,6 +45,30 @@ func Run(config *common.Config, args []string) {
 		}
 	}
 
+	// #### Setup instances ####
+
+	process.SetRoot(config.Root)
+
+	err := process.SetSubreaper()
+	if err != nil {
+		log.Fatalf("Failed to adopt orphaned processes: %s", err.Error())
+	}
+
+	// #### Setup ports ####
+
+	if p := config.Ports; p != nil {
+		err := process.SetPortRange(p.Min, p.Max)
+		if err != nil {
+			log.Fatalf("Failed to set port range %d-%d: %s", p.Min, p.Max, err.Error())
+		}
+	}
+
+	// #### Setup jobs ####
+
+	if j := config.Jobs; j != nil && j.TTL > 0 {
+		process.SetJobTTL(time.Duration(j.TTL) * time.Second)
+	}
+
 	// #### Setup TLS ####
 
 	sHost, sPort, err := net.SplitHostPort(config.Server)
@@ -2,45 +2,361 @@ package server
 
 import (
 	"context"
+	"time"
 
 	"github.com/norganna/cynosure/common"
+	"github.com/norganna/cynosure/pipes"
+	"github.com/norganna/cynosure/process"
 	"github.com/norganna/cynosure/proto/cynosure"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 )
 
+// logTimeLayout is the layout of the `LogEntry.Time` values (as output by `time.Time.String()`).
+const logTimeLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
+// defaultReplaceDeadline is how long a replacement has to become ready if the request does not specify.
+const defaultReplaceDeadline = 60 * time.Second
+
 func newHandler(c *common.Config) cynosure.APIServer {
 	return &cynoHandler{
 		c: c,
+		m: process.Default,
+		s: process.DefaultScheduler,
 	}
 }
 
 type cynoHandler struct {
 	c *common.Config
+	m process.ProcessManager
+	s process.Scheduler
+}
+
+func (c *cynoHandler) Diff(_ context.Context, req *cynosure.DiffRequest) (*cynosure.DiffResponse, error) {
+	ns, name := req.GetNamespace(), req.GetName()
+
+	to := process.FindRevision(ns, name, req.GetTo())
+	if to == nil {
+		return nil, status.Errorf(codes.NotFound, "revision %d of %s not found", req.GetTo(), name)
+	}
+
+	number := req.GetFrom()
+	if number == 0 {
+		number = to.Revision - 1
+	}
+	from := process.FindRevision(ns, name, number)
+	if from == nil || number == 0 {
+		return nil, status.Errorf(codes.NotFound, "revision %d of %s not found", number, name)
+	}
+
+	changes, err := process.Diff(from.Start, to.Start)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &cynosure.DiffResponse{
+		From:    from.Revision,
+		To:      to.Revision,
+		Changes: changes,
+	}, nil
 }
 
 func (c *cynoHandler) Environment(context.Context, *cynosure.EnvironmentRequest) (*cynosure.EnvironmentResponse, error) {
 	panic("implement me")
 }
 
+func (c *cynoHandler) History(_ context.Context, req *cynosure.HistoryRequest) (*cynosure.HistoryResponse, error) {
+	return &cynosure.HistoryResponse{
+		Revisions: process.History(req.GetNamespace(), req.GetName()),
+	}, nil
+}
+
 func (c *cynoHandler) Image(context.Context, *cynosure.ImageRequest) (*cynosure.ImageResponse, error) {
 	panic("implement me")
 }
 
-func (c *cynoHandler) Info(context.Context, *cynosure.InfoRequest) (*cynosure.InfoResponse, error) {
-	panic("implement me")
+func (c *cynoHandler) Info(_ context.Context, req *cynosure.InfoRequest) (*cynosure.InfoResponse, error) {
+	p, err := c.process(req.GetIdentifier())
+	if err != nil {
+		return nil, err
+	}
+
+	return &cynosure.InfoResponse{
+		Process: p.Process(),
+	}, nil
 }
 
-func (c *cynoHandler) Logs(context.Context, *cynosure.LogsRequest) (*cynosure.LogsResponse, error) {
-	panic("implement me")
+func (c *cynoHandler) Logs(_ context.Context, req *cynosure.LogsRequest) (*cynosure.LogsResponse, error) {
+	p, err := c.process(req.GetIdentifier())
+	if err != nil {
+		return nil, err
+	}
+
+	logging := p.Log()
+	if logging == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "process %s is not logging", req.GetIdentifier())
+	}
+
+	var lines []pipes.Liner
+	var count int64
+
+	switch {
+	case req.GetSince() != "":
+		since, err := time.Parse(logTimeLayout, req.GetSince())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to parse since time: %s", err)
+		}
+		lines, count = logging.Since(since)
+	case req.GetHead() > 0:
+		lines, count = logging.Head(int(req.GetHead()))
+	case req.GetTail() > 0:
+		lines, count = logging.Tail(int(req.GetTail()))
+	default:
+		lines, count = logging.Since(time.Time{})
+	}
+
+	res := &cynosure.LogsResponse{
+		Count:    count,
+		Continue: req.GetSince(),
+	}
+	for _, line := range lines {
+		entry := line.Entry()
+		res.Entries = append(res.Entries, entry)
+		res.Continue = entry.Time
+	}
+	return res, nil
 }
 
-func (c *cynoHandler) Running(context.Context, *cynosure.RunningRequest) (*cynosure.RunningResponse, error) {
-	panic("implement me")
+func (c *cynoHandler) Replace(ctx context.Context, req *cynosure.ReplaceRequest) (*cynosure.ReplaceResponse, error) {
+	// The revision belongs to the process being replaced, whatever the replacement is named.
+	ns, name := targetName(c.m, req.GetIdentifier())
+
+	group, list, err := c.m.Replace(req.GetIdentifier(), req.GetStart(), replaceDeadline(req.GetDeadline()), int(req.GetSurge()))
+	if rErr := replaceError(err, req.GetIdentifier()); rErr != nil {
+		return nil, rErr
+	}
+
+	res := &cynosure.ReplaceResponse{
+		Success:   err == nil,
+		Group:     group,
+		Processes: processes(list),
+	}
+	if err != nil {
+		res.Message = err.Error()
+	} else {
+		process.RecordRevision(ns, name, process.ActionReplace, peerIdentity(ctx), 0, req.GetStart())
+	}
+	return res, nil
 }
 
-func (c *cynoHandler) Start(context.Context, *cynosure.StartRequest) (*cynosure.StartResponse, error) {
-	panic("implement me")
+func (c *cynoHandler) Rollback(ctx context.Context, req *cynosure.RollbackRequest) (*cynosure.RollbackResponse, error) {
+	ns, name := req.GetNamespace(), req.GetName()
+
+	number := req.GetRevision()
+	if number == 0 {
+		if current := process.FindRevision(ns, name, 0); current != nil {
+			number = current.Revision - 1
+		}
+	}
+
+	rev := process.FindRevision(ns, name, number)
+	if rev == nil || number == 0 {
+		return nil, status.Errorf(codes.NotFound, "revision %d of %s not found", number, name)
+	}
+
+	var group string
+	var list []process.Processor
+	var err error
+
+	deadline := replaceDeadline(req.GetDeadline())
+	if target := c.m.Named(ns, name); target != "" {
+		group, list, err = c.m.Replace(target, rev.Start, deadline, int(req.GetSurge()))
+		if rErr := replaceError(err, target); rErr != nil {
+			return nil, rErr
+		}
+	} else {
+		group, list, err = c.m.Start(rev.Start)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	res := &cynosure.RollbackResponse{
+		Success:   err == nil,
+		Group:     group,
+		Processes: processes(list),
+	}
+	if err != nil {
+		res.Message = err.Error()
+	} else {
+		res.Revision = process.RecordRevision(ns, name, process.ActionRollback, peerIdentity(ctx), rev.Revision, rev.Start)
+	}
+	return res, nil
 }
 
-func (c *cynoHandler) Stop(context.Context, *cynosure.StopRequest) (*cynosure.StopResponse, error) {
-	panic("implement me")
+func (c *cynoHandler) Resume(_ context.Context, req *cynosure.SuspendRequest) (*cynosure.ScheduleResponse, error) {
+	schedule, err := c.s.Resume(req.GetNamespace(), req.GetName())
+	if err != nil {
+		return nil, scheduleError(err, req.GetName())
+	}
+
+	return &cynosure.ScheduleResponse{
+		Schedule: schedule,
+	}, nil
+}
+
+func (c *cynoHandler) Running(_ context.Context, req *cynosure.RunningRequest) (*cynosure.RunningResponse, error) {
+	return &cynosure.RunningResponse{
+		Processes: processes(c.m.List(req.GetFilters())),
+	}, nil
+}
+
+func (c *cynoHandler) Scale(_ context.Context, req *cynosure.ScaleRequest) (*cynosure.ScaleResponse, error) {
+	list, err := c.m.Scale(req.GetGroup(), int(req.GetReplicas()))
+	if err == process.ErrUnknownGroup {
+		return nil, status.Errorf(codes.NotFound, "process group %s not found", req.GetGroup())
+	}
+	if err == process.ErrReplacing {
+		return nil, status.Error(codes.Aborted, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &cynosure.ScaleResponse{
+		Processes: processes(list),
+	}, nil
+}
+
+func (c *cynoHandler) Schedule(_ context.Context, req *cynosure.ScheduleRequest) (*cynosure.ScheduleResponse, error) {
+	schedule, err := c.s.Schedule(req)
+	if err != nil {
+		return nil, scheduleError(err, req.GetName())
+	}
+
+	return &cynosure.ScheduleResponse{
+		Schedule: schedule,
+	}, nil
+}
+
+func (c *cynoHandler) Schedules(_ context.Context, req *cynosure.SchedulesRequest) (*cynosure.SchedulesResponse, error) {
+	return &cynosure.SchedulesResponse{
+		Schedules: c.s.List(req.GetNamespace()),
+	}, nil
+}
+
+func (c *cynoHandler) Start(ctx context.Context, req *cynosure.StartRequest) (*cynosure.StartResponse, error) {
+	group, list, err := c.m.Start(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	process.RecordRevision(req.GetNamespace(), req.GetCommand().GetName(), process.ActionStart, peerIdentity(ctx), 0, req)
+
+	res := &cynosure.StartResponse{
+		Group:     group,
+		Processes: processes(list),
+	}
+	if len(res.Processes) > 0 {
+		res.Process = res.Processes[0]
+	}
+	return res, nil
+}
+
+func (c *cynoHandler) Stop(_ context.Context, req *cynosure.StopRequest) (*cynosure.StopResponse, error) {
+	return &cynosure.StopResponse{
+		Success: c.m.Stop(req.GetIdentifier()),
+	}, nil
+}
+
+func (c *cynoHandler) Suspend(_ context.Context, req *cynosure.SuspendRequest) (*cynosure.ScheduleResponse, error) {
+	schedule, err := c.s.Suspend(req.GetNamespace(), req.GetName())
+	if err != nil {
+		return nil, scheduleError(err, req.GetName())
+	}
+
+	return &cynosure.ScheduleResponse{
+		Schedule: schedule,
+	}, nil
+}
+
+func (c *cynoHandler) Unschedule(_ context.Context, req *cynosure.UnscheduleRequest) (*cynosure.UnscheduleResponse, error) {
+	return &cynosure.UnscheduleResponse{
+		Success: c.s.Delete(req.GetNamespace(), req.GetName()),
+	}, nil
+}
+
+func (c *cynoHandler) process(id string) (process.Processor, error) {
+	p := c.m.Get(id)
+	if p == nil {
+		return nil, status.Errorf(codes.NotFound, "process %s not found", id)
+	}
+	return p, nil
+}
+
+// peerIdentity returns the common name of the client certificate used to make the request.
+//
+// Requests made through the HTTP gateway will have the identity of the server's own client certificate.
+func peerIdentity(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	if info, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+		if certs := info.State.PeerCertificates; len(certs) > 0 {
+			return certs[0].Subject.CommonName
+		}
+	}
+	return ""
+}
+
+func replaceDeadline(ms int64) time.Duration {
+	if ms <= 0 {
+		return defaultReplaceDeadline
+	}
+	return time.Duration(ms) * time.Millisecond
+}
+
+// targetName returns the namespace and name of the process, or of the group's processes, being replaced.
+func targetName(m process.ProcessManager, target string) (string, string) {
+	list := m.Group(target)
+	if p := m.Get(target); p != nil {
+		list = []process.Processor{p}
+	}
+	if len(list) == 0 {
+		return "", ""
+	}
+	return list[0].Namespace(), list[0].Name()
+}
+
+// replaceError converts an error from a replacement into an API error (not being ready is not an API error).
+func replaceError(err error, target string) error {
+	switch err {
+	case nil, process.ErrNotReady:
+		return nil
+	case process.ErrUnknownTarget:
+		return status.Errorf(codes.NotFound, "process or group %s not found", target)
+	case process.ErrReplacing:
+		return status.Error(codes.Aborted, err.Error())
+	default:
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+}
+
+// scheduleError converts an error from the scheduler into an API error.
+func scheduleError(err error, name string) error {
+	if err == process.ErrUnknownSchedule {
+		return status.Errorf(codes.NotFound, "schedule %s not found", name)
+	}
+	return status.Error(codes.InvalidArgument, err.Error())
+}
+
+func processes(list []process.Processor) []*cynosure.Process {
+	out := make([]*cynosure.Process, len(list))
+	for i, p := range list {
+		out[i] = p.Process()
+	}
+	return out
 }
@@ -0,0 +1,121 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/norganna/cynosure/deps"
+	_ "github.com/norganna/cynosure/deps/always"
+	"github.com/norganna/cynosure/process"
+	"github.com/norganna/cynosure/proto/cynosure"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestInfo(t *testing.T) {
+	err := deps.NewInstance("never", "", "always", map[string]string{"state": "false"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := process.NewProcessManager()
+	defer m.Quit()
+	c := &cynoHandler{m: m}
+
+	_, err = c.Info(context.Background(), &cynosure.InfoRequest{Identifier: "missing"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("got error %v for a missing process, want not found", err)
+	}
+
+	_, list, err := m.Start(&cynosure.StartRequest{
+		Namespace: "test",
+		Command: &cynosure.Command{
+			Name:  "waiter",
+			Entry: "/bin/sleep",
+			Args:  []string{"60"},
+			Requirements: map[string]*cynosure.Deps{
+				"never": {Deps: []*cynosure.Dep{{Identity: "never"}}, Deadline: 100},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Info tells that the process gave up waiting for its requirements.
+	var info *cynosure.Process
+	for end := time.Now().Add(5 * time.Second); time.Now().Before(end); time.Sleep(100 * time.Millisecond) {
+		res, err := c.Info(context.Background(), &cynosure.InfoRequest{Identifier: list[0].ID()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		info = res.GetProcess()
+		if info.GetState() != cynosure.Process_Running {
+			break
+		}
+	}
+
+	if info.GetState() != cynosure.Process_Failed {
+		t.Fatalf("got state %s, want %s", info.GetState(), cynosure.Process_Failed)
+	}
+	if !strings.Contains(info.GetExitMessage(), process.ErrRequirementsDeadline.Error()) {
+		t.Errorf("got exit message %q", info.GetExitMessage())
+	}
+}
+
+func TestReplaceRevision(t *testing.T) {
+	m := process.NewProcessManager()
+	defer m.Quit()
+	c := &cynoHandler{m: m}
+
+	start := func(name, arg string, replicas int32) *cynosure.StartRequest {
+		return &cynosure.StartRequest{
+			Namespace: "revisions",
+			Replicas:  replicas,
+			Command: &cynosure.Command{
+				Name:  name,
+				Entry: "/bin/sleep",
+				Args:  []string{arg},
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		target func(res *cynosure.StartResponse) string
+	}{
+		{"web", func(res *cynosure.StartResponse) string { return res.GetGroup() }},
+		{"api", func(res *cynosure.StartResponse) string { return res.GetProcesses()[1].GetIdentifier() }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := c.Start(context.Background(), start(tt.name, "60", 2))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Replacing with a differently named command is recorded in the history of the process being replaced.
+			replaced, err := c.Replace(context.Background(), &cynosure.ReplaceRequest{
+				Identifier: tt.target(res),
+				Start:      start(tt.name+"-next", "61", 0),
+			})
+			if err != nil || !replaced.GetSuccess() {
+				t.Fatalf("got %v %v", replaced, err)
+			}
+
+			list := process.History("revisions", tt.name)
+			if len(list) != 2 {
+				t.Fatalf("got %d revisions, want 2", len(list))
+			}
+			if rev := list[1]; rev.Action != process.ActionReplace || rev.Start.GetCommand().GetName() != tt.name+"-next" {
+				t.Errorf("got %s of %s", rev.Action, rev.Start.GetCommand().GetName())
+			}
+			if others := process.History("revisions", tt.name+"-next"); len(others) != 0 {
+				t.Errorf("got %d revisions under the replacement's name", len(others))
+			}
+		})
+	}
+}
@@ -7,9 +7,15 @@ import (
 	_ "github.com/norganna/cynosure/deps/always" // Plugin.
 	_ "github.com/norganna/cynosure/deps/consul" // Plugin.
 	_ "github.com/norganna/cynosure/deps/cyno"   // Plugin.
+	_ "github.com/norganna/cynosure/deps/dns"    // Plugin.
 	_ "github.com/norganna/cynosure/deps/etcd"   // Plugin.
+	_ "github.com/norganna/cynosure/deps/exec"   // Plugin.
+	_ "github.com/norganna/cynosure/deps/file"   // Plugin.
+	_ "github.com/norganna/cynosure/deps/grpc"   // Plugin.
 	_ "github.com/norganna/cynosure/deps/http"   // Plugin.
 	_ "github.com/norganna/cynosure/deps/kube"   // Plugin.
 	_ "github.com/norganna/cynosure/deps/port"   // Plugin.
+	_ "github.com/norganna/cynosure/deps/tcp"    // Plugin.
+	_ "github.com/norganna/cynosure/deps/unix"   // Plugin.
 	_ "github.com/norganna/cynosure/deps/wait"   // Plugin.
 )